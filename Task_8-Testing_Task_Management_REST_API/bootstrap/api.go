@@ -0,0 +1,16 @@
+package bootstrap
+
+import "time"
+
+// LegacyRoutesDeprecatedAt returns the date since which the unversioned routes are deprecated.
+// NewEnv already rejects an invalid date.
+func (env *Env) LegacyRoutesDeprecatedAt() time.Time {
+	date, _ := time.Parse(time.DateOnly, env.LegacyDeprecatedAt)
+	return date
+}
+
+// LegacyRoutesSunset returns the date the unversioned routes stop being served.
+func (env *Env) LegacyRoutesSunset() time.Time {
+	date, _ := time.Parse(time.DateOnly, env.LegacySunset)
+	return date
+}
@@ -1,19 +1,148 @@
 package bootstrap
 
-import "go.mongodb.org/mongo-driver/mongo"
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/usecases"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Application holds what the API is made of. Its components are started in the order they are
+// registered and stopped in the reverse order: the tracing and the database first and last, then
+// the migrations, the background workers, and the HTTP server last and first, so that no request
+// is served without them.
+// Health answers the probes, checking the database and any other registered dependency.
 type Application struct {
-	Env   *Env
-	Mongo *mongo.Client
+	Env    *Env
+	Logger *slog.Logger
+	Mongo  *mongo.Client
+	Health domain.HealthUsecase
+	// Migrator migrates the database, when the application starts unless MIGRATE_ON_STARTUP is false
+	Migrator domain.Migrator
+
+	lifecycle lifecycle
+	failures  chan error
 }
 
-func App() Application {
-	app := &Application{}
-	app.Env = NewEnv()
+// App loads the configuration, parsing args as command line flags, and creates the application.
+func App(args ...string) *Application {
+	app := &Application{failures: make(chan error, 1)}
+	app.Env = NewEnv(args...)
+	app.Logger = NewLogger(app.Env)
+
+	// the tracer provider is stopped last, to export the spans of everything stopped before it
+	if tracing := NewTracing(app.Env); tracing != nil {
+		app.Register("tracing", tracing)
+	}
+
 	app.Mongo = NewMongoDBClient(app.Env)
-	return *app
+	app.Health = usecases.NewHealthUsecase(time.Duration(app.Env.HealthCheckTimeoutSec) * time.Second)
+
+	mongoDB := mongoDBComponent{
+		client:   app.Mongo,
+		attempts: app.Env.DBConnectAttempts,
+		backoff:  time.Duration(app.Env.DBConnectBackoffSec) * time.Second,
+	}
+	app.Register("MongoDB", mongoDB)
+	app.Health.Register("mongodb", mongoDB)
+
+	app.Migrator = NewMigrator(app.Env, app.Mongo)
+	if app.Env.MigrateOnStartup {
+		app.Register("migrations", migrationComponent{
+			migrator: app.Migrator,
+			timeout:  time.Duration(app.Env.MigrationTimeoutSec) * time.Second,
+		})
+	}
+	return app
+}
+
+// Register adds a component, such as a background worker, to be started after the ones already registered.
+func (app *Application) Register(name string, component Component) {
+	app.lifecycle.register(name, component)
+}
+
+// Serve registers the HTTP server, which should be the last component.
+func (app *Application) Serve(server *http.Server) {
+	app.Register("HTTP server on "+server.Addr, &serverComponent{server: server, failed: app.fail})
+}
+
+// fail stops a running application after a component failed in the background.
+func (app *Application) fail(err error) {
+	select {
+	case app.failures <- err:
+	default:
+		// the application is already stopping
+	}
+}
+
+// Start starts the components in the order they were registered.
+func (app *Application) Start(c context.Context) error {
+	return app.lifecycle.start(c)
 }
 
-func (app *Application) CloseMongoDBConnection() {
-	CloseMongoDBClient(app.Mongo)
+// Stop stops the started components in the reverse order, giving them until the context is done.
+// The readiness probe fails from then on.
+func (app *Application) Stop(c context.Context) error {
+	if app.Health != nil {
+		app.Health.Drain()
+	}
+
+	return app.lifecycle.stop(c)
+}
+
+// Run starts the application and blocks until it is interrupted with SIGINT or SIGTERM, or
+// a component fails. It then fails the readiness probe and keeps serving for SHUTDOWN_DELAY_SECONDS, so that
+// the load balancer stops sending requests, before stopping the application, giving the requests in flight
+// and the workers SHUTDOWN_TIMEOUT_SECONDS to complete.
+func (app *Application) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutting down")
+	case err = <-app.failures:
+		slog.Error("Shutting down after a failure", "error", err)
+	}
+	// a second signal kills the process right away
+	stop()
+
+	app.Health.Drain()
+	time.Sleep(time.Duration(app.Env.ShutdownDelaySec) * time.Second)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(app.Env.ShutdownTimeoutSec)*time.Second)
+	defer cancel()
+
+	return errors.Join(err, app.Stop(shutdownCtx))
+}
+
+// RunCommand starts the application, runs a command talking to the storage, such as a migration,
+// then stops the application, without serving requests. SIGINT and SIGTERM cancel the command.
+func (app *Application) RunCommand(command func(c context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Start(ctx); err != nil {
+		return err
+	}
+
+	err := command(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(app.Env.ShutdownTimeoutSec)*time.Second)
+	defer cancel()
+
+	return errors.Join(err, app.Stop(shutdownCtx))
 }
@@ -2,50 +2,192 @@ package bootstrap
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 )
 
+// mongoDBPingTimeout bounds each attempt to reach MongoDB when the application starts.
+const mongoDBPingTimeout = 10 * time.Second
+
+// NewMongoDBClient creates the client of the database. It only connects once the application starts,
+// when the database is checked as the first component of the application.
 func NewMongoDBClient(env *Env) *mongo.Client {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	clientOptions, err := mongoDBOptions(env)
+	if err != nil {
+		fatal("invalid MongoDB configuration", "error", err)
+	}
 
-	dbHost := env.DBHost
-	dbPort := env.DBPort
+	// create the client, which connects in the background
+	client, err := mongo.Connect(context.Background(), clientOptions)
 
-	// set client options
-	URI := fmt.Sprintf("mongodb://%v:%v", dbHost, dbPort)
-	clientOptions := options.Client().ApplyURI(URI)
+	if err != nil {
+		fatal("failed to create the MongoDB client", "error", err)
+	}
 
-	// connect to MongoDB
-	client, err := mongo.Connect(ctx, clientOptions)
+	return client
+}
 
-	if err != nil {
-		log.Fatal(err)
+// mongoDBOptions builds the options of the client from DB_URI, or DB_HOST and DB_PORT when it is not set,
+// then from the discrete settings, which take precedence over the options of the URI when they are set.
+func mongoDBOptions(env *Env) (*options.ClientOptions, error) {
+	uri := env.DBURI
+	if uri == "" {
+		uri = "mongodb://" + net.JoinHostPort(env.DBHost, env.DBPort)
 	}
 
-	// check the connection
-	err = client.Ping(ctx, nil)
-	if err != nil {
-		log.Fatal(err)
+	// every command is traced as a child of the span of the usecase calling the repository
+	clientOptions := options.Client().ApplyURI(uri).SetMonitor(otelmongo.NewMonitor())
+
+	if env.DBUsername != "" || env.DBPassword != "" || env.DBAuthSource != "" || env.DBAuthMechanism != "" {
+		var credential options.Credential
+		if clientOptions.Auth != nil {
+			credential = *clientOptions.Auth
+		}
+		if env.DBUsername != "" {
+			credential.Username = env.DBUsername
+		}
+		if env.DBPassword != "" {
+			credential.Password = env.DBPassword
+			credential.PasswordSet = true
+		}
+		if env.DBAuthSource != "" {
+			credential.AuthSource = env.DBAuthSource
+		}
+		if env.DBAuthMechanism != "" {
+			credential.AuthMechanism = env.DBAuthMechanism
+		}
+		clientOptions.SetAuth(credential)
 	}
 
-	return client
+	if env.DBTLS || env.DBTLSCAFile != "" || env.DBTLSCertFile != "" {
+		tlsConfig, err := mongoDBTLSConfig(env)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+
+	if env.DBReplicaSet != "" {
+		clientOptions.SetReplicaSet(env.DBReplicaSet)
+	}
+	if env.DBReadConcern != "" {
+		clientOptions.SetReadConcern(&readconcern.ReadConcern{Level: env.DBReadConcern})
+	}
+	if env.DBWriteConcern != "" {
+		concern := &writeconcern.WriteConcern{W: env.DBWriteConcern}
+		if nodes, err := strconv.Atoi(env.DBWriteConcern); err == nil {
+			concern.W = nodes
+		}
+		clientOptions.SetWriteConcern(concern)
+	}
+
+	if env.DBMaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(uint64(env.DBMaxPoolSize))
+	}
+	if env.DBMinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(uint64(env.DBMinPoolSize))
+	}
+	if env.DBMaxConnIdleSec > 0 {
+		clientOptions.SetMaxConnIdleTime(time.Duration(env.DBMaxConnIdleSec) * time.Second)
+	}
+	if env.DBConnectTimeoutSec > 0 {
+		clientOptions.SetConnectTimeout(time.Duration(env.DBConnectTimeoutSec) * time.Second)
+	}
+	if env.DBServerSelectionSec > 0 {
+		clientOptions.SetServerSelectionTimeout(time.Duration(env.DBServerSelectionSec) * time.Second)
+	}
+
+	if err := clientOptions.Validate(); err != nil {
+		return nil, err
+	}
+
+	return clientOptions, nil
 }
 
-func CloseMongoDBClient(client *mongo.Client) {
-	if client == nil {
-		return
+// mongoDBTLSConfig returns the TLS configuration of the connection, trusting DB_TLS_CA_FILE, if set,
+// rather than the system certificates, and authenticating with DB_TLS_CERT_FILE and DB_TLS_KEY_FILE, if set.
+func mongoDBTLSConfig(env *Env) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if env.DBTLSCAFile != "" {
+		ca, err := os.ReadFile(env.DBTLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DB_TLS_CA_FILE: %w", err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no PEM certificate found in DB_TLS_CA_FILE %v", env.DBTLSCAFile)
+		}
 	}
 
-	err := client.Disconnect(context.TODO())
-	if err != nil {
-		log.Fatal(err)
+	if env.DBTLSCertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(env.DBTLSCertFile, env.DBTLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load DB_TLS_CERT_FILE and DB_TLS_KEY_FILE: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
 	}
 
-	fmt.Println("Connection to MongoDB closed.")
+	return tlsConfig, nil
+}
+
+// mongoDBComponent checks the connection to MongoDB when the application starts and when its readiness
+// is probed, and closes it once every other component has stopped.
+// MongoDB may be briefly unavailable when the application starts, such as when both are started together,
+// so it is tried attempts times, waiting backoff after the first failure and twice as long after each next one.
+type mongoDBComponent struct {
+	client   *mongo.Client
+	attempts int
+	backoff  time.Duration
+}
+
+func (component mongoDBComponent) Start(c context.Context) error {
+	// check the connection
+	return retryWithBackoff(c, component.attempts, component.backoff, func(c context.Context) error {
+		ctx, cancel := context.WithTimeout(c, mongoDBPingTimeout)
+		defer cancel()
+
+		return component.Check(ctx)
+	})
+}
+
+func (component mongoDBComponent) Check(c context.Context) error {
+	return component.client.Ping(c, nil)
+}
+
+func (component mongoDBComponent) Stop(c context.Context) error {
+	return component.client.Disconnect(c)
+}
+
+// retryWithBackoff calls operation until it succeeds, up to attempts times, doubling the delay between
+// the attempts from backoff. It gives up early when the context is done, returning the last error.
+func retryWithBackoff(c context.Context, attempts int, backoff time.Duration, operation func(c context.Context) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = operation(c); err == nil || attempt >= attempts {
+			return err
+		}
+
+		slog.Warn("Retrying after a failed attempt", "attempt", attempt, "attempts", attempts, "retry_in", backoff, "error", err)
+		select {
+		case <-c.Done():
+			return errors.Join(err, c.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
 }
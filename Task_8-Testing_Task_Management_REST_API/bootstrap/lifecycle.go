@@ -0,0 +1,118 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Component is a part of the application with a lifetime of its own, such as the database,
+// a background worker or the HTTP server. Start must not block once the component is running,
+// and Stop must return once the component has stopped, or when its context is done.
+type Component interface {
+	Start(c context.Context) error
+	Stop(c context.Context) error
+}
+
+// Worker runs a function in the background, as a component of the application.
+// The function is given a context that is cancelled when the application stops, and must return then.
+type Worker struct {
+	run    func(c context.Context)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewWorker(run func(c context.Context)) *Worker {
+	return &Worker{run: run}
+}
+
+func (worker *Worker) Start(c context.Context) error {
+	// the worker outlives the context it is started with
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c))
+	worker.cancel = cancel
+	worker.done = make(chan struct{})
+
+	go func() {
+		defer close(worker.done)
+		worker.run(ctx)
+	}()
+
+	return nil
+}
+
+func (worker *Worker) Stop(c context.Context) error {
+	worker.cancel()
+
+	select {
+	case <-worker.done:
+		return nil
+	case <-c.Done():
+		return c.Err()
+	}
+}
+
+// namedComponent is a component registered with the application, named in the logs and errors.
+type namedComponent struct {
+	name      string
+	component Component
+}
+
+// lifecycle starts components in the order they are registered and stops them in the reverse order,
+// so that a component can rely on the ones registered before it for as long as it runs.
+type lifecycle struct {
+	mu         sync.Mutex
+	components []namedComponent
+	started    int
+}
+
+func (l *lifecycle) register(name string, component Component) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.components = append(l.components, namedComponent{name: name, component: component})
+}
+
+// start starts the components that are not started yet. When one of them fails,
+// the ones already started are stopped again before the error is returned.
+func (l *lifecycle) start(c context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.started < len(l.components) {
+		named := l.components[l.started]
+		if err := named.component.Start(c); err != nil {
+			return errors.Join(fmt.Errorf("failed to start %v: %w", named.name, err), l.stopStarted(c))
+		}
+
+		slog.Info("Started component", "component", named.name)
+		l.started++
+	}
+
+	return nil
+}
+
+// stop stops the started components, the last started first. Every component is stopped
+// even if another one fails to, and the errors are returned together.
+func (l *lifecycle) stop(c context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.stopStarted(c)
+}
+
+func (l *lifecycle) stopStarted(c context.Context) error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		named := l.components[l.started-1]
+		if err := named.component.Stop(c); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %v: %w", named.name, err))
+			continue
+		}
+
+		slog.Info("Stopped component", "component", named.name)
+	}
+
+	return errors.Join(errs...)
+}
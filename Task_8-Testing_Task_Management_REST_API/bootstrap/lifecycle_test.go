@@ -0,0 +1,163 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// recordingComponent records when it is started and stopped in the shared events.
+type recordingComponent struct {
+	name     string
+	events   *[]string
+	startErr error
+	stopErr  error
+}
+
+func (component *recordingComponent) Start(c context.Context) error {
+	if component.startErr != nil {
+		return component.startErr
+	}
+
+	*component.events = append(*component.events, "start "+component.name)
+	return nil
+}
+
+func (component *recordingComponent) Stop(c context.Context) error {
+	*component.events = append(*component.events, "stop "+component.name)
+	return component.stopErr
+}
+
+type LifecycleSuite struct {
+	suite.Suite
+	events []string
+	app    *Application
+}
+
+func (suite *LifecycleSuite) SetupTest() {
+	suite.events = nil
+	suite.app = &Application{failures: make(chan error, 1)}
+}
+
+func (suite *LifecycleSuite) register(name string, startErr error, stopErr error) {
+	suite.app.Register(name, &recordingComponent{name: name, events: &suite.events, startErr: startErr, stopErr: stopErr})
+}
+
+func (suite *LifecycleSuite) TestStartAndStop_InOrder() {
+	suite.register("database", nil, nil)
+	suite.register("worker", nil, nil)
+	suite.register("server", nil, nil)
+
+	suite.NoError(suite.app.Start(context.Background()))
+	suite.NoError(suite.app.Stop(context.Background()))
+
+	suite.Equal([]string{
+		"start database", "start worker", "start server",
+		"stop server", "stop worker", "stop database",
+	}, suite.events)
+}
+
+func (suite *LifecycleSuite) TestStart_FailureStopsStartedComponents() {
+	suite.register("database", nil, nil)
+	suite.register("worker", nil, nil)
+	suite.register("server", errors.New("address already in use"), nil)
+
+	err := suite.app.Start(context.Background())
+
+	suite.ErrorContains(err, "failed to start server: address already in use")
+	suite.Equal([]string{"start database", "start worker", "stop worker", "stop database"}, suite.events)
+
+	// nothing is left to stop
+	suite.NoError(suite.app.Stop(context.Background()))
+	suite.Len(suite.events, 4)
+}
+
+func (suite *LifecycleSuite) TestStop_StopsEveryComponentDespiteFailures() {
+	suite.register("database", nil, nil)
+	suite.register("worker", nil, errors.New("stuck"))
+	suite.register("server", nil, nil)
+	suite.NoError(suite.app.Start(context.Background()))
+
+	err := suite.app.Stop(context.Background())
+
+	suite.ErrorContains(err, "failed to stop worker: stuck")
+	suite.Equal([]string{"stop server", "stop worker", "stop database"}, suite.events[3:])
+}
+
+func (suite *LifecycleSuite) TestWorker_StopsWithTheApplication() {
+	stopped := make(chan struct{})
+	suite.app.Register("worker", NewWorker(func(c context.Context) {
+		<-c.Done()
+		close(stopped)
+	}))
+
+	suite.NoError(suite.app.Start(context.Background()))
+	suite.NoError(suite.app.Stop(context.Background()))
+
+	select {
+	case <-stopped:
+	default:
+		suite.Fail("the worker should have returned before Stop")
+	}
+}
+
+func (suite *LifecycleSuite) TestWorker_StopDeadline() {
+	release := make(chan struct{})
+	defer close(release)
+	suite.app.Register("worker", NewWorker(func(c context.Context) {
+		<-release
+	}))
+	suite.NoError(suite.app.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	suite.ErrorIs(suite.app.Stop(ctx), context.DeadlineExceeded)
+}
+
+func (suite *LifecycleSuite) TestServer_DrainsRequestsInFlight() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+	suite.app.Serve(&http.Server{Addr: address, Handler: handler})
+	suite.Require().NoError(suite.app.Start(context.Background()))
+
+	body := make(chan string, 1)
+	go func() {
+		response, err := http.Get("http://" + address)
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		defer response.Body.Close()
+		content, _ := io.ReadAll(response.Body)
+		body <- string(content)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	suite.NoError(suite.app.Stop(ctx))
+
+	suite.Equal("done", <-body)
+	_, err = http.Get("http://" + address)
+	suite.Error(err, "the server should not accept connections once stopped")
+}
+
+func TestLifecycleSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleSuite))
+}
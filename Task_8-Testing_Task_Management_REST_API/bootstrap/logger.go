@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// NewLogger creates the logger of the application, writing LOG_FORMAT records from LOG_LEVEL up
+// to the standard error, with secrets redacted. It becomes the default logger, which the
+// standard log package writes to as well.
+func NewLogger(env *Env) *slog.Logger {
+	logger := slog.New(newLogHandler(env, os.Stderr))
+	slog.SetDefault(logger)
+
+	return logger
+}
+
+func newLogHandler(env *Env, w io.Writer) slog.Handler {
+	// NewEnv already rejects an invalid level
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(env.LogLevel))
+
+	options := &slog.HandlerOptions{Level: level, ReplaceAttr: infrastructure.RedactSecrets}
+	if env.LogFormat == "json" {
+		return slog.NewJSONHandler(w, options)
+	}
+
+	return slog.NewTextHandler(w, options)
+}
+
+// fatal logs why the application can't start and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
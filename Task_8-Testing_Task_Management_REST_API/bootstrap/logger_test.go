@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggerSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggerSuite) TestJSON() {
+	var output bytes.Buffer
+	logger := slog.New(newLogHandler(&Env{LogLevel: "warn", LogFormat: "json"}, &output))
+
+	logger.Info("hidden")
+	logger.Warn("shown", "password", "hunter2")
+
+	suite.JSONEq(`{"level": "WARN", "msg": "shown", "password": "[REDACTED]", "time": "`+timeOf(suite, output.Bytes())+`"}`, output.String())
+}
+
+func (suite *LoggerSuite) TestText() {
+	var output bytes.Buffer
+	logger := slog.New(newLogHandler(&Env{LogLevel: "debug", LogFormat: "text"}, &output))
+
+	logger.Debug("shown", "user_id", "1")
+
+	suite.Contains(output.String(), `level=DEBUG msg=shown user_id=1`)
+}
+
+// timeOf extracts the time of a JSON record, which the test can't know in advance.
+func timeOf(suite *LoggerSuite, record []byte) string {
+	start := bytes.Index(record, []byte(`"time":"`)) + len(`"time":"`)
+	end := bytes.IndexByte(record[start:], '"')
+	suite.Require().True(end > 0)
+
+	return string(record[start : start+end])
+}
+
+func TestLoggerSuite(t *testing.T) {
+	suite.Run(t, new(LoggerSuite))
+}
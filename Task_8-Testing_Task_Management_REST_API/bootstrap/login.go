@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"time"
+)
+
+// LoginPolicy returns how failed logins are throttled, as configured by the LOGIN_* variables.
+func (env *Env) LoginPolicy() domain.LoginPolicy {
+	return domain.LoginPolicy{
+		MaxAccountFailures: env.LoginMaxAccountFails,
+		MaxIPFailures:      env.LoginMaxIPFails,
+		Lockout:            time.Duration(env.LoginLockoutMinute) * time.Minute,
+		MaxDelay:           time.Duration(env.LoginMaxDelaySec) * time.Second,
+	}
+}
+
+// LoginChallengeTTL returns how long users with two-factor authentication have to enter their code after their password.
+func (env *Env) LoginChallengeTTL() time.Duration {
+	return time.Duration(env.LoginChallengeMinute) * time.Minute
+}
+
+// TwoFactorRequiredRoles returns the roles whose users have to enable two-factor authentication,
+// which is only the 'ADMIN' role when REQUIRE_ADMIN_TWO_FACTOR is set.
+func (env *Env) TwoFactorRequiredRoles() []string {
+	if env.RequireAdminTwoFactor {
+		return []string{domain.RoleAdmin}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"strings"
+	"time"
+)
+
+// NewMailer creates the mailer used to send emails to users.
+// Only writing the emails to MAIL_FILE, or to the standard output when it is empty, is supported for now.
+func NewMailer(env *Env) domain.Mailer {
+	mailer, err := infrastructure.NewFileMailer(env.MailFile, env.MailFrom)
+	if err != nil {
+		fatal("failed to create the mailer", "error", err)
+	}
+
+	return mailer
+}
+
+// PasswordResetTokenTTL returns how long a password reset token stays valid.
+func (env *Env) PasswordResetTokenTTL() time.Duration {
+	return time.Duration(env.PasswordResetTTL) * time.Minute
+}
+
+// BaseURL returns the URL the links sent to users point to, which is APP_BASE_URL
+// or, when it is not set, the server address.
+func (env *Env) BaseURL() string {
+	if env.AppBaseURL != "" {
+		return strings.TrimSuffix(env.AppBaseURL, "/")
+	}
+
+	return "http://" + env.ServerAddress
+}
+
+// EmailVerificationTokenTTL returns how long an email verification link stays valid.
+func (env *Env) EmailVerificationTokenTTL() time.Duration {
+	return time.Duration(env.VerificationTTLHour) * time.Hour
+}
+
+// EmailVerificationResendInterval returns how long a user has to wait before another verification link can be sent.
+func (env *Env) EmailVerificationResendInterval() time.Duration {
+	return time.Duration(env.VerificationResendSec) * time.Second
+}
@@ -0,0 +1,34 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/repository"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewMigrator creates the migrator of the database of the application.
+func NewMigrator(env *Env, client *mongo.Client) domain.Migrator {
+	return repository.NewMigrator(*client.Database(env.DBName), domain.CollectionMigration, repository.Migrations)
+}
+
+// migrationComponent applies the migrations not applied yet when the application starts, right after
+// MongoDB is reachable, giving up after timeout, which includes waiting for another instance applying them.
+type migrationComponent struct {
+	migrator domain.Migrator
+	timeout  time.Duration
+}
+
+func (component migrationComponent) Start(c context.Context) error {
+	ctx, cancel := context.WithTimeout(c, component.timeout)
+	defer cancel()
+
+	_, err := component.migrator.Migrate(ctx)
+	return err
+}
+
+func (component migrationComponent) Stop(c context.Context) error {
+	return nil
+}
@@ -0,0 +1,40 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+)
+
+// NewPasswordHasher creates the hasher of PASSWORD_HASH_ALGORITHM, tuned by the ARGON2_* variables or BCRYPT_COST.
+// Hashes made with another algorithm or other parameters are upgraded the next time their user logs in.
+func NewPasswordHasher(env *Env) domain.PasswordHasher {
+	if env.PasswordHashAlgorithm == infrastructure.PasswordAlgorithmBcrypt {
+		return infrastructure.NewBcryptHasher(env.BcryptCost)
+	}
+
+	params := infrastructure.DefaultArgon2idParams
+	params.Memory = uint32(env.Argon2MemoryKiB)
+	params.Iterations = uint32(env.Argon2Iterations)
+	params.Parallelism = uint8(env.Argon2Parallelism)
+
+	return infrastructure.NewArgon2idHasher(params)
+}
+
+// NewPasswordPolicy creates the policy new passwords have to comply with, refusing the passwords
+// listed in the PASSWORD_BREACHED_LIST file when it is set.
+func NewPasswordPolicy(env *Env) domain.PasswordPolicy {
+	policy := domain.PasswordPolicy{
+		MinLength: env.PasswordMinLength,
+		MaxLength: env.PasswordMaxLength,
+	}
+
+	if env.PasswordBreachedList != "" {
+		breached, err := infrastructure.LoadPasswordList(env.PasswordBreachedList)
+		if err != nil {
+			fatal("failed to load PASSWORD_BREACHED_LIST", "error", err)
+		}
+		policy.Breached = breached
+	}
+
+	return policy
+}
@@ -0,0 +1,32 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewRateLimitStore creates the store selected by RATE_LIMIT_STORE: 'memory' keeps the buckets in the process,
+// while 'mongo' shares them through the database between every replica of the API.
+func NewRateLimitStore(env *Env, database mongo.Database) domain.RateLimitStore {
+	if env.RateLimitStore == "mongo" {
+		return repository.NewRateLimitRepo(database, domain.CollectionRateLimit)
+	}
+
+	return infrastructure.NewMemoryRateLimitStore()
+}
+
+// PublicRateLimit returns the limit of the routes that don't require authentication, per client IP.
+// NewEnv already rejects an invalid configuration.
+func (env *Env) PublicRateLimit() domain.RateLimit {
+	limit, _ := domain.ParseRateLimit(env.RateLimitPublic)
+	return limit
+}
+
+// ProtectedRateLimit returns the limit of the routes that require authentication, per user.
+func (env *Env) ProtectedRateLimit() domain.RateLimit {
+	limit, _ := domain.ParseRateLimit(env.RateLimitProtected)
+	return limit
+}
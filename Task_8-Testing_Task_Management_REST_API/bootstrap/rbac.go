@@ -0,0 +1,16 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+)
+
+// Roles returns the roles configured in ROLE_PERMISSIONS, or the default roles when it is not set.
+// NewEnv already rejects an invalid configuration.
+func (env *Env) Roles() domain.Roles {
+	roles, err := domain.ParseRoles(env.RolePermissions)
+	if err != nil {
+		return domain.DefaultRoles()
+	}
+
+	return roles
+}
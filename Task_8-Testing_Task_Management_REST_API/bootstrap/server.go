@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NewHTTPServer creates the HTTP server of the API, listening on SERVER_ADDRESS.
+// The timeouts keep slow or idle clients from holding connections forever.
+func NewHTTPServer(env *Env, handler http.Handler) *http.Server {
+	readTimeout := time.Duration(env.ServerReadTimeoutSec) * time.Second
+
+	return &http.Server{
+		Addr:              env.ServerAddress,
+		Handler:           handler,
+		ReadTimeout:       readTimeout,
+		ReadHeaderTimeout: readTimeout,
+		WriteTimeout:      time.Duration(env.ServerWriteTimeoutSec) * time.Second,
+		IdleTimeout:       time.Duration(env.ServerIdleTimeoutSec) * time.Second,
+	}
+}
+
+// serverComponent serves HTTP requests as a component of the application.
+// It stops accepting connections when it is stopped, and waits for the requests in flight to complete.
+type serverComponent struct {
+	server *http.Server
+	failed func(err error)
+}
+
+func (component *serverComponent) Start(c context.Context) error {
+	// listen before returning, so that an address already in use fails the start
+	listener, err := net.Listen("tcp", component.server.Addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := component.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			component.failed(err)
+		}
+	}()
+
+	return nil
+}
+
+func (component *serverComponent) Stop(c context.Context) error {
+	err := component.server.Shutdown(c)
+	if err != nil {
+		// the deadline passed, drop the requests still in flight
+		return errors.Join(err, component.server.Close())
+	}
+
+	return nil
+}
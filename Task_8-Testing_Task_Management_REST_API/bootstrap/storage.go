@@ -0,0 +1,23 @@
+package bootstrap
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"strings"
+)
+
+// NewBlobStorage creates the storage used for the content of task attachments.
+// Only the local filesystem, rooted at ATTACHMENT_DIR, is supported for now.
+func NewBlobStorage(env *Env) domain.BlobStorage {
+	storage, err := infrastructure.NewLocalBlobStorage(env.AttachmentDir)
+	if err != nil {
+		fatal("failed to create the attachment storage", "error", err)
+	}
+
+	return storage
+}
+
+// AllowedAttachmentTypes returns the MIME types listed in ATTACHMENT_ALLOWED_TYPES.
+func (env *Env) AllowedAttachmentTypes() []string {
+	return strings.Split(env.AttachmentTypes, ",")
+}
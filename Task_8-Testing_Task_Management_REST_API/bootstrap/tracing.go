@@ -0,0 +1,90 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// TracingServiceName names the API in the traces, unless OTEL_SERVICE_NAME is set.
+const TracingServiceName = "task-manager"
+
+// NewTracing sets up OpenTelemetry: the W3C trace context of the incoming requests is always
+// propagated, and the spans are exported as TRACING_EXPORTER says. 'stdout' and 'file' write them
+// as JSON, for local use, and 'otlp' sends them to the collector configured with the standard
+// OTEL_EXPORTER_OTLP_* variables. It returns nil when the spans are not exported.
+func NewTracing(env *Env) Component {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var file io.Closer
+	var err error
+	switch env.TracingExporter {
+	case "none":
+		return nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "file":
+		var f *os.File
+		f, err = os.OpenFile(env.TracingFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err == nil {
+			file = f
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		}
+	case "otlp":
+		exporter, err = otlptracehttp.New(context.Background())
+	}
+	if err != nil {
+		fatal("failed to create the trace exporter", "exporter", env.TracingExporter, "error", err)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(TracingServiceName)),
+	)
+	if err != nil {
+		fatal("failed to describe the service in the traces", "error", err)
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence
+	res, err = resource.Merge(res, resource.Environment())
+	if err != nil {
+		fatal("failed to describe the service in the traces", "error", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(env.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return &tracingComponent{provider: provider, file: file}
+}
+
+// tracingComponent flushes the spans still buffered when the application stops.
+type tracingComponent struct {
+	provider *sdktrace.TracerProvider
+	file     io.Closer
+}
+
+func (component *tracingComponent) Start(c context.Context) error {
+	return nil
+}
+
+func (component *tracingComponent) Stop(c context.Context) error {
+	err := component.provider.Shutdown(c)
+	if component.file != nil {
+		err = errors.Join(err, component.file.Close())
+	}
+
+	return err
+}
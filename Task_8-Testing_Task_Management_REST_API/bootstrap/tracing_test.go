@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+)
+
+type TracingSuite struct {
+	suite.Suite
+}
+
+func (suite *TracingSuite) TestNone() {
+	suite.Nil(NewTracing(&Env{TracingExporter: "none"}))
+}
+
+func (suite *TracingSuite) TestFile_FlushedOnStop() {
+	file := filepath.Join(suite.T().TempDir(), "traces.json")
+	tracing := NewTracing(&Env{TracingExporter: "file", TracingFile: file, TracingSampleRatio: 1})
+	suite.Require().NotNil(tracing)
+	suite.NoError(tracing.Start(context.Background()))
+
+	_, span := otel.Tracer("test").Start(context.Background(), "TaskUsecase.GetTasks")
+	span.End()
+
+	// the spans are batched, and only written once the application stops
+	suite.NoError(tracing.Stop(context.Background()))
+
+	content, err := os.ReadFile(file)
+	suite.NoError(err)
+	suite.Contains(string(content), `"Name":"TaskUsecase.GetTasks"`)
+	suite.Contains(string(content), TracingServiceName)
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/delivery/command"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/repository"
+	"Task_8-Testing_Task_Management_REST_API/usecases"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// The administrative CLI runs one command against the configured storage, reusing the configuration
+// and the usecases of the server, such as creating the first admin. See command.Usage.
+func main() {
+	configArgs, args := command.SplitArgs(os.Args[1:])
+
+	switch {
+	case len(args) == 2 && args[0] == "config" && args[1] == "print":
+		if err := command.PrintConfig(os.Stdout, configArgs); err != nil {
+			slog.Error("invalid configuration", "error", err)
+			os.Exit(1)
+		}
+		return
+	case len(args) == 2 && args[0] == "config" && args[1] == "check":
+		// starting the application validates the configuration, then connects to MongoDB
+		app := bootstrap.App(adminArgs(configArgs)...)
+		if err := app.RunCommand(func(c context.Context) error { return nil }); err != nil {
+			app.Logger.Error("MongoDB can't be reached", "error", err)
+			os.Exit(1)
+		}
+		fmt.Println("The configuration is valid and MongoDB can be reached")
+		return
+	case !command.NeedsStorage(args):
+		fmt.Fprint(os.Stderr, command.Usage)
+		os.Exit(2)
+	}
+
+	app := bootstrap.App(adminArgs(configArgs)...)
+	commands := newCommands(app)
+
+	err := app.RunCommand(func(c context.Context) error {
+		return commands.Run(c, args)
+	})
+	if errors.Is(err, command.ErrUsage) {
+		fmt.Fprintf(os.Stderr, "%v\n\n%v", err, command.Usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		app.Logger.Error("The command failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// adminArgs leaves the logs of the application starting and stopping out, unless the config flags ask for them,
+// and leaves the migrations to the 'migrate' command.
+func adminArgs(configArgs []string) []string {
+	args := append([]string{"--log-level=warn"}, configArgs...)
+	return append(args, "--migrate-on-startup=false")
+}
+
+func newCommands(app *bootstrap.Application) *command.Commands {
+	env := app.Env
+	timeout := time.Duration(env.ContextTimeout) * time.Second
+	database := *app.Mongo.Database(env.DBName)
+
+	userRepo := repository.NewUserRepo(database, domain.CollectionUser)
+	taskRepo := repository.NewTaskRepo(database, domain.CollectionTask)
+	projectRepo := repository.NewProjectRepo(database, domain.CollectionProject)
+	commentRepo := repository.NewCommentRepo(database, domain.CollectionComment)
+	roles := env.Roles()
+
+	return &command.Commands{
+		UserUsecase: usecases.NewUserUsecase(
+			userRepo,
+			taskRepo,
+			projectRepo,
+			commentRepo,
+			repository.NewPersonalAccessTokenRepo(database, domain.CollectionPersonalAccessToken),
+			repository.NewLoginAttemptRepo(database, domain.CollectionLoginAttempt),
+			timeout,
+		),
+		TaskUsecase: usecases.NewTaskUsecase(
+			taskRepo,
+			commentRepo,
+			userRepo,
+			projectRepo,
+			bootstrap.NewBlobStorage(env),
+			roles,
+			timeout,
+		),
+		Migrator:       app.Migrator,
+		PasswordHasher: bootstrap.NewPasswordHasher(env),
+		PasswordPolicy: bootstrap.NewPasswordPolicy(env),
+		Roles:          roles,
+		In:             os.Stdin,
+		Out:            os.Stdout,
+	}
+}
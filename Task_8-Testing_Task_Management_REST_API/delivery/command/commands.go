@@ -0,0 +1,123 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// ErrUsage is returned for a command line that matches no command, or a command with invalid arguments.
+var ErrUsage = errors.New("invalid command line")
+
+// Usage describes the commands of the administrative CLI.
+const Usage = `Usage: admin [config flags] <command> [arguments]
+
+Commands:
+  user create --email EMAIL --name NAME [--role ROLE | --admin]
+                                create a user, verified, with the password read from the standard input
+  user list [--role ROLE] [--query TEXT]
+                                list the users
+  user promote USER             make a user an admin
+  user demote USER              make an admin a user again
+  user reset-password USER      set the password read from the standard input, and end the sessions of the user
+  task list [--project ID] [--assignee USER]
+                                list the tasks
+  task export [--project ID] [--assignee USER] [--format json|csv] [--output FILE]
+                                export the tasks, to the standard output by default
+  migrate [status]              apply the pending migrations, or only list the migrations
+  config print                  print the configuration, with its secrets masked
+  config check                  check the configuration and that MongoDB can be reached
+
+USER is the ID or the email of a user. The config flags, such as --app-env=production, come before
+the command, with their value after an equal sign; every variable of the configuration has one.
+`
+
+// Commands runs the administrative commands, talking directly to the storage through the usecases.
+// Passwords are read from In rather than the command line, where they would end up in the shell history.
+type Commands struct {
+	UserUsecase    domain.UserUsecase
+	TaskUsecase    domain.TaskUsecase
+	Migrator       domain.Migrator
+	PasswordHasher domain.PasswordHasher
+	PasswordPolicy domain.PasswordPolicy
+	Roles          domain.Roles
+	In             io.Reader
+	Out            io.Writer
+}
+
+// Run runs the command of the command line, without the config flags.
+func (commands *Commands) Run(c context.Context, args []string) error {
+	switch {
+	case len(args) >= 2 && args[0] == "user":
+		return commands.runUserCommand(c, args[1], args[2:])
+	case len(args) >= 2 && args[0] == "task":
+		return commands.runTaskCommand(c, args[1], args[2:])
+	case len(args) >= 1 && args[0] == "migrate":
+		return commands.Migrate(c, args[1:])
+	default:
+		return fmt.Errorf("%w: unknown command '%v'", ErrUsage, strings.Join(args, " "))
+	}
+}
+
+// NeedsStorage reports whether the command line runs a command talking to the storage,
+// rather than a config command or an invalid one.
+func NeedsStorage(args []string) bool {
+	return len(args) >= 1 && (args[0] == "user" || args[0] == "task" || args[0] == "migrate")
+}
+
+// PrintConfig prints the configuration loaded with the config flags in args, with its secrets masked,
+// and returns why it is invalid, if it is.
+func PrintConfig(out io.Writer, args []string) error {
+	env, err := bootstrap.LoadEnv(args)
+	if env != nil {
+		env.Print(out)
+	}
+
+	return err
+}
+
+// SplitArgs separates the config flags, which come first, from the command and its arguments.
+func SplitArgs(args []string) (configArgs []string, commandArgs []string) {
+	for i, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return args[:i], args[i:]
+		}
+	}
+
+	return args, nil
+}
+
+// parseFlags parses the arguments of a command, which takes exactly positional arguments after its flags.
+func parseFlags(flags *pflag.FlagSet, args []string, positional int) ([]string, error) {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUsage, err)
+	}
+	if flags.NArg() != positional {
+		return nil, fmt.Errorf("%w: expected %v arguments after the flags, got %v", ErrUsage, positional, flags.NArg())
+	}
+
+	return flags.Args(), nil
+}
+
+// readPassword reads the password from the first line of In.
+func (commands *Commands) readPassword() (string, error) {
+	line, err := bufio.NewReader(commands.In).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("%w: the password must be given on the standard input", ErrUsage)
+	}
+
+	return password, commands.PasswordPolicy.Validate(password)
+}
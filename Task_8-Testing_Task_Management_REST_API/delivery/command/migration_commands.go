@@ -0,0 +1,39 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Migrate applies the migrations not applied yet, or only lists every migration with 'status'.
+func (commands *Commands) Migrate(c context.Context, args []string) error {
+	switch {
+	case len(args) == 1 && args[0] == "status":
+		migrations, err := commands.Migrator.Status(c)
+		printMigrations(commands.Out, migrations)
+		return err
+	case len(args) == 0:
+		migrations, err := commands.Migrator.Migrate(c)
+		if err == nil && len(migrations) == 0 {
+			fmt.Fprintln(commands.Out, "The database is up to date")
+		}
+		printMigrations(commands.Out, migrations)
+		return err
+	default:
+		return fmt.Errorf("%w: 'migrate' expects no argument or 'status'", ErrUsage)
+	}
+}
+
+func printMigrations(out io.Writer, migrations []domain.MigrationStatus) {
+	for _, migration := range migrations {
+		applied := "pending"
+		if migration.AppliedAt != nil {
+			applied = "applied " + migration.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(out, "%4d  %-28s  %v\n", migration.Version, applied, migration.Description)
+	}
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationCommandsTestSuite struct {
+	suite.Suite
+	mockMigrator *mocks.Migrator
+	output       *bytes.Buffer
+	commands     *Commands
+}
+
+func (suite *MigrationCommandsTestSuite) SetupTest() {
+	suite.mockMigrator = new(mocks.Migrator)
+	suite.output = &bytes.Buffer{}
+	suite.commands = &Commands{Migrator: suite.mockMigrator, Out: suite.output}
+}
+
+func (suite *MigrationCommandsTestSuite) TestMigrate() {
+	appliedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	suite.mockMigrator.On("Migrate", mock.Anything).Return([]domain.MigrationStatus{
+		{Version: 2, Description: "make the emails of the users unique", AppliedAt: &appliedAt},
+	}, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"migrate"})
+
+	suite.NoError(err)
+	suite.Equal(fmt.Sprintf("%4d  %-28s  %v\n", 2, "applied 2026-10-19T12:00:00Z", "make the emails of the users unique"), suite.output.String())
+}
+
+func (suite *MigrationCommandsTestSuite) TestMigrate_UpToDate() {
+	suite.mockMigrator.On("Migrate", mock.Anything).Return(nil, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"migrate"})
+
+	suite.NoError(err)
+	suite.Equal("The database is up to date\n", suite.output.String())
+}
+
+func (suite *MigrationCommandsTestSuite) TestMigrateStatus() {
+	suite.mockMigrator.On("Status", mock.Anything).Return([]domain.MigrationStatus{
+		{Version: 1, Description: "normalize the emails"},
+	}, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"migrate", "status"})
+
+	suite.NoError(err)
+	suite.Contains(suite.output.String(), "pending")
+}
+
+func (suite *MigrationCommandsTestSuite) TestMigrate_UnknownArgument() {
+	err := suite.commands.Run(context.Background(), []string{"migrate", "down"})
+
+	suite.ErrorIs(err, ErrUsage)
+}
+
+func TestMigrationCommandsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationCommandsTestSuite))
+}
@@ -0,0 +1,141 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func (commands *Commands) runTaskCommand(c context.Context, name string, args []string) error {
+	switch name {
+	case "list":
+		return commands.ListTasks(c, args)
+	case "export":
+		return commands.ExportTasks(c, args)
+	default:
+		return fmt.Errorf("%w: unknown command 'task %v'", ErrUsage, name)
+	}
+}
+
+// ListTasks lists the tasks of every project, of one project or of one assignee.
+func (commands *Commands) ListTasks(c context.Context, args []string) error {
+	flags := pflag.NewFlagSet("task list", pflag.ContinueOnError)
+	project := flags.String("project", "", "")
+	assignee := flags.String("assignee", "", "")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	tasks, err := commands.getTasks(c, *project, *assignee)
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(commands.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tPROJECT\tSTATUS\tDUE\tTITLE")
+	for _, task := range tasks {
+		fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\n", task.ID.Hex(), task.ProjectID.Hex(), task.Status, task.DueDate.Format(time.DateOnly), task.Title)
+	}
+
+	return table.Flush()
+}
+
+// ExportTasks writes the tasks of every project, of one project or of one assignee, as a JSON array or as CSV,
+// to the standard output or to a file.
+func (commands *Commands) ExportTasks(c context.Context, args []string) error {
+	flags := pflag.NewFlagSet("task export", pflag.ContinueOnError)
+	project := flags.String("project", "", "")
+	assignee := flags.String("assignee", "", "")
+	format := flags.String("format", "json", "")
+	output := flags.String("output", "", "")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("%w: invalid format '%v', expected 'json' or 'csv'", ErrUsage, *format)
+	}
+
+	tasks, err := commands.getTasks(c, *project, *assignee)
+	if err != nil {
+		return err
+	}
+
+	out := commands.Out
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if *format == "csv" {
+		err = writeTasksCSV(out, tasks)
+	} else {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(tasks)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output != "" {
+		fmt.Fprintf(commands.Out, "Exported %v tasks to %v\n", len(tasks), *output)
+	}
+	return nil
+}
+
+// getTasks returns the tasks of the project or of the assignee, if given, or all of them.
+func (commands *Commands) getTasks(c context.Context, project string, assignee string) ([]domain.Task, error) {
+	switch {
+	case project != "" && assignee != "":
+		return nil, fmt.Errorf("%w: --project and --assignee can't be combined", ErrUsage)
+	case project != "":
+		return commands.TaskUsecase.GetProjectTasks(c, project)
+	case assignee != "":
+		user, err := commands.findUser(c, assignee)
+		if err != nil {
+			return nil, err
+		}
+		return commands.TaskUsecase.GetAssignedTasks(c, user.UserID.Hex())
+	default:
+		return commands.TaskUsecase.GetTasks(c)
+	}
+}
+
+// writeTasksCSV writes one row per task, the assignees separated by spaces and the attachments left out.
+func writeTasksCSV(out io.Writer, tasks []domain.Task) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write([]string{"id", "project_id", "title", "description", "duedate", "status", "assignees"}); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		assignees := make([]string, len(task.Assignees))
+		for i, assignee := range task.Assignees {
+			assignees[i] = assignee.Hex()
+		}
+
+		err := writer.Write([]string{
+			task.ID.Hex(), task.ProjectID.Hex(), task.Title, task.Description,
+			task.DueDate.Format(time.RFC3339), task.Status, strings.Join(assignees, " "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskCommandsTestSuite struct {
+	suite.Suite
+	mockTaskUsecase *mocks.TaskUsecase
+	mockUserUsecase *mocks.UserUsecase
+	output          *bytes.Buffer
+	commands        *Commands
+	tasks           []domain.Task
+}
+
+func (suite *TaskCommandsTestSuite) SetupTest() {
+	suite.mockTaskUsecase = new(mocks.TaskUsecase)
+	suite.mockUserUsecase = new(mocks.UserUsecase)
+	suite.output = &bytes.Buffer{}
+	suite.commands = &Commands{TaskUsecase: suite.mockTaskUsecase, UserUsecase: suite.mockUserUsecase, Out: suite.output}
+	suite.tasks = []domain.Task{{
+		ID:          primitive.NewObjectID(),
+		ProjectID:   primitive.NewObjectID(),
+		Title:       "Write the report, then send it",
+		Description: "quarterly",
+		DueDate:     time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		Status:      "In Progress",
+		Assignees:   []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()},
+	}}
+}
+
+func (suite *TaskCommandsTestSuite) TearDownTest() {
+	suite.mockTaskUsecase.AssertExpectations(suite.T())
+	suite.mockUserUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *TaskCommandsTestSuite) TestListTasks() {
+	suite.mockTaskUsecase.On("GetTasks", mock.Anything).Return(suite.tasks, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"task", "list"})
+
+	suite.NoError(err)
+	suite.Contains(suite.output.String(), "ID")
+	suite.Contains(suite.output.String(), suite.tasks[0].ID.Hex())
+	suite.Contains(suite.output.String(), "2026-11-02")
+}
+
+func (suite *TaskCommandsTestSuite) TestListTasks_OfAnAssignee() {
+	user := &domain.User{UserID: primitive.NewObjectID()}
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	suite.mockTaskUsecase.On("GetAssignedTasks", mock.Anything, user.UserID.Hex()).Return(suite.tasks, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"task", "list", "--assignee", "user@example.com"})
+
+	suite.NoError(err)
+}
+
+func (suite *TaskCommandsTestSuite) TestListTasks_ProjectAndAssignee() {
+	err := suite.commands.Run(context.Background(), []string{"task", "list", "--project", "1", "--assignee", "2"})
+
+	suite.ErrorIs(err, ErrUsage)
+}
+
+func (suite *TaskCommandsTestSuite) TestExportTasks_JSON() {
+	projectID := suite.tasks[0].ProjectID.Hex()
+	suite.mockTaskUsecase.On("GetProjectTasks", mock.Anything, projectID).Return(suite.tasks, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"task", "export", "--project", projectID})
+
+	suite.NoError(err)
+	var exported []domain.Task
+	suite.Require().NoError(json.Unmarshal(suite.output.Bytes(), &exported))
+	suite.Equal(suite.tasks, exported)
+}
+
+func (suite *TaskCommandsTestSuite) TestExportTasks_CSVFile() {
+	suite.mockTaskUsecase.On("GetTasks", mock.Anything).Return(suite.tasks, nil).Once()
+	file := filepath.Join(suite.T().TempDir(), "tasks.csv")
+
+	err := suite.commands.Run(context.Background(), []string{"task", "export", "--format", "csv", "--output", file})
+
+	suite.NoError(err)
+	suite.Equal("Exported 1 tasks to "+file+"\n", suite.output.String())
+	content, err := os.ReadFile(file)
+	suite.NoError(err)
+	task := suite.tasks[0]
+	suite.Equal("id,project_id,title,description,duedate,status,assignees\n"+
+		task.ID.Hex()+","+task.ProjectID.Hex()+",\"Write the report, then send it\",quarterly,2026-11-02T00:00:00Z,In Progress,"+
+		task.Assignees[0].Hex()+" "+task.Assignees[1].Hex()+"\n", string(content))
+}
+
+func (suite *TaskCommandsTestSuite) TestExportTasks_InvalidFormat() {
+	err := suite.commands.Run(context.Background(), []string{"task", "export", "--format", "xml"})
+
+	suite.ErrorIs(err, ErrUsage)
+}
+
+func TestTaskCommandsTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskCommandsTestSuite))
+}
@@ -0,0 +1,209 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (commands *Commands) runUserCommand(c context.Context, name string, args []string) error {
+	switch name {
+	case "create":
+		return commands.CreateUser(c, args)
+	case "list":
+		return commands.ListUsers(c, args)
+	case "promote":
+		return commands.PromoteUser(c, args)
+	case "demote":
+		return commands.DemoteUser(c, args)
+	case "reset-password":
+		return commands.ResetPassword(c, args)
+	default:
+		return fmt.Errorf("%w: unknown command 'user %v'", ErrUsage, name)
+	}
+}
+
+// CreateUser creates a user with a verified email, as the operator vouches for it, such as the first admin.
+func (commands *Commands) CreateUser(c context.Context, args []string) error {
+	flags := pflag.NewFlagSet("user create", pflag.ContinueOnError)
+	email := flags.String("email", "", "")
+	name := flags.String("name", "", "")
+	role := flags.String("role", domain.RoleUser, "")
+	admin := flags.Bool("admin", false, "")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	if *admin {
+		*role = domain.RoleAdmin
+	}
+
+	var validation domain.ValidationError
+	normalized, err := domain.NormalizeEmail(*email)
+	if err != nil {
+		validation.Add("email", "email", fmt.Sprintf("'%v' is not a valid email address", *email))
+	}
+	if *name == "" {
+		validation.Add("name", "required", "name is required")
+	}
+	if !commands.Roles.Exists(*role) {
+		validation.Add("role", "role", fmt.Sprintf("invalid user role '%v'", *role))
+	}
+	if err := validation.Err(); err != nil {
+		return err
+	}
+
+	existing, err := commands.UserUsecase.GetByEmail(c, normalized)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrUserExists
+	}
+
+	password, err := commands.readPassword()
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := commands.PasswordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	user := &domain.User{Name: *name, Email: normalized, Password: hashedPassword, Role: *role, EmailVerified: true}
+	if err := commands.UserUsecase.Create(c, user); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(commands.Out, "Created the %v %v with the ID %v\n", user.Role, user.Email, user.UserID.Hex())
+	return nil
+}
+
+// ListUsers lists the users, optionally filtered by role and by a text matched against their name and email.
+func (commands *Commands) ListUsers(c context.Context, args []string) error {
+	flags := pflag.NewFlagSet("user list", pflag.ContinueOnError)
+	role := flags.String("role", "", "")
+	query := flags.String("query", "", "")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(commands.Out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tEMAIL\tNAME\tROLE\tSTATUS")
+
+	filter := domain.UserFilter{Query: *query, Role: *role}
+	for pagination := domain.NewPagination(1, domain.MaxPageLimit); ; pagination.Page++ {
+		page, err := commands.UserUsecase.GetUsers(c, filter, pagination)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range page.Users {
+			status := "active"
+			if user.Disabled {
+				status = "disabled"
+			} else if !user.EmailVerified {
+				status = "unverified"
+			}
+			fmt.Fprintf(table, "%v\t%v\t%v\t%v\t%v\n", user.ID.Hex(), user.Email, user.Name, user.Role, status)
+		}
+
+		if pagination.Page*pagination.Limit >= page.Total {
+			break
+		}
+	}
+
+	return table.Flush()
+}
+
+// PromoteUser makes a user an admin.
+func (commands *Commands) PromoteUser(c context.Context, args []string) error {
+	user, err := commands.userArgument(c, "user promote", args)
+	if err != nil {
+		return err
+	}
+
+	if user.Role == domain.RoleAdmin {
+		fmt.Fprintf(commands.Out, "%v is already an admin\n", user.Email)
+		return nil
+	}
+
+	if err := commands.UserUsecase.Promote(c, user.UserID.Hex()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(commands.Out, "Promoted %v to admin\n", user.Email)
+	return nil
+}
+
+// DemoteUser makes an admin a user again, unless they are the last active admin.
+func (commands *Commands) DemoteUser(c context.Context, args []string) error {
+	user, err := commands.userArgument(c, "user demote", args)
+	if err != nil {
+		return err
+	}
+
+	if err := commands.UserUsecase.Demote(c, user.UserID.Hex()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(commands.Out, "Demoted %v to user\n", user.Email)
+	return nil
+}
+
+// ResetPassword sets the password of a user, such as one locked out of their account, and ends their sessions.
+func (commands *Commands) ResetPassword(c context.Context, args []string) error {
+	user, err := commands.userArgument(c, "user reset-password", args)
+	if err != nil {
+		return err
+	}
+
+	password, err := commands.readPassword()
+	if err != nil {
+		return err
+	}
+	hashedPassword, err := commands.PasswordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	if err := commands.UserUsecase.SetPassword(c, user.UserID.Hex(), hashedPassword); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(commands.Out, "Reset the password of %v and ended their sessions\n", user.Email)
+	return nil
+}
+
+// userArgument finds the user given by the only argument of a command, by ID or by email.
+func (commands *Commands) userArgument(c context.Context, name string, args []string) (*domain.User, error) {
+	args, err := parseFlags(pflag.NewFlagSet(name, pflag.ContinueOnError), args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return commands.findUser(c, args[0])
+}
+
+func (commands *Commands) findUser(c context.Context, idOrEmail string) (*domain.User, error) {
+	if primitive.IsValidObjectID(idOrEmail) {
+		return commands.UserUsecase.GetByID(c, idOrEmail)
+	}
+
+	email, err := domain.NormalizeEmail(idOrEmail)
+	if err != nil {
+		return nil, err
+	}
+	user, err := commands.UserUsecase.GetByEmail(c, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return user, nil
+}
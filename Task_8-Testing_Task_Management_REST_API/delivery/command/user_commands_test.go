@@ -0,0 +1,169 @@
+package command
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UserCommandsTestSuite struct {
+	suite.Suite
+	mockUserUsecase *mocks.UserUsecase
+	output          *bytes.Buffer
+	commands        *Commands
+}
+
+func (suite *UserCommandsTestSuite) SetupTest() {
+	suite.mockUserUsecase = new(mocks.UserUsecase)
+	suite.output = &bytes.Buffer{}
+	suite.commands = &Commands{
+		UserUsecase:    suite.mockUserUsecase,
+		PasswordHasher: infrastructure.NewBcryptHasher(4),
+		PasswordPolicy: domain.PasswordPolicy{MinLength: 8, MaxLength: 128},
+		Roles:          domain.DefaultRoles(),
+		In:             strings.NewReader("correct horse battery\n"),
+		Out:            suite.output,
+	}
+}
+
+func (suite *UserCommandsTestSuite) TearDownTest() {
+	suite.mockUserUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *UserCommandsTestSuite) verifies(password string, hash string) bool {
+	ok, err := suite.commands.PasswordHasher.Verify(password, hash)
+	return ok && err == nil
+}
+
+func (suite *UserCommandsTestSuite) TestCreateUser_Admin() {
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "admin@example.com").Return(nil, nil).Once()
+	suite.mockUserUsecase.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Email == "admin@example.com" && user.Name == "Admin" && user.Role == domain.RoleAdmin &&
+			user.EmailVerified && suite.verifies("correct horse battery", user.Password)
+	})).Return(nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "create", "--email", " Admin@Example.com", "--name", "Admin", "--admin"})
+
+	suite.NoError(err)
+	suite.Contains(suite.output.String(), "Created the ADMIN admin@example.com")
+}
+
+func (suite *UserCommandsTestSuite) TestCreateUser_Invalid() {
+	err := suite.commands.Run(context.Background(), []string{"user", "create", "--email", "not an email", "--role", "OWNER"})
+
+	var validation *domain.ValidationError
+	suite.Require().ErrorAs(err, &validation)
+	suite.Len(validation.Fields, 3)
+}
+
+func (suite *UserCommandsTestSuite) TestCreateUser_Exists() {
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "user@example.com").Return(&domain.User{}, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "create", "--email", "user@example.com", "--name", "User"})
+
+	suite.ErrorIs(err, domain.ErrUserExists)
+}
+
+func (suite *UserCommandsTestSuite) TestCreateUser_WeakPassword() {
+	suite.commands.In = strings.NewReader("short\n")
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "user@example.com").Return(nil, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "create", "--email", "user@example.com", "--name", "User"})
+
+	suite.ErrorIs(err, domain.ErrInvalidInput)
+}
+
+func (suite *UserCommandsTestSuite) TestPromoteUser_ByEmail() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "user@example.com", Role: domain.RoleUser}
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	suite.mockUserUsecase.On("Promote", mock.Anything, user.UserID.Hex()).Return(nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "promote", "user@example.com"})
+
+	suite.NoError(err)
+	suite.Equal("Promoted user@example.com to admin\n", suite.output.String())
+}
+
+func (suite *UserCommandsTestSuite) TestPromoteUser_NotFound() {
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "promote", "nobody@example.com"})
+
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+}
+
+func (suite *UserCommandsTestSuite) TestDemoteUser_ByID() {
+	id := primitive.NewObjectID()
+	suite.mockUserUsecase.On("GetByID", mock.Anything, id.Hex()).Return(&domain.User{UserID: id, Email: "admin@example.com"}, nil).Once()
+	suite.mockUserUsecase.On("Demote", mock.Anything, id.Hex()).Return(domain.ErrLastAdmin).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "demote", id.Hex()})
+
+	suite.ErrorIs(err, domain.ErrLastAdmin)
+}
+
+func (suite *UserCommandsTestSuite) TestResetPassword() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "user@example.com", Password: "old"}
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil).Once()
+	suite.mockUserUsecase.On("SetPassword", mock.Anything, user.UserID.Hex(), mock.MatchedBy(func(hashedPassword string) bool {
+		return suite.verifies("correct horse battery", hashedPassword)
+	})).Return(nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "reset-password", "user@example.com"})
+
+	suite.NoError(err)
+	suite.Contains(suite.output.String(), "ended their sessions")
+}
+
+func (suite *UserCommandsTestSuite) TestResetPassword_NoPassword() {
+	suite.commands.In = strings.NewReader("")
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "user@example.com").Return(&domain.User{}, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "reset-password", "user@example.com"})
+
+	suite.ErrorIs(err, ErrUsage)
+}
+
+func (suite *UserCommandsTestSuite) TestListUsers_EveryPage() {
+	first := make([]domain.UserProfile, domain.MaxPageLimit)
+	for i := range first {
+		first[i] = domain.UserProfile{ID: primitive.NewObjectID(), Email: "user@example.com", Role: domain.RoleUser, EmailVerified: true}
+	}
+	filter := domain.UserFilter{Role: domain.RoleAdmin}
+	suite.mockUserUsecase.On("GetUsers", mock.Anything, filter, domain.Pagination{Page: 1, Limit: domain.MaxPageLimit}).
+		Return(&domain.UserPage{Users: first, Total: domain.MaxPageLimit + 1}, nil).Once()
+	suite.mockUserUsecase.On("GetUsers", mock.Anything, filter, domain.Pagination{Page: 2, Limit: domain.MaxPageLimit}).
+		Return(&domain.UserPage{Users: []domain.UserProfile{{ID: primitive.NewObjectID(), Email: "off@example.com", Disabled: true}}, Total: domain.MaxPageLimit + 1}, nil).Once()
+
+	err := suite.commands.Run(context.Background(), []string{"user", "list", "--role", domain.RoleAdmin})
+
+	suite.NoError(err)
+	lines := strings.Split(strings.TrimSpace(suite.output.String()), "\n")
+	suite.Len(lines, domain.MaxPageLimit+2)
+	suite.Contains(lines[len(lines)-1], "disabled")
+}
+
+func (suite *UserCommandsTestSuite) TestUnknownCommand() {
+	err := suite.commands.Run(context.Background(), []string{"user", "rename"})
+
+	suite.ErrorIs(err, ErrUsage)
+}
+
+func (suite *UserCommandsTestSuite) TestSplitArgs() {
+	configArgs, commandArgs := SplitArgs([]string{"--app-env=test", "user", "promote", "--help"})
+
+	suite.Equal([]string{"--app-env=test"}, configArgs)
+	suite.Equal([]string{"user", "promote", "--help"}, commandArgs)
+}
+
+func TestUserCommandsTestSuite(t *testing.T) {
+	suite.Run(t, new(UserCommandsTestSuite))
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccessTokenController struct {
+	PersonalAccessTokenUsecase domain.PersonalAccessTokenUsecase
+	Env                        *bootstrap.Env
+}
+
+type accessTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,required"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"min=0"`
+}
+
+// CreateToken creates a personal access token for the authenticated user, with the name, scopes and expiry
+// in the request body. The token is part of the response and can't be retrieved again.
+func (controller *AccessTokenController) CreateToken(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	var request accessTokenRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	token, err := controller.PersonalAccessTokenUsecase.Create(c, userID, domain.PersonalAccessTokenRequest{
+		Name:          request.Name,
+		Scopes:        request.Scopes,
+		ExpiresInDays: request.ExpiresInDays,
+	})
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// GetMyTokens retrieves the personal access tokens of the authenticated user, with when they were last used.
+func (controller *AccessTokenController) GetMyTokens(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	tokens, err := controller.PersonalAccessTokenUsecase.GetUserTokens(c, userID)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// RevokeToken deletes the personal access token with the ID in the path, if it belongs to the authenticated user.
+func (controller *AccessTokenController) RevokeToken(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	err = controller.PersonalAccessTokenUsecase.Revoke(c, userID, c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "access token revoked"})
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AccessTokenControllerTestSuite struct {
+	suite.Suite
+	mockTokenUsecase *mocks.PersonalAccessTokenUsecase
+	controller       *AccessTokenController
+	router           *gin.Engine
+	userID           string
+}
+
+func (suite *AccessTokenControllerTestSuite) SetupTest() {
+	suite.mockTokenUsecase = new(mocks.PersonalAccessTokenUsecase)
+	suite.controller = &AccessTokenController{
+		PersonalAccessTokenUsecase: suite.mockTokenUsecase,
+	}
+	suite.userID = primitive.NewObjectID().Hex()
+	suite.router = gin.Default()
+
+	// simulate the claims set by the authentication middleware
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": suite.userID, "role": "USER"})
+	})
+
+	// define the routes
+	suite.router.GET("/me/tokens", suite.controller.GetMyTokens)
+	suite.router.POST("/me/tokens", suite.controller.CreateToken)
+	suite.router.DELETE("/me/tokens/:id", suite.controller.RevokeToken)
+}
+
+func (suite *AccessTokenControllerTestSuite) TearDownTest() {
+	suite.mockTokenUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *AccessTokenControllerTestSuite) TestCreateToken_Success() {
+	request := domain.PersonalAccessTokenRequest{Name: "ci", Scopes: []string{"tasks:read"}, ExpiresInDays: 30}
+	created := &domain.CreatedPersonalAccessToken{
+		Token:               "tmpat_secret",
+		PersonalAccessToken: domain.PersonalAccessToken{Name: "ci", Scopes: request.Scopes, TokenHash: "hash"},
+	}
+	suite.mockTokenUsecase.On("Create", mock.Anything, suite.userID, request).Return(created, nil).Once()
+
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/me/tokens", bytes.NewBufferString(`{"name": "ci", "scopes": ["tasks:read"], "expires_in_days": 30}`))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, httpRequest)
+
+	suite.Equal(http.StatusCreated, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), `"token":"tmpat_secret"`)
+	suite.NotContains(responseWriter.Body.String(), "hash")
+}
+
+func (suite *AccessTokenControllerTestSuite) TestCreateToken_InvalidScope() {
+	suite.mockTokenUsecase.On("Create", mock.Anything, suite.userID, mock.Anything).Return(nil, domain.ErrInvalidInput).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/me/tokens", bytes.NewBufferString(`{"name": "ci", "scopes": ["users:delete"]}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+}
+
+func (suite *AccessTokenControllerTestSuite) TestGetMyTokens() {
+	tokens := []domain.PersonalAccessToken{{Name: "ci", Hint: "abcd"}}
+	suite.mockTokenUsecase.On("GetUserTokens", mock.Anything, suite.userID).Return(tokens, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/me/tokens", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), `"hint":"abcd"`)
+}
+
+func (suite *AccessTokenControllerTestSuite) TestRevokeToken_NotFound() {
+	tokenID := primitive.NewObjectID().Hex()
+	suite.mockTokenUsecase.On("Revoke", mock.Anything, suite.userID, tokenID).Return(domain.ErrTokenNotFound).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/me/tokens/"+tokenID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
+}
+
+func TestAccessTokenControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AccessTokenControllerTestSuite))
+}
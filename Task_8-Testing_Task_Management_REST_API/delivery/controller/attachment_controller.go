@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead is the room left for the multipart boundaries and headers on top of the file itself.
+const multipartOverhead = 1 << 20
+
+type AttachmentController struct {
+	AttachmentUsecase domain.AttachmentUsecase
+	Env               *bootstrap.Env
+}
+
+// UploadAttachment attaches the file sent in the 'file' field of a multipart form
+// to the task with the ID in the path.
+func (controller *AttachmentController) UploadAttachment(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	if controller.Env != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, controller.Env.AttachmentMaxSize+multipartOverhead)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(c, domain.ErrAttachmentTooLarge)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a file is required in the 'file' form field"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid file"})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := controller.AttachmentUsecase.Upload(c, c.Param("id"), userID, fileHeader.Filename, file)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// DownloadAttachment streams the content of an attachment of the task with the ID in the path.
+func (controller *AttachmentController) DownloadAttachment(c *gin.Context) {
+	attachment, content, err := controller.AttachmentUsecase.Open(c, c.Param("id"), c.Param("attachmentID"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+	defer content.Close()
+
+	headers := map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", attachment.FileName),
+		"ETag":                fmt.Sprintf("%q", attachment.Hash),
+	}
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, headers)
+}
+
+// DeleteAttachment removes an attachment from the task with the ID in the path.
+// The uploader of the attachment and admins are allowed to delete it.
+func (controller *AttachmentController) DeleteAttachment(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	userRole, err := infrastructure.GetUserRoleFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	err = controller.AttachmentUsecase.Delete(c, c.Param("id"), c.Param("attachmentID"), userID, userRole)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "attachment deleted successfully"})
+}
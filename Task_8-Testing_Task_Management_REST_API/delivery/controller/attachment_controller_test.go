@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AttachmentControllerTestSuite struct {
+	suite.Suite
+	mockAttachmentUsecase *mocks.AttachmentUsecase
+	controller            *AttachmentController
+	router                *gin.Engine
+	userID                string
+}
+
+func (suite *AttachmentControllerTestSuite) SetupTest() {
+	suite.mockAttachmentUsecase = new(mocks.AttachmentUsecase)
+	suite.controller = &AttachmentController{
+		AttachmentUsecase: suite.mockAttachmentUsecase,
+		Env:               &bootstrap.Env{AttachmentMaxSize: 1 << 10},
+	}
+	suite.userID = primitive.NewObjectID().Hex()
+	suite.router = gin.Default()
+
+	// simulate the claims set by the authentication middleware
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": suite.userID, "role": "USER"})
+	})
+
+	// define the routes
+	suite.router.POST("/tasks/:id/attachments", suite.controller.UploadAttachment)
+	suite.router.GET("/tasks/:id/attachments/:attachmentID", suite.controller.DownloadAttachment)
+	suite.router.DELETE("/tasks/:id/attachments/:attachmentID", suite.controller.DeleteAttachment)
+}
+
+func (suite *AttachmentControllerTestSuite) TearDownTest() {
+	suite.mockAttachmentUsecase.AssertExpectations(suite.T())
+}
+
+func multipartFile(field string, fileName string, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, fileName)
+	part.Write([]byte(content))
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func (suite *AttachmentControllerTestSuite) TestUploadAttachment_Success() {
+	taskID := primitive.NewObjectID().Hex()
+	attachment := &domain.Attachment{ID: primitive.NewObjectID(), FileName: "notes.txt"}
+
+	suite.mockAttachmentUsecase.On("Upload", mock.Anything, taskID, suite.userID, "notes.txt", mock.Anything).Return(attachment, nil).Once()
+
+	body, contentType := multipartFile("file", "notes.txt", "hello")
+	request, _ := http.NewRequest(http.MethodPost, "/tasks/"+taskID+"/attachments", body)
+	request.Header.Set("Content-Type", contentType)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusCreated, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), attachment.ID.Hex())
+}
+
+func (suite *AttachmentControllerTestSuite) TestUploadAttachment_MissingFile() {
+	body, contentType := multipartFile("other", "notes.txt", "hello")
+	request, _ := http.NewRequest(http.MethodPost, "/tasks/"+primitive.NewObjectID().Hex()+"/attachments", body)
+	request.Header.Set("Content-Type", contentType)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+}
+
+func (suite *AttachmentControllerTestSuite) TestUploadAttachment_UnsupportedType() {
+	taskID := primitive.NewObjectID().Hex()
+
+	suite.mockAttachmentUsecase.On("Upload", mock.Anything, taskID, suite.userID, "run.exe", mock.Anything).Return(nil, domain.ErrUnsupportedMediaType).Once()
+
+	body, contentType := multipartFile("file", "run.exe", "MZ")
+	request, _ := http.NewRequest(http.MethodPost, "/tasks/"+taskID+"/attachments", body)
+	request.Header.Set("Content-Type", contentType)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnsupportedMediaType, responseWriter.Code)
+}
+
+func (suite *AttachmentControllerTestSuite) TestDownloadAttachment_Success() {
+	taskID := primitive.NewObjectID().Hex()
+	attachment := &domain.Attachment{ID: primitive.NewObjectID(), FileName: "notes.txt", ContentType: "text/plain", Size: 5}
+
+	suite.mockAttachmentUsecase.On("Open", mock.Anything, taskID, attachment.ID.Hex()).Return(attachment, io.NopCloser(strings.NewReader("hello")), nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/tasks/"+taskID+"/attachments/"+attachment.ID.Hex(), nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Equal("hello", responseWriter.Body.String())
+	suite.Equal("text/plain", responseWriter.Header().Get("Content-Type"))
+	suite.Contains(responseWriter.Header().Get("Content-Disposition"), "notes.txt")
+}
+
+func (suite *AttachmentControllerTestSuite) TestDeleteAttachment_NotFound() {
+	taskID := primitive.NewObjectID().Hex()
+	attachmentID := primitive.NewObjectID().Hex()
+
+	suite.mockAttachmentUsecase.On("Delete", mock.Anything, taskID, attachmentID, suite.userID, "USER").Return(domain.ErrAttachmentNotFound).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/tasks/"+taskID+"/attachments/"+attachmentID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "attachment not found")
+}
+
+func TestAttachmentControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentControllerTestSuite))
+}
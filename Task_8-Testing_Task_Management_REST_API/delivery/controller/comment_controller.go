@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentController struct {
+	CommentUsecase domain.CommentUsecase
+	Env            *bootstrap.Env
+}
+
+type commentRequest struct {
+	Content  string `json:"content" binding:"required,max=5000"`
+	ParentID string `json:"parent_id" binding:"omitempty,objectid"`
+}
+
+// CreateComment adds a comment, or a reply when 'parent_id' is given, to the task with the ID in the path.
+// The authenticated user becomes the author of the comment.
+func (controller *CommentController) CreateComment(c *gin.Context) {
+	var request commentRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	comment, err := controller.CommentUsecase.Create(c, c.Param("id"), userID, request.ParentID, request.Content)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// GetTaskComments lists the comment threads of the task with the ID in the path.
+// The 'page' and 'limit' query parameters select which threads are returned.
+func (controller *CommentController) GetTaskComments(c *gin.Context) {
+	page, err := controller.CommentUsecase.GetTaskComments(c, c.Param("id"), getPagination(c))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// EditComment replaces the content of the comment with the ID in the path.
+// Only the author of the comment is allowed to edit it.
+func (controller *CommentController) EditComment(c *gin.Context) {
+	var request commentRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	comment, err := controller.CommentUsecase.Edit(c, c.Param("id"), userID, request.Content)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment deletes the comment with the ID in the path.
+// The author of the comment and admins moderating the discussion are allowed to delete it.
+func (controller *CommentController) DeleteComment(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	userRole, err := infrastructure.GetUserRoleFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	err = controller.CommentUsecase.Delete(c, c.Param("id"), userID, userRole)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "comment deleted successfully"})
+}
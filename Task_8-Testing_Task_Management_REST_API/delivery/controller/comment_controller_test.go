@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommentControllerTestSuite struct {
+	suite.Suite
+	mockCommentUsecase *mocks.CommentUsecase
+	controller         *CommentController
+	router             *gin.Engine
+	userID             string
+}
+
+func (suite *CommentControllerTestSuite) SetupTest() {
+	suite.mockCommentUsecase = new(mocks.CommentUsecase)
+	suite.controller = &CommentController{
+		CommentUsecase: suite.mockCommentUsecase,
+	}
+	suite.userID = primitive.NewObjectID().Hex()
+	suite.router = gin.Default()
+
+	// simulate the claims set by the authentication middleware
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": suite.userID, "role": "USER"})
+	})
+
+	// define the routes
+	suite.router.GET("/tasks/:id/comments", suite.controller.GetTaskComments)
+	suite.router.POST("/tasks/:id/comments", suite.controller.CreateComment)
+	suite.router.PUT("/comments/:id", suite.controller.EditComment)
+	suite.router.DELETE("/comments/:id", suite.controller.DeleteComment)
+}
+
+func (suite *CommentControllerTestSuite) TearDownTest() {
+	suite.mockCommentUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *CommentControllerTestSuite) TestCreateComment_Success() {
+	taskID := primitive.NewObjectID().Hex()
+	comment := &domain.Comment{ID: primitive.NewObjectID(), Content: "hello"}
+
+	suite.mockCommentUsecase.On("Create", mock.Anything, taskID, suite.userID, "", "hello").Return(comment, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/tasks/"+taskID+"/comments", bytes.NewBufferString(`{"content": "hello"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusCreated, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), comment.ID.Hex())
+}
+
+func (suite *CommentControllerTestSuite) TestCreateComment_TaskNotFound() {
+	suite.mockCommentUsecase.On("Create", mock.Anything, "missing", suite.userID, "", "hello").Return(nil, domain.ErrTaskNotFound).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/tasks/missing/comments", bytes.NewBufferString(`{"content": "hello"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "task not found")
+}
+
+func (suite *CommentControllerTestSuite) TestGetTaskComments_Pagination() {
+	taskID := primitive.NewObjectID().Hex()
+	pagination := domain.NewPagination(2, 5)
+
+	suite.mockCommentUsecase.On("GetTaskComments", mock.Anything, taskID, pagination).Return(domain.CommentPage{Comments: []domain.Comment{}, Pagination: pagination, Total: 7}, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/tasks/"+taskID+"/comments?page=2&limit=5", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), `"total":7`)
+}
+
+func (suite *CommentControllerTestSuite) TestEditComment_Forbidden() {
+	commentID := primitive.NewObjectID().Hex()
+
+	suite.mockCommentUsecase.On("Edit", mock.Anything, commentID, suite.userID, "edited").Return(nil, domain.ErrForbidden).Once()
+
+	request, _ := http.NewRequest(http.MethodPut, "/comments/"+commentID, bytes.NewBufferString(`{"content": "edited"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusForbidden, responseWriter.Code)
+}
+
+func (suite *CommentControllerTestSuite) TestDeleteComment_Success() {
+	commentID := primitive.NewObjectID().Hex()
+
+	suite.mockCommentUsecase.On("Delete", mock.Anything, commentID, suite.userID, "USER").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/comments/"+commentID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "comment deleted successfully")
+}
+
+func TestCommentControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentControllerTestSuite))
+}
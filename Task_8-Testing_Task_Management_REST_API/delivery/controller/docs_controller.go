@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed swagger_ui.html
+var swaggerUI []byte
+
+// DocsController serves the OpenAPI document of the API and a Swagger UI to browse it.
+// Document holds the encoded document, which can only be generated once every route is registered.
+type DocsController struct {
+	Document []byte
+	Env      *bootstrap.Env
+}
+
+// GetOpenAPIDocument returns the OpenAPI 3 document of the API.
+func (controller *DocsController) GetOpenAPIDocument(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", controller.Document)
+}
+
+// GetSwaggerUI returns a Swagger UI page showing the document served at '/openapi.json'.
+func (controller *DocsController) GetSwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerUI)
+}
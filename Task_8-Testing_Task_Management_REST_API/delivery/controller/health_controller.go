@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthController answers the liveness and readiness probes of the orchestrator.
+type HealthController struct {
+	HealthUsecase domain.HealthUsecase
+}
+
+// GetLiveness reports that the process is up.
+func (controller *HealthController) GetLiveness(c *gin.Context) {
+	c.JSON(http.StatusOK, controller.HealthUsecase.Liveness())
+}
+
+// GetReadiness reports whether every dependency is up, with a 503 Service Unavailable status when one
+// of them is down or the server is shutting down, so that no more requests are routed to it.
+func (controller *HealthController) GetReadiness(c *gin.Context) {
+	report := controller.HealthUsecase.Readiness(c)
+	if !report.Up() {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthControllerTestSuite struct {
+	suite.Suite
+	mockHealthUsecase *mocks.HealthUsecase
+	controller        *HealthController
+	router            *gin.Engine
+}
+
+func (suite *HealthControllerTestSuite) SetupTest() {
+	suite.mockHealthUsecase = new(mocks.HealthUsecase)
+	suite.controller = &HealthController{
+		HealthUsecase: suite.mockHealthUsecase,
+	}
+	suite.router = gin.Default()
+
+	// define the routes
+	suite.router.GET("/healthz", suite.controller.GetLiveness)
+	suite.router.GET("/readyz", suite.controller.GetReadiness)
+}
+
+func (suite *HealthControllerTestSuite) TearDownTest() {
+	suite.mockHealthUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *HealthControllerTestSuite) get(path string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	return responseWriter
+}
+
+func (suite *HealthControllerTestSuite) TestGetLiveness() {
+	suite.mockHealthUsecase.On("Liveness").Return(domain.HealthReport{Status: domain.HealthStatusUp}).Once()
+
+	responseWriter := suite.get("/healthz")
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.JSONEq(`{"status": "up"}`, responseWriter.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TestGetReadiness_Up() {
+	report := domain.HealthReport{
+		Status: domain.HealthStatusUp,
+		Checks: map[string]domain.DependencyHealth{"mongodb": {Status: domain.HealthStatusUp, LatencyMS: 1.5}},
+	}
+	suite.mockHealthUsecase.On("Readiness", mock.Anything).Return(report).Once()
+
+	responseWriter := suite.get("/readyz")
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.JSONEq(`{"status": "up", "checks": {"mongodb": {"status": "up", "latency_ms": 1.5}}}`, responseWriter.Body.String())
+}
+
+func (suite *HealthControllerTestSuite) TestGetReadiness_Down() {
+	report := domain.HealthReport{
+		Status: domain.HealthStatusDown,
+		Checks: map[string]domain.DependencyHealth{"mongodb": {Status: domain.HealthStatusDown, LatencyMS: 2000, Error: "context deadline exceeded"}},
+	}
+	suite.mockHealthUsecase.On("Readiness", mock.Anything).Return(report).Once()
+
+	responseWriter := suite.get("/readyz")
+
+	suite.Equal(http.StatusServiceUnavailable, responseWriter.Code)
+	suite.JSONEq(`{"status": "down", "checks": {"mongodb": {"status": "down", "latency_ms": 2000, "error": "context deadline exceeded"}}}`, responseWriter.Body.String())
+}
+
+func TestHealthControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthControllerTestSuite))
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getPagination reads the 'page' and 'limit' query parameters of the request.
+// Missing or malformed values fall back to the defaults of domain.NewPagination.
+func getPagination(c *gin.Context) domain.Pagination {
+	page, _ := strconv.ParseInt(c.Query("page"), 10, 64)
+	limit, _ := strconv.ParseInt(c.Query("limit"), 10, 64)
+
+	return domain.NewPagination(page, limit)
+}
+
+// errorStatus maps the errors returned by the usecases to an HTTP status code.
+// Errors that are not known domain errors are treated as internal server errors.
+func errorStatus(err error) int {
+	var validation *domain.ValidationError
+
+	switch {
+	case errors.As(err, &validation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrInvalidInput), errors.Is(err, domain.ErrInvalidToken),
+		errors.Is(err, domain.ErrInvalidTwoFactorCode):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForbidden), errors.Is(err, domain.ErrNotMember),
+		errors.Is(err, domain.ErrTwoFactorRequired):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrTaskNotFound), errors.Is(err, domain.ErrCommentNotFound),
+		errors.Is(err, domain.ErrAttachmentNotFound), errors.Is(err, domain.ErrProjectNotFound),
+		errors.Is(err, domain.ErrUserNotFound), errors.Is(err, domain.ErrTokenNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrProjectNotEmpty), errors.Is(err, domain.ErrLastOwner),
+		errors.Is(err, domain.ErrLastAdmin), errors.Is(err, domain.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, domain.ErrTwoFactorNotEnabled), errors.Is(err, domain.ErrUserExists):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, domain.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondWithError writes the error returned by a usecase as a JSON response,
+// hiding the message of unexpected errors behind a generic one.
+// Validation errors list every invalid field in 'fields'.
+func respondWithError(c *gin.Context, err error) {
+	status := errorStatus(err)
+	if status == http.StatusInternalServerError {
+		c.JSON(status, gin.H{"error": "internal server error"})
+		return
+	}
+
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		c.JSON(status, gin.H{"error": "validation failed", "fields": validation.Fields})
+		return
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
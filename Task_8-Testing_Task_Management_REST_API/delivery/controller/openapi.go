@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"net/http"
+)
+
+// the bodies of the responses built with gin.H, for the OpenAPI document
+
+type messageResponse struct {
+	Message string `json:"message"`
+}
+
+type loginResponse struct {
+	Message        string `json:"message"`
+	Token          string `json:"token,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+type recoveryCodesResponse struct {
+	Message       string   `json:"message"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type accessTokensResponse struct {
+	Tokens []domain.PersonalAccessToken `json:"tokens"`
+}
+
+type attachmentForm struct {
+	File []byte `json:"file" binding:"required"`
+}
+
+// Operations documents the routes of a version of the API, keyed by their method and gin path
+// without the version prefix, such as "GET /tasks/:id".
+// Every registered route must be listed here, so that it is part of the OpenAPI document.
+func Operations() map[string]infrastructure.OpenAPIOperation {
+	return map[string]infrastructure.OpenAPIOperation{
+		"POST /register": {
+			Summary: "Register a new user", Tag: "authentication", Public: true,
+			Request: registerRequest{}, Response: messageResponse{},
+		},
+		"POST /login": {
+			Summary: "Log in, returning a token or, with two-factor authentication enabled, a challenge token",
+			Tag:     "authentication", Public: true, Request: loginRequest{}, Response: loginResponse{},
+		},
+		"POST /login/2fa": {
+			Summary: "Complete a login with a two-factor code", Tag: "authentication", Public: true,
+			Request: twoFactorLoginRequest{}, Response: loginResponse{},
+		},
+		"GET /verify-email": {
+			Summary: "Verify the email of a user with the token mailed to them", Tag: "authentication", Public: true,
+			Query: []string{"token"}, Response: messageResponse{},
+		},
+		"POST /verify-email/resend": {
+			Summary: "Send a new email verification link", Tag: "authentication", Public: true,
+			Request: resendVerificationRequest{}, Response: messageResponse{},
+		},
+		"POST /password/forgot": {
+			Summary: "Send a password reset token", Tag: "authentication", Public: true,
+			Request: forgotPasswordRequest{}, Response: messageResponse{},
+		},
+		"POST /password/reset": {
+			Summary: "Set a new password with a reset token", Tag: "authentication", Public: true,
+			Request: resetPasswordRequest{}, Response: messageResponse{},
+		},
+
+		"GET /users": {
+			Summary: "List the users", Tag: "users", Permission: domain.PermissionUsersRead,
+			Query: []string{"q", "role", "page", "limit"}, Response: domain.UserPage{},
+		},
+		"POST /promote/:id": {
+			Summary: "Promote a user to admin", Tag: "users", Permission: domain.PermissionUsersPromote,
+			Response: messageResponse{},
+		},
+		"POST /demote/:id": {
+			Summary: "Demote an admin back to user", Tag: "users", Permission: domain.PermissionUsersDemote,
+			Response: messageResponse{},
+		},
+		"POST /users/:id/disable": {
+			Summary: "Disable a user", Tag: "users", Permission: domain.PermissionUsersDisable,
+			Response: messageResponse{},
+		},
+		"POST /users/:id/enable": {
+			Summary: "Enable a disabled user", Tag: "users", Permission: domain.PermissionUsersDisable,
+			Response: messageResponse{},
+		},
+		"DELETE /users/:id": {
+			Summary: "Delete a user, orphaning or reassigning their tasks", Tag: "users",
+			Permission: domain.PermissionUsersDelete, Query: []string{"tasks", "to"}, Response: messageResponse{},
+		},
+		"POST /users/:id/unlock": {
+			Summary: "Lift the login lockout of a user", Tag: "users", Permission: domain.PermissionUsersUnlock,
+			Response: messageResponse{},
+		},
+
+		"POST /me/2fa/enroll": {
+			Summary: "Start enrolling in two-factor authentication", Tag: "two-factor authentication",
+			Response: domain.TwoFactorEnrolment{},
+		},
+		"POST /me/2fa/confirm": {
+			Summary: "Enable two-factor authentication with a first code", Tag: "two-factor authentication",
+			Request: twoFactorCodeRequest{}, Response: recoveryCodesResponse{},
+		},
+		"POST /me/2fa/disable": {
+			Summary: "Disable two-factor authentication", Tag: "two-factor authentication",
+			Request: twoFactorCodeRequest{}, Response: messageResponse{},
+		},
+
+		"GET /me/tokens": {
+			Summary: "List your personal access tokens", Tag: "personal access tokens", Response: accessTokensResponse{},
+		},
+		"POST /me/tokens": {
+			Summary: "Create a personal access token", Tag: "personal access tokens",
+			Request: accessTokenRequest{}, Response: domain.CreatedPersonalAccessToken{}, Status: http.StatusCreated,
+		},
+		"DELETE /me/tokens/:id": {
+			Summary: "Revoke a personal access token", Tag: "personal access tokens", Response: messageResponse{},
+		},
+
+		"GET /tasks": {
+			Summary: "List the tasks of every project", Tag: "tasks", Permission: domain.PermissionTasksRead,
+			Response: []domain.Task{},
+		},
+		"GET /tasks/:id": {
+			Summary: "Get a task", Tag: "tasks", Permission: domain.PermissionTasksRead, Response: domain.Task{},
+		},
+		"POST /tasks": {
+			Summary: "Add a task, to the project given in the body if any", Tag: "tasks", Permission: domain.PermissionTasksCreate,
+			Request: taskRequest{}, Response: messageResponse{},
+		},
+		"PUT /tasks/:id": {
+			Summary: "Update the given fields of a task", Tag: "tasks", Permission: domain.PermissionTasksUpdate,
+			Request: updateTaskRequest{}, Response: messageResponse{},
+		},
+		"DELETE /tasks/:id": {
+			Summary: "Delete a task", Tag: "tasks", Permission: domain.PermissionTasksDelete, Response: messageResponse{},
+		},
+		"PUT /tasks/:id/assignees": {
+			Summary: "Replace the users assigned to a task", Tag: "tasks", Permission: domain.PermissionTasksAssign,
+			Request: assigneesRequest{}, Response: messageResponse{},
+		},
+		"PATCH /tasks/:id/status": {
+			Summary: "Change the status of a task without a project, allowed for its assignees", Tag: "tasks",
+			Request: statusRequest{}, Response: messageResponse{},
+		},
+		"GET /me/tasks": {
+			Summary: "List the tasks you are assigned to", Tag: "tasks", Permission: domain.PermissionTasksReadAssigned,
+			Response: []domain.Task{},
+		},
+
+		"GET /tasks/:id/comments": {
+			Summary: "List the comment threads of a task without a project, allowed for its assignees", Tag: "comments",
+			Query: []string{"page", "limit"}, Response: domain.CommentPage{},
+		},
+		"POST /tasks/:id/comments": {
+			Summary: "Comment on a task without a project, allowed for its assignees", Tag: "comments",
+			Permission: domain.PermissionCommentsWrite, Request: commentRequest{}, Response: domain.Comment{}, Status: http.StatusCreated,
+		},
+		"PUT /comments/:id": {
+			Summary: "Edit one of your comments", Tag: "comments", Permission: domain.PermissionCommentsWrite,
+			Request: commentRequest{}, Response: domain.Comment{},
+		},
+		"DELETE /comments/:id": {
+			Summary: "Delete a comment", Tag: "comments", Permission: domain.PermissionCommentsWrite,
+			Response: messageResponse{},
+		},
+
+		"POST /tasks/:id/attachments": {
+			Summary: "Attach a file to a task without a project, allowed for its assignees", Tag: "attachments",
+			Request: attachmentForm{}, ContentType: "multipart/form-data", Response: domain.Attachment{}, Status: http.StatusCreated,
+		},
+		"GET /tasks/:id/attachments/:attachmentID": {
+			Summary: "Download an attachment of a task without a project, allowed for its assignees", Tag: "attachments",
+		},
+		"DELETE /tasks/:id/attachments/:attachmentID": {
+			Summary: "Delete an attachment of a task without a project", Tag: "attachments", Response: messageResponse{},
+		},
+
+		"POST /projects": {
+			Summary: "Create a project you own", Tag: "projects", Permission: domain.PermissionProjectsCreate,
+			Request: projectRequest{}, Response: domain.Project{}, Status: http.StatusCreated,
+		},
+		"GET /projects": {
+			Summary: "List the projects you are a member of", Tag: "projects", Permission: domain.PermissionProjectsRead,
+			Response: []domain.Project{},
+		},
+		"GET /projects/:pid": {
+			Summary: "Get a project, allowed for viewers", Tag: "projects", Permission: domain.PermissionProjectsRead,
+			Response: domain.Project{},
+		},
+		"PUT /projects/:pid": {
+			Summary: "Update a project, allowed for owners", Tag: "projects", Permission: domain.PermissionProjectsRead,
+			Request: projectRequest{}, Response: messageResponse{},
+		},
+		"DELETE /projects/:pid": {
+			Summary: "Delete a project without tasks, allowed for owners", Tag: "projects",
+			Permission: domain.PermissionProjectsRead, Response: messageResponse{},
+		},
+		"PUT /projects/:pid/members/:uid": {
+			Summary: "Add a member to a project or change their role, allowed for owners", Tag: "projects",
+			Permission: domain.PermissionProjectsRead, Request: memberRequest{}, Response: messageResponse{},
+		},
+		"DELETE /projects/:pid/members/:uid": {
+			Summary: "Remove a member from a project, allowed for owners", Tag: "projects",
+			Permission: domain.PermissionProjectsRead, Response: messageResponse{},
+		},
+
+		"GET /projects/:pid/tasks": {
+			Summary: "List the tasks of a project, allowed for viewers", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Response: []domain.Task{},
+		},
+		"POST /projects/:pid/tasks": {
+			Summary: "Add a task to a project, allowed for members", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Request: taskRequest{}, Response: domain.Task{}, Status: http.StatusCreated,
+		},
+		"GET /projects/:pid/tasks/:id": {
+			Summary: "Get a task of a project, allowed for viewers", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Response: domain.Task{},
+		},
+		"PUT /projects/:pid/tasks/:id": {
+			Summary: "Update the given fields of a task of a project, allowed for members", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Request: updateTaskRequest{}, Response: messageResponse{},
+		},
+		"DELETE /projects/:pid/tasks/:id": {
+			Summary: "Delete a task of a project, allowed for members", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Response: messageResponse{},
+		},
+		"PUT /projects/:pid/tasks/:id/assignees": {
+			Summary: "Replace the users assigned to a task of a project, allowed for owners", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Request: assigneesRequest{}, Response: messageResponse{},
+		},
+		"PATCH /projects/:pid/tasks/:id/status": {
+			Summary: "Change the status of a task, allowed for its assignees", Tag: "project tasks",
+			Permission: domain.PermissionProjectsRead, Request: statusRequest{}, Response: messageResponse{},
+		},
+
+		"GET /projects/:pid/tasks/:id/comments": {
+			Summary: "List the comment threads of a task, allowed for viewers", Tag: "comments",
+			Permission: domain.PermissionProjectsRead, Query: []string{"page", "limit"}, Response: domain.CommentPage{},
+		},
+		"POST /projects/:pid/tasks/:id/comments": {
+			Summary: "Comment on a task or reply to a comment, allowed for members", Tag: "comments",
+			Permission: domain.PermissionProjectsRead, Request: commentRequest{}, Response: domain.Comment{}, Status: http.StatusCreated,
+		},
+
+		"POST /projects/:pid/tasks/:id/attachments": {
+			Summary: "Attach a file to a task, allowed for members", Tag: "attachments",
+			Permission: domain.PermissionProjectsRead, Request: attachmentForm{}, ContentType: "multipart/form-data",
+			Response: domain.Attachment{}, Status: http.StatusCreated,
+		},
+		"GET /projects/:pid/tasks/:id/attachments/:attachmentID": {
+			Summary: "Download an attachment, allowed for viewers", Tag: "attachments",
+			Permission: domain.PermissionProjectsRead,
+		},
+		"DELETE /projects/:pid/tasks/:id/attachments/:attachmentID": {
+			Summary: "Delete an attachment, allowed for members", Tag: "attachments",
+			Permission: domain.PermissionProjectsRead, Response: messageResponse{},
+		},
+	}
+}
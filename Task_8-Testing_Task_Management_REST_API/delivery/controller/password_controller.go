@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PasswordController struct {
+	PasswordResetUsecase domain.PasswordResetUsecase
+	Env                  *bootstrap.Env
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ForgotPassword sends a password reset token to the email in the request body.
+// The response is the same whether or not a user is registered with the email.
+func (controller *PasswordController) ForgotPassword(c *gin.Context) {
+	var request forgotPasswordRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err := controller.PasswordResetUsecase.RequestReset(c, request.Email)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "if a user is registered with this email, a reset token has been sent to it"})
+}
+
+// ResetPassword sets the password in the request body for the user the reset token was sent to.
+// Every session of the user is invalidated, so they have to log in again.
+func (controller *PasswordController) ResetPassword(c *gin.Context) {
+	var request resetPasswordRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err := controller.PasswordResetUsecase.ResetPassword(c, request.Token, request.Password)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "password has been reset, please log in again"})
+}
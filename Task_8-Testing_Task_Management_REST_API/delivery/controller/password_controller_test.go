@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordControllerTestSuite struct {
+	suite.Suite
+	mockResetUsecase *mocks.PasswordResetUsecase
+	controller       *PasswordController
+	router           *gin.Engine
+}
+
+func (suite *PasswordControllerTestSuite) SetupTest() {
+	suite.mockResetUsecase = new(mocks.PasswordResetUsecase)
+	suite.controller = &PasswordController{
+		PasswordResetUsecase: suite.mockResetUsecase,
+	}
+	suite.router = gin.Default()
+
+	// define the routes
+	suite.router.POST("/password/forgot", suite.controller.ForgotPassword)
+	suite.router.POST("/password/reset", suite.controller.ResetPassword)
+}
+
+func (suite *PasswordControllerTestSuite) TearDownTest() {
+	suite.mockResetUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *PasswordControllerTestSuite) TestForgotPassword_Success() {
+	suite.mockResetUsecase.On("RequestReset", mock.Anything, "test@example.com").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/password/forgot", bytes.NewBufferString(`{"email": "test@example.com"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "reset token has been sent")
+}
+
+func (suite *PasswordControllerTestSuite) TestForgotPassword_MailerFailure() {
+	suite.mockResetUsecase.On("RequestReset", mock.Anything, "test@example.com").Return(errors.New("smtp is down")).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/password/forgot", bytes.NewBufferString(`{"email": "test@example.com"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusInternalServerError, responseWriter.Code)
+	suite.NotContains(responseWriter.Body.String(), "smtp")
+}
+
+func (suite *PasswordControllerTestSuite) TestResetPassword_Success() {
+	suite.mockResetUsecase.On("ResetPassword", mock.Anything, "token", "new password").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/password/reset", bytes.NewBufferString(`{"token": "token", "password": "new password"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+}
+
+func (suite *PasswordControllerTestSuite) TestResetPassword_InvalidToken() {
+	suite.mockResetUsecase.On("ResetPassword", mock.Anything, "token", "new password").Return(domain.ErrInvalidToken).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/password/reset", bytes.NewBufferString(`{"token": "token", "password": "new password"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrInvalidToken.Error())
+}
+
+func (suite *PasswordControllerTestSuite) TestResetPassword_InvalidBody() {
+	request, _ := http.NewRequest(http.MethodPost, "/password/reset", bytes.NewBufferString(`{"token": `))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+}
+
+func TestPasswordControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordControllerTestSuite))
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectController struct {
+	ProjectUsecase domain.ProjectUsecase
+	Env            *bootstrap.Env
+}
+
+type projectRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+}
+
+type memberRequest struct {
+	Role string `json:"role" binding:"required,oneof=viewer member owner"`
+}
+
+// CreateProject creates a new project owned by the authenticated user.
+func (controller *ProjectController) CreateProject(c *gin.Context) {
+	var request projectRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	project := &domain.Project{Name: request.Name, Description: request.Description}
+	err = controller.ProjectUsecase.Create(c, project, userID)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetMyProjects retrieves the projects the authenticated user is a member of.
+func (controller *ProjectController) GetMyProjects(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	projects, err := controller.ProjectUsecase.GetUserProjects(c, userID)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+// GetProject retrieves the project with the ID in the path.
+func (controller *ProjectController) GetProject(c *gin.Context) {
+	project, err := controller.ProjectUsecase.GetByID(c, c.Param("pid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject changes the name and description of the project with the ID in the path.
+func (controller *ProjectController) UpdateProject(c *gin.Context) {
+	var request projectRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err := controller.ProjectUsecase.Update(c, c.Param("pid"), request.Name, request.Description)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "project updated successfully"})
+}
+
+// DeleteProject deletes the project with the ID in the path once it has no tasks left.
+func (controller *ProjectController) DeleteProject(c *gin.Context) {
+	err := controller.ProjectUsecase.Delete(c, c.Param("pid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "project deleted successfully"})
+}
+
+// SetProjectMember adds the user with the ID in the path to the project with the 'role' given in the body,
+// or changes the role of the user if they already are a member.
+func (controller *ProjectController) SetProjectMember(c *gin.Context) {
+	var request memberRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err := controller.ProjectUsecase.AddMember(c, c.Param("pid"), c.Param("uid"), request.Role)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "project member saved successfully"})
+}
+
+// RemoveProjectMember removes the user with the ID in the path from the project.
+func (controller *ProjectController) RemoveProjectMember(c *gin.Context) {
+	err := controller.ProjectUsecase.RemoveMember(c, c.Param("pid"), c.Param("uid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "project member removed successfully"})
+}
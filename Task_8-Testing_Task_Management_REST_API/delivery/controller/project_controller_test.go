@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProjectControllerTestSuite struct {
+	suite.Suite
+	mockProjectUsecase *mocks.ProjectUsecase
+	controller         *ProjectController
+	router             *gin.Engine
+	userID             string
+}
+
+func (suite *ProjectControllerTestSuite) SetupTest() {
+	suite.mockProjectUsecase = new(mocks.ProjectUsecase)
+	suite.controller = &ProjectController{
+		ProjectUsecase: suite.mockProjectUsecase,
+	}
+	suite.userID = primitive.NewObjectID().Hex()
+	suite.router = gin.Default()
+
+	// simulate the claims set by the authentication middleware
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": suite.userID, "role": "USER"})
+	})
+
+	// define the routes
+	suite.router.GET("/projects", suite.controller.GetMyProjects)
+	suite.router.POST("/projects", suite.controller.CreateProject)
+	suite.router.GET("/projects/:pid", suite.controller.GetProject)
+	suite.router.DELETE("/projects/:pid", suite.controller.DeleteProject)
+	suite.router.PUT("/projects/:pid/members/:uid", suite.controller.SetProjectMember)
+	suite.router.DELETE("/projects/:pid/members/:uid", suite.controller.RemoveProjectMember)
+}
+
+func (suite *ProjectControllerTestSuite) TearDownTest() {
+	suite.mockProjectUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *ProjectControllerTestSuite) TestCreateProject_Success() {
+	suite.mockProjectUsecase.On("Create", mock.Anything, mock.AnythingOfType("*domain.Project"), suite.userID).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/projects", bytes.NewBufferString(`{"name": "Test Project"}`))
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusCreated, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "Test Project")
+}
+
+func (suite *ProjectControllerTestSuite) TestCreateProject_EmptyName() {
+	request, _ := http.NewRequest(http.MethodPost, "/projects", bytes.NewBufferString(`{"name": ""}`))
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "name is required")
+}
+
+func (suite *ProjectControllerTestSuite) TestGetMyProjects_Success() {
+	mockProjects := []domain.Project{{ID: primitive.NewObjectID(), Name: "Test Project"}}
+
+	suite.mockProjectUsecase.On("GetUserProjects", mock.Anything, suite.userID).Return(mockProjects, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/projects", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), mockProjects[0].ID.Hex())
+}
+
+func (suite *ProjectControllerTestSuite) TestGetProject_NotFound() {
+	projectID := primitive.NewObjectID().Hex()
+
+	suite.mockProjectUsecase.On("GetByID", mock.Anything, projectID).Return(nil, domain.ErrProjectNotFound).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/projects/"+projectID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
+}
+
+func (suite *ProjectControllerTestSuite) TestDeleteProject_NotEmpty() {
+	projectID := primitive.NewObjectID().Hex()
+
+	suite.mockProjectUsecase.On("Delete", mock.Anything, projectID).Return(domain.ErrProjectNotEmpty).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/projects/"+projectID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusConflict, responseWriter.Code)
+}
+
+func (suite *ProjectControllerTestSuite) TestSetProjectMember_Success() {
+	projectID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+
+	suite.mockProjectUsecase.On("AddMember", mock.Anything, projectID, userID, domain.ProjectRoleMember).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPut, "/projects/"+projectID+"/members/"+userID, bytes.NewBufferString(`{"role": "member"}`))
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+}
+
+func (suite *ProjectControllerTestSuite) TestRemoveProjectMember_LastOwner() {
+	projectID := primitive.NewObjectID().Hex()
+
+	suite.mockProjectUsecase.On("RemoveMember", mock.Anything, projectID, suite.userID).Return(domain.ErrLastOwner).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/projects/"+projectID+"/members/"+suite.userID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusConflict, responseWriter.Code)
+}
+
+func TestProjectControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectControllerTestSuite))
+}
@@ -3,9 +3,12 @@ package controller
 import (
 	"Task_8-Testing_Task_Management_REST_API/bootstrap"
 	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TaskController struct {
@@ -13,6 +16,42 @@ type TaskController struct {
 	Env         *bootstrap.Env
 }
 
+type taskRequest struct {
+	ProjectID   string    `json:"project_id" binding:"omitempty,objectid"`
+	Title       string    `json:"title" binding:"required,max=200"`
+	Description string    `json:"description" binding:"max=2000"`
+	DueDate     time.Time `json:"duedate"`
+	Status      string    `json:"status" binding:"omitempty,task_status"`
+	Assignees   []string  `json:"assignees" binding:"omitempty,dive,objectid"`
+}
+
+// updateTaskRequest only changes the fields that are given, so none of them is required.
+type updateTaskRequest struct {
+	Title       string    `json:"title" binding:"max=200"`
+	Description string    `json:"description" binding:"max=2000"`
+	DueDate     time.Time `json:"duedate"`
+	Status      string    `json:"status" binding:"omitempty,task_status"`
+}
+
+// toTask converts the validated request to a new task, pending unless another status is given.
+func (request taskRequest) toTask() *domain.Task {
+	task := &domain.Task{
+		Title:       request.Title,
+		Description: request.Description,
+		DueDate:     request.DueDate,
+		Status:      request.Status,
+	}
+
+	// the IDs are known to be valid once the request is bound
+	task.ProjectID, _ = primitive.ObjectIDFromHex(request.ProjectID)
+	for _, assignee := range request.Assignees {
+		assigneeID, _ := primitive.ObjectIDFromHex(assignee)
+		task.Assignees = append(task.Assignees, assigneeID)
+	}
+
+	return task
+}
+
 // GetAllTasks retrieves all tasks from the database and returns them as a JSON response.
 func (controller *TaskController) GetAllTasks(c *gin.Context) {
 	tasks, err := controller.TaskUsecase.GetTasks(c)
@@ -40,22 +79,20 @@ func (controller *TaskController) GetTask(c *gin.Context) {
 
 // CreateTask is a method of the TaskController struct that handles the creation of a new task.
 // It takes a gin.Context object as a parameter, which represents the HTTP request and response.
-// The function first binds the JSON data from the request body to a new_task variable.
-// If the request body is invalid, it returns a JSON response with an error message.
+// The function first binds the JSON data from the request body to a taskRequest and validates it.
+// If the request body is malformed or has invalid fields, it returns a JSON response with the errors.
 // Otherwise, it calls the Create method of the TaskUsecase to create the task.
 // If an error occurs during the creation process, it returns a JSON response with the error message.
 // Finally, it returns a JSON response with a success message if the task is created successfully.
 func (controller *TaskController) CreateTask(c *gin.Context) {
-	var new_task domain.Task
-
-	if e := c.BindJSON(&new_task); e != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	var request taskRequest
+	if !bindJSON(c, &request) {
 		return
 	}
 
-	err := controller.TaskUsecase.Create(c, &new_task)
+	err := controller.TaskUsecase.Create(c, request.toTask())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		respondWithError(c, err)
 		return
 	}
 
@@ -64,20 +101,26 @@ func (controller *TaskController) CreateTask(c *gin.Context) {
 
 // UpdateTask updates a task with the given ID.
 // It receives a JSON payload containing the updated task information.
-// If the request body is invalid, it returns a 400 Bad Request response.
+// If the request body is malformed, it returns a 400 Bad Request response,
+// and if some of its fields are invalid, a 422 Unprocessable Entity response.
 // If the task with the given ID is not found, it returns a 404 Not Found response.
 // Otherwise, it updates the task and returns a 200 OK response.
 func (controller *TaskController) UpdateTask(c *gin.Context) {
-	var updated_task domain.Task
+	var request updateTaskRequest
 	id := c.Param("id")
 
-	err := c.BindJSON(&updated_task)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	if !bindJSON(c, &request) {
 		return
 	}
 
-	err = controller.TaskUsecase.UpdateTask(c, id, &updated_task)
+	updated_task := domain.Task{
+		Title:       request.Title,
+		Description: request.Description,
+		DueDate:     request.DueDate,
+		Status:      request.Status,
+	}
+
+	err := controller.TaskUsecase.UpdateTask(c, id, &updated_task)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
 		return
@@ -98,3 +141,151 @@ func (controller *TaskController) DeleteTask(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "task deleted successfully"})
 }
+
+type assigneesRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required"`
+}
+
+type statusRequest struct {
+	Status string `json:"status" binding:"required,task_status"`
+}
+
+// AssignUsers replaces the users assigned to the task with the given ID.
+// It expects a JSON body with the IDs of the users in 'user_ids'.
+// If one of the users does not exist, it returns a 400 Bad Request response.
+func (controller *TaskController) AssignUsers(c *gin.Context) {
+	var request assigneesRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err := controller.TaskUsecase.AssignUsers(c, c.Param("id"), request.UserIDs)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "task assignees updated successfully"})
+}
+
+// UpdateTaskStatus changes the status of the task with the given ID.
+// Users assigned to the task and admins are allowed to change its status.
+func (controller *TaskController) UpdateTaskStatus(c *gin.Context) {
+	var request statusRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	userRole, err := infrastructure.GetUserRoleFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	err = controller.TaskUsecase.UpdateStatus(c, c.Param("id"), userID, userRole, request.Status)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "task status updated successfully"})
+}
+
+// GetMyTasks retrieves the tasks the authenticated user is assigned to.
+func (controller *TaskController) GetMyTasks(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	tasks, err := controller.TaskUsecase.GetAssignedTasks(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// GetProjectTasks retrieves the tasks of the project with the ID in the path.
+func (controller *TaskController) GetProjectTasks(c *gin.Context) {
+	tasks, err := controller.TaskUsecase.GetProjectTasks(c, c.Param("pid"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// CreateProjectTask adds a new task to the project with the ID in the path.
+func (controller *TaskController) CreateProjectTask(c *gin.Context) {
+	var request taskRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	projectID, err := primitive.ObjectIDFromHex(c.Param("pid"))
+	if err != nil {
+		respondWithError(c, domain.ErrProjectNotFound)
+		return
+	}
+	new_task := request.toTask()
+	new_task.ProjectID = projectID
+
+	err = controller.TaskUsecase.Create(c, new_task)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, new_task)
+}
+
+// RequireTaskInProject is a middleware that makes sure the task with the ID in the 'id' path parameter
+// belongs to the project with the ID in the 'pid' path parameter, so that the task handlers can be
+// reused for project-scoped routes.
+func (controller *TaskController) RequireTaskInProject(c *gin.Context) {
+	task, err := controller.TaskUsecase.GetTaskByID(c, c.Param("id"))
+	if err != nil || task.ProjectID.Hex() != c.Param("pid") {
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+// RequireTaskWithoutProject is a middleware that makes sure the task with the ID in the 'id' path parameter
+// doesn't belong to a project and can be read by the user, so that the task handlers can be reused for the
+// tasks without a project.
+func (controller *TaskController) RequireTaskWithoutProject(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		c.Abort()
+		return
+	}
+
+	userRole, err := infrastructure.GetUserRoleFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		c.Abort()
+		return
+	}
+
+	if _, err := controller.TaskUsecase.GetTaskWithoutProject(c, c.Param("id"), userID, userRole); err != nil {
+		respondWithError(c, err)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
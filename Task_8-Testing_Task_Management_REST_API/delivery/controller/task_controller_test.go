@@ -6,11 +6,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -37,6 +39,17 @@ func (suite *TaskControllerTestSuite) SetupSuite() {
 	suite.router.POST("/tasks", suite.controller.CreateTask)
 	suite.router.PUT("/tasks/:id", suite.controller.UpdateTask)
 	suite.router.DELETE("/tasks/:id", suite.controller.DeleteTask)
+	suite.router.PUT("/tasks/:id/assignees", suite.controller.AssignUsers)
+	suite.router.GET("/projects/:pid/tasks", suite.controller.GetProjectTasks)
+	suite.router.POST("/projects/:pid/tasks", suite.controller.CreateProjectTask)
+	suite.router.GET("/projects/:pid/tasks/:id", suite.controller.RequireTaskInProject, suite.controller.GetTask)
+
+	// routes that need the claims set by the authentication middleware
+	authenticated := suite.router.Group("", func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": "test-user-id", "role": "USER"})
+	})
+	authenticated.PATCH("/tasks/:id/status", suite.controller.RequireTaskWithoutProject, suite.controller.UpdateTaskStatus)
+	authenticated.GET("/me/tasks", suite.controller.GetMyTasks)
 }
 
 func (suite *TaskControllerTestSuite) TearDownSuite() {
@@ -103,7 +116,7 @@ func (suite *TaskControllerTestSuite) TestCreateTask_Success() {
 		Title:       "Test Task",
 		Description: "Test Task Description",
 		DueDate:     time.Now(),
-		Status:      "Test Status",
+		Status:      domain.TaskStatusPending,
 	}
 
 	suite.mockTaskUsecase.On("Create", mock.Anything, mock.AnythingOfType("*domain.Task")).Return(nil).Once()
@@ -119,12 +132,44 @@ func (suite *TaskControllerTestSuite) TestCreateTask_Success() {
 	suite.Contains(responseWriter.Body.String(), "task added successfully")
 }
 
+func (suite *TaskControllerTestSuite) TestCreateTask_InvalidFields() {
+	request, _ := http.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{"title": "", "status": "someday", "assignees": ["not an id"]}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	var response struct {
+		Error  string              `json:"error"`
+		Fields []domain.FieldError `json:"fields"`
+	}
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.NoError(json.Unmarshal(responseWriter.Body.Bytes(), &response))
+	suite.Equal("validation failed", response.Error)
+	suite.Equal([]domain.FieldError{
+		{Field: "title", Code: "required", Message: "title is required"},
+		{Field: "status", Code: "task_status", Message: "status must be one of pending, in_progress, done"},
+		{Field: "assignees[0]", Code: "objectid", Message: "assignees[0] must be a valid id"},
+	}, response.Fields)
+}
+
+func (suite *TaskControllerTestSuite) TestCreateTask_MalformedBody() {
+	request, _ := http.NewRequest(http.MethodPost, "/tasks", bytes.NewBufferString(`{"title": `))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "invalid request body")
+}
+
 func (suite *TaskControllerTestSuite) TestUpdateTask_Success() {
 	updatedTask := domain.Task{
 		Title:       "Updated Task",
 		Description: "Updated Task Description",
 		DueDate:     time.Now(),
-		Status:      "Updated Status",
+		Status:      domain.TaskStatusInProgress,
 	}
 
 	suite.mockTaskUsecase.On("UpdateTask", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("*domain.Task")).Return(nil).Once()
@@ -145,7 +190,7 @@ func (suite *TaskControllerTestSuite) TestUpdateTask_TaskNotFound() {
 		Title:       "Updated Task",
 		Description: "Updated Task Description",
 		DueDate:     time.Now(),
-		Status:      "Updated Status",
+		Status:      domain.TaskStatusInProgress,
 	}
 
 	suite.mockTaskUsecase.On("UpdateTask", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("*domain.Task")).Return(errors.New("task not found")).Once()
@@ -183,6 +228,158 @@ func (suite *TaskControllerTestSuite) TestDeleteTask_TaskNotFound() {
 	suite.Contains(responseWriter.Body.String(), "task not found")
 }
 
+func (suite *TaskControllerTestSuite) TestAssignUsers_Success() {
+	suite.mockTaskUsecase.On("AssignUsers", mock.Anything, "TASK_ID", []string{"USER_ID"}).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPut, "/tasks/TASK_ID/assignees", bytes.NewBufferString(`{"user_ids": ["USER_ID"]}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "task assignees updated successfully")
+}
+
+func (suite *TaskControllerTestSuite) TestAssignUsers_UnknownUser() {
+	suite.mockTaskUsecase.On("AssignUsers", mock.Anything, "TASK_ID", []string{"UNKNOWN"}).Return(fmt.Errorf("%w: user 'UNKNOWN' does not exist", domain.ErrInvalidInput)).Once()
+
+	request, _ := http.NewRequest(http.MethodPut, "/tasks/TASK_ID/assignees", bytes.NewBufferString(`{"user_ids": ["UNKNOWN"]}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "does not exist")
+}
+
+func (suite *TaskControllerTestSuite) TestUpdateTaskStatus_ByAssignee() {
+	// a user without the 'tasks:update' permission, assigned to the task
+	mockTask := domain.Task{ID: primitive.NewObjectID(), Assignees: []primitive.ObjectID{primitive.NewObjectID()}}
+	suite.mockTaskUsecase.On("GetTaskWithoutProject", mock.Anything, "TASK_ID", "test-user-id", "USER").Return(mockTask, nil).Once()
+	suite.mockTaskUsecase.On("UpdateStatus", mock.Anything, "TASK_ID", "test-user-id", "USER", "done").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPatch, "/tasks/TASK_ID/status", bytes.NewBufferString(`{"status": "done"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "task status updated successfully")
+}
+
+func (suite *TaskControllerTestSuite) TestUpdateTaskStatus_TaskInProject() {
+	suite.mockTaskUsecase.On("GetTaskWithoutProject", mock.Anything, "PROJECT_TASK_ID", "test-user-id", "USER").Return(domain.Task{}, domain.ErrTaskNotFound).Once()
+
+	request, _ := http.NewRequest(http.MethodPatch, "/tasks/PROJECT_TASK_ID/status", bytes.NewBufferString(`{"status": "done"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	// the status of the tasks of a project is changed through the project
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
+	suite.mockTaskUsecase.AssertNotCalled(suite.T(), "UpdateStatus", mock.Anything, "PROJECT_TASK_ID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *TaskControllerTestSuite) TestUpdateTaskStatus_Forbidden() {
+	suite.mockTaskUsecase.On("GetTaskWithoutProject", mock.Anything, "TASK_ID", "test-user-id", "USER").Return(domain.Task{}, nil).Once()
+	suite.mockTaskUsecase.On("UpdateStatus", mock.Anything, "TASK_ID", "test-user-id", "USER", "done").Return(domain.ErrForbidden).Once()
+
+	request, _ := http.NewRequest(http.MethodPatch, "/tasks/TASK_ID/status", bytes.NewBufferString(`{"status": "done"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusForbidden, responseWriter.Code)
+}
+
+func (suite *TaskControllerTestSuite) TestUpdateTaskStatus_UnknownStatus() {
+	suite.mockTaskUsecase.On("GetTaskWithoutProject", mock.Anything, "TASK_ID", "test-user-id", "USER").Return(domain.Task{}, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPatch, "/tasks/TASK_ID/status", bytes.NewBufferString(`{"status": "someday"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "status must be one of pending, in_progress, done")
+}
+
+func (suite *TaskControllerTestSuite) TestGetMyTasks_Success() {
+	mockTasks := []domain.Task{
+		{ID: primitive.NewObjectID(), Title: "Assigned Task", Status: "in progress"},
+	}
+
+	suite.mockTaskUsecase.On("GetAssignedTasks", mock.Anything, "test-user-id").Return(mockTasks, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/me/tasks", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "Assigned Task")
+}
+
+func (suite *TaskControllerTestSuite) TestGetProjectTasks_Success() {
+	projectID := primitive.NewObjectID()
+	mockTasks := []domain.Task{{ID: primitive.NewObjectID(), ProjectID: projectID, Title: "Project Task"}}
+
+	suite.mockTaskUsecase.On("GetProjectTasks", mock.Anything, projectID.Hex()).Return(mockTasks, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/projects/"+projectID.Hex()+"/tasks", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "Project Task")
+}
+
+func (suite *TaskControllerTestSuite) TestCreateProjectTask_SetsProject() {
+	projectID := primitive.NewObjectID()
+
+	suite.mockTaskUsecase.On("Create", mock.Anything, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.ProjectID == projectID && task.Title == "Project Task"
+	})).Return(nil).Once()
+
+	// a project ID in the body is overridden by the one in the path
+	body := fmt.Sprintf(`{"title": "Project Task", "project_id": "%v"}`, primitive.NewObjectID().Hex())
+	request, _ := http.NewRequest(http.MethodPost, "/projects/"+projectID.Hex()+"/tasks", bytes.NewBufferString(body))
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusCreated, responseWriter.Code)
+}
+
+func (suite *TaskControllerTestSuite) TestRequireTaskInProject_OtherProject() {
+	mockTask := domain.Task{ID: primitive.NewObjectID(), ProjectID: primitive.NewObjectID()}
+
+	suite.mockTaskUsecase.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/projects/"+primitive.NewObjectID().Hex()+"/tasks/"+mockTask.ID.Hex(), nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
+}
+
+func (suite *TaskControllerTestSuite) TestRequireTaskInProject_SameProject() {
+	mockTask := domain.Task{ID: primitive.NewObjectID(), ProjectID: primitive.NewObjectID(), Title: "Project Task"}
+
+	suite.mockTaskUsecase.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Twice()
+
+	request, _ := http.NewRequest(http.MethodGet, "/projects/"+mockTask.ProjectID.Hex()+"/tasks/"+mockTask.ID.Hex(), nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "Project Task")
+}
+
 func TestTaskControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(TaskControllerTestSuite))
 }
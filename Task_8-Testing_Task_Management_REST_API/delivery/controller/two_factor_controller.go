@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorController struct {
+	TwoFactorUsecase domain.TwoFactorUsecase
+	Env              *bootstrap.Env
+}
+
+type twoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Enroll generates a TOTP secret for the authenticated user and returns it, along with the 'otpauth://' URI
+// to show as a QR code. Two-factor authentication is only enabled once confirmed with a code.
+func (controller *TwoFactorController) Enroll(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	enrolment, err := controller.TwoFactorUsecase.Enroll(c, userID)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrolment)
+}
+
+// Confirm enables two-factor authentication for the authenticated user with the first code of their authenticator app.
+// The response carries the recovery codes, which are shown only once.
+func (controller *TwoFactorController) Confirm(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	var request twoFactorCodeRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	recoveryCodes, err := controller.TwoFactorUsecase.Confirm(c, userID, request.Code)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication enabled", "recovery_codes": recoveryCodes})
+}
+
+// Disable turns two-factor authentication off for the authenticated user, given a code or a recovery code.
+func (controller *TwoFactorController) Disable(c *gin.Context) {
+	userID, err := infrastructure.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+		return
+	}
+
+	var request twoFactorCodeRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err = controller.TwoFactorUsecase.Disable(c, userID, request.Code)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication disabled"})
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TwoFactorControllerTestSuite struct {
+	suite.Suite
+	mockTwoFactorUsecase *mocks.TwoFactorUsecase
+	controller           *TwoFactorController
+	router               *gin.Engine
+	userID               string
+}
+
+func (suite *TwoFactorControllerTestSuite) SetupTest() {
+	suite.mockTwoFactorUsecase = new(mocks.TwoFactorUsecase)
+	suite.controller = &TwoFactorController{
+		TwoFactorUsecase: suite.mockTwoFactorUsecase,
+	}
+	suite.userID = primitive.NewObjectID().Hex()
+	suite.router = gin.Default()
+
+	// simulate the claims set by the authentication middleware
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": suite.userID, "role": "USER"})
+	})
+
+	// define the routes
+	suite.router.POST("/me/2fa/enroll", suite.controller.Enroll)
+	suite.router.POST("/me/2fa/confirm", suite.controller.Confirm)
+	suite.router.POST("/me/2fa/disable", suite.controller.Disable)
+}
+
+func (suite *TwoFactorControllerTestSuite) TearDownTest() {
+	suite.mockTwoFactorUsecase.AssertExpectations(suite.T())
+}
+
+func (suite *TwoFactorControllerTestSuite) TestEnroll_Success() {
+	enrolment := &domain.TwoFactorEnrolment{Secret: "SECRET", URI: "otpauth://totp/TaskManager:test@example.com?secret=SECRET"}
+	suite.mockTwoFactorUsecase.On("Enroll", mock.Anything, suite.userID).Return(enrolment, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/me/2fa/enroll", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), `"otpauth_uri":"otpauth://totp/`)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestEnroll_AlreadyEnabled() {
+	suite.mockTwoFactorUsecase.On("Enroll", mock.Anything, suite.userID).Return(nil, domain.ErrTwoFactorAlreadyEnabled).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/me/2fa/enroll", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusConflict, responseWriter.Code)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirm_ReturnsRecoveryCodes() {
+	suite.mockTwoFactorUsecase.On("Confirm", mock.Anything, suite.userID, "123456").Return([]string{"abcd-efgh"}, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/me/2fa/confirm", bytes.NewBufferString(`{"code": "123456"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), `"recovery_codes":["abcd-efgh"]`)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestConfirm_InvalidCode() {
+	suite.mockTwoFactorUsecase.On("Confirm", mock.Anything, suite.userID, "000000").Return(nil, domain.ErrInvalidTwoFactorCode).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/me/2fa/confirm", bytes.NewBufferString(`{"code": "000000"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+}
+
+func (suite *TwoFactorControllerTestSuite) TestDisable_RequiredForRole() {
+	suite.mockTwoFactorUsecase.On("Disable", mock.Anything, suite.userID, "123456").Return(domain.ErrTwoFactorRequired).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/me/2fa/disable", bytes.NewBufferString(`{"code": "123456"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusForbidden, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrTwoFactorRequired.Error())
+}
+
+func TestTwoFactorControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorControllerTestSuite))
+}
@@ -7,31 +7,77 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserController struct {
-	UserUsecase domain.UserUsecase
-	Env         *bootstrap.Env
+	UserUsecase              domain.UserUsecase
+	EmailVerificationUsecase domain.EmailVerificationUsecase
+	LoginAttemptUsecase      domain.LoginAttemptUsecase
+	TwoFactorUsecase         domain.TwoFactorUsecase
+	PasswordHasher           domain.PasswordHasher
+	PasswordPolicy           domain.PasswordPolicy
+	Env                      *bootstrap.Env
+}
+
+type registerRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Role     string `json:"role" binding:"required"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type resendVerificationRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type twoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
 }
 
 // ValidateUserInfo validates the user information before performing any operations.
-// It checks if the password is at least 6 characters long, if the user role is either 'USER' or 'ADMIN',
-// and if the name field is not empty. It also checks if there are any existing users in the system.
-// If the user role is 'ADMIN' and there are existing users, it returns an error indicating that
-// an admin can only be registered if no users exist.
-// If all validations pass, it returns nil.
+// It checks if the email is a valid address, which it normalizes, if the password complies with the password policy,
+// if the user role is one of the configured roles, and if the name field is not empty.
+// Once these fields are valid, it also checks if there are any existing users in the system.
+// If the user role is not 'USER' and there are existing users, it reports that
+// only the first user can be registered with a privileged role.
+// Every invalid field is reported in the returned domain.ValidationError. If all validations pass, it returns nil.
 func (controller *UserController) ValidateUserInfo(c context.Context, user *domain.User) error {
-	if len(user.Password) < 6 {
-		return errors.New("password must be atleast 6 characters long")
+	var validation domain.ValidationError
+
+	email, err := domain.NormalizeEmail(user.Email)
+	if err != nil {
+		validation.Add("email", "email", fmt.Sprintf("'%v' is not a valid email address", user.Email))
+	} else {
+		user.Email = email
 	}
-	if user.Role != "ADMIN" && user.Role != "USER" {
-		return errors.New("invalid user role, user role is either 'USER' or 'ADMIN'")
+
+	var policyErr *domain.ValidationError
+	if err := controller.PasswordPolicy.Validate(user.Password); errors.As(err, &policyErr) {
+		validation.Fields = append(validation.Fields, policyErr.Fields...)
+	} else if err != nil {
+		return err
 	}
+
 	if len(user.Name) == 0 {
-		return errors.New("empty name field not allowed")
+		validation.Add("name", "required", "name is required")
+	}
+
+	if !controller.Env.Roles().Exists(user.Role) {
+		validation.Add("role", "role", fmt.Sprintf("invalid user role '%v'", user.Role))
+	}
+	if len(validation.Fields) > 0 {
+		return &validation
 	}
 
 	usersExist, err := controller.UserUsecase.AreThereAnyUsers(c)
@@ -39,34 +85,50 @@ func (controller *UserController) ValidateUserInfo(c context.Context, user *doma
 		return err
 	}
 
-	if user.Role == "ADMIN" && usersExist {
-		fmt.Println(usersExist)
-		return errors.New("admin can only be registered if no users exist")
+	if user.Role != domain.RoleUser && usersExist {
+		validation.Add("role", "role", fmt.Sprintf("'%v' can only be registered if no users exist", user.Role))
 	}
 
-	return nil
+	return validation.Err()
 }
 
 // HandelUserRegister handles the registration of a new user.
 // It receives the user registration information from the request body,
 // validates the information, checks if the user already exists,
-// hashes the password, and adds the user to the database.
+// hashes the password, and adds the user to the database with an unverified email.
+// A verification link is then mailed to the user.
 // If successful, it returns a JSON response with a success message.
-// If there are any errors, it returns a JSON response with an error message.
+// Invalid fields are all listed in one 422 Unprocessable Entity response, whether they are
+// found when binding the body or by ValidateUserInfo, and an email
+// that is already registered gets a 409 Conflict response.
 func (controller *UserController) HandelUserRegister(context *gin.Context) {
-	var curr_user *domain.User
+	var request registerRequest
 
 	// get inputed info from body
-	err := context.ShouldBindJSON(&curr_user)
-	if err != nil {
-		context.JSON(400, gin.H{"error": "invalid request"})
+	validation, ok := bindJSONFields(context, &request)
+	if !ok {
 		return
 	}
 
-	// check validity of the info entered entered for the new user
-	err = controller.ValidateUserInfo(context, curr_user)
-	if err != nil {
-		context.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+	curr_user := &domain.User{
+		Name:     request.Name,
+		Email:    request.Email,
+		Password: request.Password,
+		Role:     request.Role,
+	}
+
+	// check validity of the info entered entered for the new user, along with the fields checked when binding
+	var domainValidation *domain.ValidationError
+	err := controller.ValidateUserInfo(context, curr_user)
+	if errors.As(err, &domainValidation) {
+		validation.Merge(domainValidation)
+	} else if err != nil {
+		respondWithError(context, err)
+		return
+	}
+
+	if err := validation.Err(); err != nil {
+		respondWithError(context, err)
 		return
 	}
 
@@ -78,63 +140,215 @@ func (controller *UserController) HandelUserRegister(context *gin.Context) {
 	}
 
 	if existingUser != nil {
-		context.JSON(http.StatusNotAcceptable, gin.H{"error": "user already exists"})
+		respondWithError(context, domain.ErrUserExists)
 		return
 	}
 
 	// hash inputed password
-	hashedPassword, err := infrastructure.HashPassword(curr_user.Password)
+	hashedPassword, err := controller.PasswordHasher.Hash(curr_user.Password)
 	if err != nil {
 		context.JSON(500, gin.H{"error": "internal server error"})
 		return
 	}
-	curr_user.Password = string(hashedPassword)
+	curr_user.Password = hashedPassword
 
 	// add user to database
+	curr_user.EmailVerified = false
 	err = controller.UserUsecase.Create(context, curr_user)
+	// the unique index on the email catches a registration racing with another one for the same email
+	if errors.Is(err, domain.ErrUserExists) {
+		respondWithError(context, err)
+		return
+	}
 	if err != nil {
 		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	context.JSON(200, gin.H{"message": "user registered successfully"})
+	// the account exists even if the link could not be sent, a new one can be requested
+	err = controller.EmailVerificationUsecase.SendVerification(context, curr_user)
+	if err != nil {
+		context.JSON(200, gin.H{"message": "user registered successfully, but the verification email could not be sent"})
+		return
+	}
+
+	context.JSON(200, gin.H{"message": "user registered successfully, a verification link has been sent to your email"})
 }
 
 // HandelUserLogin handles the user login functionality.
 // It receives a request context and expects the user information to be provided in the request body as JSON.
-// It checks if the user exists and if the provided password is correct.
+// It checks if the user exists and if the provided password is correct, answering both cases the same way
+// so that callers cannot find out which emails are registered. Logins are counted per account and per IP before the
+// password is checked, and taken back once they succeed. Further attempts after failed ones are delayed and eventually
+// locked out, with a 'Retry-After' header telling when to try again.
+// Disabled users are refused as if their password was wrong.
 // If the user exists and the password is correct, it generates a signed JWT token and returns it in the response.
+// When REQUIRE_EMAIL_VERIFICATION is set, users who have not verified their email are refused a token.
+// Users with two-factor authentication enabled get a short-lived challenge token instead,
+// to be exchanged for a JWT token with a code at '/login/2fa'.
 // The token can be used for authentication in subsequent requests.
 // If there are any errors during the process, appropriate error responses are returned.
 func (controller *UserController) HandelUserLogin(context *gin.Context) {
-	var curr_user *domain.User
+	var curr_user loginRequest
 
 	// get inputed info from body
-	err := context.ShouldBindJSON(&curr_user)
+	if !bindJSON(context, &curr_user) {
+		return
+	}
+
+	// no user can be registered with an invalid email, but the attempt still counts as a failure
+	email, emailErr := domain.NormalizeEmail(curr_user.Email)
+	if emailErr != nil {
+		email = curr_user.Email
+	}
+	ip := context.ClientIP()
+
+	wait, err := controller.LoginAttemptUsecase.Attempt(context, email, ip)
 	if err != nil {
-		context.JSON(400, gin.H{"error": "invalid request"})
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if wait > 0 {
+		context.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		context.JSON(http.StatusTooManyRequests, gin.H{"error": domain.ErrTooManyAttempts.Error()})
 		return
 	}
 
-	// check if user already exists
-	existingUser, err := controller.UserUsecase.GetByEmail(context, curr_user.Email)
+	// check if user exists
+	var existingUser *domain.User
+	if emailErr == nil {
+		existingUser, err = controller.UserUsecase.GetByEmail(context, email)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+	}
+
+	// check if user has inputed the correct password
+	valid := false
+	if existingUser != nil {
+		valid, err = controller.PasswordHasher.Verify(curr_user.Password, existingUser.Password)
+		if err != nil {
+			context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+	} else {
+		controller.PasswordHasher.SimulateVerify(curr_user.Password)
+	}
+
+	// disabled users are refused the same way, so that the response doesn't reveal their password is right
+	if valid && existingUser.Disabled {
+		valid = false
+	}
+
+	// the attempt is already counted as a failure
+	if !valid {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": domain.ErrBadCredentials.Error()})
+		return
+	}
+
+	// the password is known now, so a hash made with older settings can be upgraded
+	if controller.PasswordHasher.NeedsRehash(existingUser.Password) {
+		controller.rehashPassword(context, existingUser, curr_user.Password)
+	}
+
+	// the failed logins are only forgotten once the second factor is checked too
+	if existingUser.TwoFactorEnabled {
+		err = controller.LoginAttemptUsecase.Forgive(context, email, ip)
+	} else {
+		err = controller.LoginAttemptUsecase.RecordSuccess(context, email, ip)
+	}
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
-	if existingUser == nil {
-		context.JSON(http.StatusNotFound, gin.H{"error": "user doesn't exist"})
+	if controller.Env.RequireEmailVerified && !existingUser.EmailVerified {
+		context.JSON(http.StatusForbidden, gin.H{"error": domain.ErrEmailUnverified.Error()})
 		return
 	}
 
-	// check if user has inputed the correct password
-	err = infrastructure.ValidatePassword(curr_user.Password, existingUser.Password)
+	if existingUser.TwoFactorEnabled {
+		context.JSON(200, gin.H{
+			"message":         "two-factor code required",
+			"challenge_token": controller.TwoFactorUsecase.CreateChallenge(existingUser),
+		})
+		return
+	}
+
+	controller.respondWithAccessToken(context, existingUser)
+}
+
+// HandleTwoFactorLogin completes the login of a user with two-factor authentication enabled.
+// It expects the challenge token returned by HandelUserLogin and a code of the authenticator app of the user,
+// or one of their recovery codes, and returns a signed JWT token. Wrong codes count as failed logins.
+func (controller *UserController) HandleTwoFactorLogin(context *gin.Context) {
+	var request twoFactorLoginRequest
+	if !bindJSON(context, &request) {
+		return
+	}
+
+	existingUser, err := controller.TwoFactorUsecase.GetChallengeUser(context, request.ChallengeToken)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": "login challenge is invalid or has expired"})
+		return
+	}
 	if err != nil {
-		context.JSON(http.StatusUnauthorized, gin.H{"error": "Incorrect password"})
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
+	ip := context.ClientIP()
+	wait, err := controller.LoginAttemptUsecase.Attempt(context, existingUser.Email, ip)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+	if wait > 0 {
+		context.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		context.JSON(http.StatusTooManyRequests, gin.H{"error": domain.ErrTooManyAttempts.Error()})
+		return
+	}
+
+	err = controller.TwoFactorUsecase.VerifyCode(context, existingUser, request.Code)
+	if errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": domain.ErrInvalidTwoFactorCode.Error()})
+		return
+	}
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	err = controller.LoginAttemptUsecase.RecordSuccess(context, existingUser.Email, ip)
+	if err != nil {
+		context.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	controller.respondWithAccessToken(context, existingUser)
+}
+
+// rehashPassword hashes the password of the user again with the current algorithm and parameters.
+// The login goes on if it fails, as the old hash is still valid, and the hash is left alone if the password
+// was changed meanwhile.
+func (controller *UserController) rehashPassword(c context.Context, user *domain.User, password string) {
+	hashedPassword, err := controller.PasswordHasher.Hash(password)
+	if err == nil {
+		var replaced bool
+		replaced, err = controller.UserUsecase.ReplacePasswordHash(c, user.UserID.Hex(), user.Password, hashedPassword)
+		if replaced {
+			user.Password = hashedPassword
+		}
+	}
+
+	if err != nil {
+		infrastructure.LoggerFromContext(c).Warn("failed to rehash the password", "user_id", user.UserID.Hex(), "error", err)
+	}
+}
+
+// respondWithAccessToken answers a successful login with a signed JWT token for the user.
+func (controller *UserController) respondWithAccessToken(context *gin.Context, existingUser *domain.User) {
 	accessTokenExp := controller.Env.AccessTokenExpiryHour
 	accessTokenSecret := controller.Env.AccessTokenSecret
 
@@ -160,33 +374,139 @@ func (controller *UserController) HandleUserPromotion(c *gin.Context) {
 	id := c.Param("id")
 
 	existingUser, err := controller.UserUsecase.GetByID(c, id)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		return
+	}
 	if err != nil {
-		fmt.Println(1)
+		infrastructure.LoggerFromContext(c).Error("failed to get the user to promote", "user_id", id, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	if existingUser == nil {
-		fmt.Println(2)
 		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
 		return
 	}
 
-	if existingUser.Role == "ADMIN" {
-		fmt.Println(3)
+	if existingUser.Role == domain.RoleAdmin {
 		c.JSON(http.StatusOK, gin.H{"message": "user is already an admin"})
 		return
 	}
 
 	// promote user to 'ADMIN'
-	existingUser.Role = "ADMIN"
-
-	// Save the changes in the database
-	err = controller.UserUsecase.UpdateUser(c, existingUser)
+	err = controller.UserUsecase.Promote(c, id)
 	if err != nil {
-		fmt.Println(4)
+		infrastructure.LoggerFromContext(c).Error("failed to promote the user", "user_id", id, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "user promoted to admin status"})
 }
+
+// GetUsers retrieves one page of the users, optionally filtered by a 'q' query parameter
+// matched against their name and email and by a 'role' query parameter.
+// The pages are selected with the 'page' and 'limit' query parameters.
+func (controller *UserController) GetUsers(c *gin.Context) {
+	filter := domain.UserFilter{
+		Query: c.Query("q"),
+		Role:  c.Query("role"),
+	}
+
+	page, err := controller.UserUsecase.GetUsers(c, filter, getPagination(c))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// HandleUserDemotion handles the demotion of a user back to the 'USER' role.
+// The last active admin cannot be demoted, so that the system is never left without an admin.
+func (controller *UserController) HandleUserDemotion(c *gin.Context) {
+	err := controller.UserUsecase.Demote(c, c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user demoted to user status"})
+}
+
+// DisableUser disables the account of a user. The user is rejected on their next request,
+// even if they still hold a valid token.
+func (controller *UserController) DisableUser(c *gin.Context) {
+	err := controller.UserUsecase.SetDisabled(c, c.Param("id"), true)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user disabled successfully"})
+}
+
+// EnableUser re-enables the account of a disabled user.
+func (controller *UserController) EnableUser(c *gin.Context) {
+	err := controller.UserUsecase.SetDisabled(c, c.Param("id"), false)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user enabled successfully"})
+}
+
+// DeleteUser deletes a user. The 'tasks' query parameter decides what happens to the tasks assigned to the user:
+// 'orphan', the default, only unassigns the user, while 'reassign' hands the tasks and the project memberships
+// of the user over to the user whose ID is given in the 'to' query parameter.
+func (controller *UserController) DeleteUser(c *gin.Context) {
+	taskPolicy := c.DefaultQuery("tasks", domain.TaskPolicyOrphan)
+
+	err := controller.UserUsecase.Delete(c, c.Param("id"), taskPolicy, c.Query("to"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user deleted successfully"})
+}
+
+// UnlockUser lifts the login lockout of the user with the ID in the path and forgets their failed logins.
+func (controller *UserController) UnlockUser(c *gin.Context) {
+	err := controller.LoginAttemptUsecase.Unlock(c, c.Param("id"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unlocked"})
+}
+
+// VerifyEmail marks the email of a user as verified using the token from the link mailed to them.
+func (controller *UserController) VerifyEmail(c *gin.Context) {
+	err := controller.EmailVerificationUsecase.Verify(c, c.Query("token"))
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "email verified successfully"})
+}
+
+// ResendVerification mails a new verification link to the email in the request body.
+// The response is the same whether or not an unverified user is registered with the email.
+func (controller *UserController) ResendVerification(c *gin.Context) {
+	var request resendVerificationRequest
+	if !bindJSON(c, &request) {
+		return
+	}
+
+	err := controller.EmailVerificationUsecase.Resend(c, request.Email)
+	if err != nil {
+		respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "if an unverified user is registered with this email, a verification link has been sent to it"})
+}
@@ -7,12 +7,14 @@ import (
 	"Task_8-Testing_Task_Management_REST_API/mocks"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -20,32 +22,50 @@ import (
 
 type UserControllerTestSuite struct {
 	suite.Suite
-	mockUserUsecase *mocks.UserUsecase
-	controller      *UserController
-	router          *gin.Engine
+	mockUserUsecase         *mocks.UserUsecase
+	mockVerificationUsecase *mocks.EmailVerificationUsecase
+	mockAttemptUsecase      *mocks.LoginAttemptUsecase
+	mockTwoFactorUsecase    *mocks.TwoFactorUsecase
+	controller              *UserController
+	router                  *gin.Engine
 }
 
 func (suite *UserControllerTestSuite) SetupSuite() {
-	err := godotenv.Load("../../.env.test")
-	if err != nil {
-		suite.Fail("Failed to load .env.test file", err)
-	}
-
 	suite.mockUserUsecase = new(mocks.UserUsecase)
+	suite.mockVerificationUsecase = new(mocks.EmailVerificationUsecase)
+	suite.mockAttemptUsecase = new(mocks.LoginAttemptUsecase)
+	suite.mockTwoFactorUsecase = new(mocks.TwoFactorUsecase)
 	suite.controller = &UserController{
-		UserUsecase: suite.mockUserUsecase,
-		Env:         bootstrap.NewEnv(),
+		UserUsecase:              suite.mockUserUsecase,
+		EmailVerificationUsecase: suite.mockVerificationUsecase,
+		LoginAttemptUsecase:      suite.mockAttemptUsecase,
+		TwoFactorUsecase:         suite.mockTwoFactorUsecase,
+		PasswordPolicy:           domain.PasswordPolicy{MinLength: 8, MaxLength: 128, Breached: map[string]bool{"password": true}},
+		Env:                      bootstrap.NewEnv("--config-dir=../..", "--app-env=test"),
 	}
+	suite.controller.PasswordHasher = bootstrap.NewPasswordHasher(suite.controller.Env)
 	suite.router = gin.Default()
 
 	// define the routes
 	suite.router.POST("/register", suite.controller.HandelUserRegister)
 	suite.router.POST("/login", suite.controller.HandelUserLogin)
+	suite.router.POST("/login/2fa", suite.controller.HandleTwoFactorLogin)
 	suite.router.PUT("/promote/:id", suite.controller.HandleUserPromotion)
+	suite.router.GET("/users", suite.controller.GetUsers)
+	suite.router.POST("/demote/:id", suite.controller.HandleUserDemotion)
+	suite.router.POST("/users/:id/disable", suite.controller.DisableUser)
+	suite.router.DELETE("/users/:id", suite.controller.DeleteUser)
+	suite.router.POST("/users/:id/unlock", suite.controller.UnlockUser)
+	suite.router.GET("/verify-email", suite.controller.VerifyEmail)
+	suite.router.POST("/verify-email/resend", suite.controller.ResendVerification)
 }
 
 func (suite *UserControllerTestSuite) TearDownTest() {
 	suite.mockUserUsecase.AssertExpectations(suite.T())
+	suite.mockVerificationUsecase.AssertExpectations(suite.T())
+	suite.mockAttemptUsecase.AssertExpectations(suite.T())
+	suite.mockTwoFactorUsecase.AssertExpectations(suite.T())
+	suite.controller.Env.RequireEmailVerified = false
 }
 
 func (suite *UserControllerTestSuite) TestHandelUserRegister_Success() {
@@ -59,6 +79,7 @@ func (suite *UserControllerTestSuite) TestHandelUserRegister_Success() {
 	suite.mockUserUsecase.On("AreThereAnyUsers", mock.Anything).Return(false, nil).Once()
 	suite.mockUserUsecase.On("GetByEmail", mock.Anything, mock.AnythingOfType("string")).Return(nil, nil).Once()
 	suite.mockUserUsecase.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+	suite.mockVerificationUsecase.On("SendVerification", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
 
 	jsonUser, _ := json.Marshal(requestUser)
 	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
@@ -69,6 +90,166 @@ func (suite *UserControllerTestSuite) TestHandelUserRegister_Success() {
 
 	suite.Equal(http.StatusOK, responseWriter.Code)
 	suite.Contains(responseWriter.Body.String(), "user registered successfully")
+	suite.Contains(responseWriter.Body.String(), "verification link has been sent")
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_NormalizesEmail() {
+	requestUser := &domain.User{
+		Email:    "  Test@Example.COM ",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     "USER",
+	}
+
+	suite.mockUserUsecase.On("AreThereAnyUsers", mock.Anything).Return(false, nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "test@example.com").Return(nil, nil).Once()
+	suite.mockUserUsecase.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Email == "test@example.com" && !user.EmailVerified
+	})).Return(nil).Once()
+	suite.mockVerificationUsecase.On("SendVerification", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("mailer is down")).Once()
+
+	jsonUser, _ := json.Marshal(requestUser)
+	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	// the account is created even though the link could not be sent
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "verification email could not be sent")
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_ConcurrentRegistration() {
+	requestUser := &domain.User{
+		Email:    "race@example.com",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     "USER",
+	}
+
+	// another registration for the same email was inserted between the check and the insertion
+	suite.mockUserUsecase.On("AreThereAnyUsers", mock.Anything).Return(true, nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "race@example.com").Return(nil, nil).Once()
+	suite.mockUserUsecase.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Email == "race@example.com"
+	})).Return(domain.ErrUserExists).Once()
+
+	jsonUser, _ := json.Marshal(requestUser)
+	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusConflict, responseWriter.Code)
+	suite.JSONEq(`{"error": "user already exists"}`, responseWriter.Body.String())
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_InvalidEmail() {
+	requestUser := &domain.User{
+		Email:    "Test User <test@example.com>",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     "USER",
+	}
+
+	jsonUser, _ := json.Marshal(requestUser)
+	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "is not a valid email address")
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_PasswordPolicy() {
+	passwords := map[string]string{
+		"short":    "at least 8 characters long",
+		"password": "appeared in a data breach",
+	}
+
+	for password, message := range passwords {
+		requestUser := &domain.User{
+			Email:    "test@example.com",
+			Password: password,
+			Name:     "Test User",
+			Role:     "USER",
+		}
+
+		jsonUser, _ := json.Marshal(requestUser)
+		request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
+		request.Header.Set("Content-Type", "application/json")
+
+		responseWriter := httptest.NewRecorder()
+		suite.router.ServeHTTP(responseWriter, request)
+
+		suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+		suite.Contains(responseWriter.Body.String(), message)
+	}
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_ListsEveryInvalidField() {
+	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBufferString(`{"email": "not an email", "password": "short", "role": "SUPERUSER"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	var response struct {
+		Fields []domain.FieldError `json:"fields"`
+	}
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.NoError(json.Unmarshal(responseWriter.Body.Bytes(), &response))
+	// the missing name is caught when binding, and reported along with the fields checked afterwards
+	suite.Equal([]domain.FieldError{
+		{Field: "name", Code: "required", Message: "name is required"},
+		{Field: "email", Code: "email", Message: "'not an email' is not a valid email address"},
+		{Field: "password", Code: "min", Message: "password must be at least 8 characters long"},
+		{Field: "role", Code: "role", Message: "invalid user role 'SUPERUSER'"},
+	}, response.Fields)
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_UnknownRole() {
+	requestUser := &domain.User{
+		Email:    "test@example.com",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     "SUPERUSER",
+	}
+
+	jsonUser, _ := json.Marshal(requestUser)
+	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "invalid user role 'SUPERUSER'")
+}
+
+func (suite *UserControllerTestSuite) TestHandelUserRegister_AdminAfterFirstUser() {
+	requestUser := &domain.User{
+		Email:    "test@example.com",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     "ADMIN",
+	}
+
+	suite.mockUserUsecase.On("AreThereAnyUsers", mock.Anything).Return(true, nil).Once()
+
+	jsonUser, _ := json.Marshal(requestUser)
+	request, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonUser))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnprocessableEntity, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "'ADMIN' can only be registered if no users exist")
 }
 
 func (suite *UserControllerTestSuite) TestHandelUserRegister_UserAlreadyExists() {
@@ -89,7 +270,7 @@ func (suite *UserControllerTestSuite) TestHandelUserRegister_UserAlreadyExists()
 	responseWriter := httptest.NewRecorder()
 	suite.router.ServeHTTP(responseWriter, request)
 
-	suite.Equal(http.StatusNotAcceptable, responseWriter.Code)
+	suite.Equal(http.StatusConflict, responseWriter.Code)
 	suite.Contains(responseWriter.Body.String(), "user already exists")
 }
 
@@ -101,10 +282,11 @@ func (suite *UserControllerTestSuite) TestHandleUserLogin_Success() {
 		Role:     "USER",
 	}
 
-	hashedPassword, _ := infrastructure.HashPassword(mockUser.Password)
-	mockUser.Password = string(hashedPassword)
+	mockUser.Password, _ = suite.controller.PasswordHasher.Hash(mockUser.Password)
 
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
 	suite.mockUserUsecase.On("GetByEmail", mock.Anything, mock.AnythingOfType("string")).Return(mockUser, nil).Once()
+	suite.mockAttemptUsecase.On("RecordSuccess", mock.Anything, "test@example.com", mock.Anything).Return(nil).Once()
 	suite.mockUserUsecase.On("CreateAccessToken", mock.AnythingOfType("*domain.User"), mock.Anything, mock.Anything).Return("mocked_jwt_token", nil).Once()
 
 	requestUser := &domain.User{
@@ -124,8 +306,64 @@ func (suite *UserControllerTestSuite) TestHandleUserLogin_Success() {
 	suite.Contains(responseWriter.Body.String(), "mocked_jwt_token")
 }
 
-func (suite *UserControllerTestSuite) TestHandleUserLogin_UserNonExistent() {
+func (suite *UserControllerTestSuite) TestHandleUserLogin_RehashesLegacyHash() {
+	mockUser := &domain.User{
+		Email: "test@example.com",
+		Name:  "Test User",
+		Role:  "USER",
+	}
+	mockUser.Password, _ = infrastructure.NewBcryptHasher(4).Hash("password123")
+	legacyHash := mockUser.Password
+
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "test@example.com").Return(mockUser, nil).Once()
+	suite.mockUserUsecase.On("ReplacePasswordHash", mock.Anything, mockUser.UserID.Hex(), legacyHash, mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$")
+	})).Return(true, nil).Once()
+	suite.mockAttemptUsecase.On("RecordSuccess", mock.Anything, "test@example.com", mock.Anything).Return(nil).Once()
+	suite.mockUserUsecase.On("CreateAccessToken", mockUser, mock.Anything, mock.Anything).Return("mocked_jwt_token", nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "test@example.com", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	// the bcrypt hash is replaced by a hash of the current algorithm
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.True(strings.HasPrefix(mockUser.Password, "$argon2id$"))
+	suite.False(suite.controller.PasswordHasher.NeedsRehash(mockUser.Password))
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserLogin_RehashAfterPasswordChange() {
+	mockUser := &domain.User{
+		Email: "test@example.com",
+		Name:  "Test User",
+		Role:  "USER",
+	}
+	mockUser.Password, _ = infrastructure.NewBcryptHasher(4).Hash("password123")
+	legacyHash := mockUser.Password
+
+	// the password was reset between reading the user and rehashing it
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "test@example.com").Return(mockUser, nil).Once()
+	suite.mockUserUsecase.On("ReplacePasswordHash", mock.Anything, mockUser.UserID.Hex(), legacyHash, mock.Anything).Return(false, nil).Once()
+	suite.mockAttemptUsecase.On("RecordSuccess", mock.Anything, "test@example.com", mock.Anything).Return(nil).Once()
+	suite.mockUserUsecase.On("CreateAccessToken", mockUser, mock.Anything, mock.Anything).Return("mocked_jwt_token", nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "test@example.com", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Equal(legacyHash, mockUser.Password)
+	suite.mockUserUsecase.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
 
+func (suite *UserControllerTestSuite) TestHandleUserLogin_UserNonExistent() {
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
 	suite.mockUserUsecase.On("GetByEmail", mock.Anything, mock.AnythingOfType("string")).Return(nil, nil).Once()
 
 	requestUser := &domain.User{
@@ -140,8 +378,9 @@ func (suite *UserControllerTestSuite) TestHandleUserLogin_UserNonExistent() {
 	responseWriter := httptest.NewRecorder()
 	suite.router.ServeHTTP(responseWriter, request)
 
-	suite.Equal(http.StatusNotFound, responseWriter.Code)
-	suite.Contains(responseWriter.Body.String(), "user doesn't exist")
+	// the response doesn't reveal that no user has this email
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrBadCredentials.Error())
 }
 
 func (suite *UserControllerTestSuite) TestHandleUserLogin_WrongPassword() {
@@ -152,9 +391,9 @@ func (suite *UserControllerTestSuite) TestHandleUserLogin_WrongPassword() {
 		Role:     "USER",
 	}
 
-	hashedPassword, _ := infrastructure.HashPassword(mockUser.Password)
-	mockUser.Password = string(hashedPassword)
+	mockUser.Password, _ = suite.controller.PasswordHasher.Hash(mockUser.Password)
 
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
 	suite.mockUserUsecase.On("GetByEmail", mock.Anything, mock.AnythingOfType("string")).Return(mockUser, nil).Once()
 
 	requestUser := &domain.User{
@@ -170,7 +409,157 @@ func (suite *UserControllerTestSuite) TestHandleUserLogin_WrongPassword() {
 	suite.router.ServeHTTP(responseWriter, request)
 
 	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
-	suite.Contains(responseWriter.Body.String(), "Incorrect password")
+	suite.Contains(responseWriter.Body.String(), domain.ErrBadCredentials.Error())
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserLogin_Disabled() {
+	mockUser := &domain.User{
+		Email:    "test@example.com",
+		Name:     "Test User",
+		Role:     "USER",
+		Disabled: true,
+	}
+
+	mockUser.Password, _ = suite.controller.PasswordHasher.Hash("password123")
+
+	// the attempt stays counted as a failure, and no token or challenge is issued
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "test@example.com").Return(mockUser, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "test@example.com", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrBadCredentials.Error())
+	suite.NotContains(responseWriter.Body.String(), "token")
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserLogin_LockedOut() {
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Millisecond*1500, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "test@example.com", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	// the password is not even checked while locked out
+	suite.Equal(http.StatusTooManyRequests, responseWriter.Code)
+	suite.Equal("2", responseWriter.Header().Get("Retry-After"))
+	suite.Contains(responseWriter.Body.String(), domain.ErrTooManyAttempts.Error())
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserLogin_InvalidEmail() {
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "not an email", mock.Anything).Return(time.Duration(0), nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "not an email", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrBadCredentials.Error())
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserLogin_TwoFactorChallenge() {
+	mockUser := &domain.User{
+		Email:            "test@example.com",
+		Name:             "Test User",
+		Role:             "USER",
+		TwoFactorEnabled: true,
+	}
+
+	mockUser.Password, _ = suite.controller.PasswordHasher.Hash("password123")
+
+	// the failed logins are not forgotten before the second factor is checked, only this attempt is
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "test@example.com").Return(mockUser, nil).Once()
+	suite.mockAttemptUsecase.On("Forgive", mock.Anything, "test@example.com", mock.Anything).Return(nil).Once()
+	suite.mockTwoFactorUsecase.On("CreateChallenge", mockUser).Return("challenge").Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "test@example.com", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), `"challenge_token":"challenge"`)
+	suite.NotContains(responseWriter.Body.String(), `"token"`)
+}
+
+func (suite *UserControllerTestSuite) TestHandleTwoFactorLogin_Success() {
+	mockUser := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", TwoFactorEnabled: true}
+
+	suite.mockTwoFactorUsecase.On("GetChallengeUser", mock.Anything, "challenge").Return(mockUser, nil).Once()
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockTwoFactorUsecase.On("VerifyCode", mock.Anything, mockUser, "123456").Return(nil).Once()
+	suite.mockAttemptUsecase.On("RecordSuccess", mock.Anything, "test@example.com", mock.Anything).Return(nil).Once()
+	suite.mockUserUsecase.On("CreateAccessToken", mockUser, mock.Anything, mock.Anything).Return("mocked_jwt_token", nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login/2fa", bytes.NewBufferString(`{"challenge_token": "challenge", "code": "123456"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "mocked_jwt_token")
+}
+
+func (suite *UserControllerTestSuite) TestHandleTwoFactorLogin_WrongCode() {
+	mockUser := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", TwoFactorEnabled: true}
+
+	suite.mockTwoFactorUsecase.On("GetChallengeUser", mock.Anything, "challenge").Return(mockUser, nil).Once()
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockTwoFactorUsecase.On("VerifyCode", mock.Anything, mockUser, "000000").Return(domain.ErrInvalidTwoFactorCode).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login/2fa", bytes.NewBufferString(`{"challenge_token": "challenge", "code": "000000"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrInvalidTwoFactorCode.Error())
+}
+
+func (suite *UserControllerTestSuite) TestHandleTwoFactorLogin_ExpiredChallenge() {
+	suite.mockTwoFactorUsecase.On("GetChallengeUser", mock.Anything, "expired").Return(nil, domain.ErrInvalidToken).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login/2fa", bytes.NewBufferString(`{"challenge_token": "expired", "code": "123456"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+}
+
+func (suite *UserControllerTestSuite) TestUnlockUser_Success() {
+	userID := primitive.NewObjectID().Hex()
+	suite.mockAttemptUsecase.On("Unlock", mock.Anything, userID).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/users/"+userID+"/unlock", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "user unlocked")
+}
+
+func (suite *UserControllerTestSuite) TestUnlockUser_UserNotFound() {
+	suite.mockAttemptUsecase.On("Unlock", mock.Anything, "missing").Return(domain.ErrUserNotFound).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/users/missing/unlock", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusNotFound, responseWriter.Code)
 }
 
 func (suite *UserControllerTestSuite) TestHandleUserPromotion_Success() {
@@ -181,7 +570,7 @@ func (suite *UserControllerTestSuite) TestHandleUserPromotion_Success() {
 	}
 
 	suite.mockUserUsecase.On("GetByID", mock.Anything, mockUser.UserID.Hex()).Return(mockUser, nil).Once()
-	suite.mockUserUsecase.On("UpdateUser", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+	suite.mockUserUsecase.On("Promote", mock.Anything, mockUser.UserID.Hex()).Return(nil).Once()
 
 	request, _ := http.NewRequest(http.MethodPut, "/promote/"+mockUser.UserID.Hex(), nil)
 	responseWriter := httptest.NewRecorder()
@@ -220,6 +609,132 @@ func (suite *UserControllerTestSuite) TestHandleUserPromotion_UserAlreadyAdmin()
 	suite.Contains(responseWriter.Body.String(), "user is already an admin")
 }
 
+func (suite *UserControllerTestSuite) TestGetUsers_Success() {
+	mockPage := &domain.UserPage{
+		Users:      []domain.UserProfile{{ID: primitive.NewObjectID(), Name: "Test User", Role: "USER"}},
+		Pagination: domain.NewPagination(2, 5),
+		Total:      6,
+	}
+
+	suite.mockUserUsecase.On("GetUsers", mock.Anything, domain.UserFilter{Query: "test", Role: "USER"}, domain.NewPagination(2, 5)).Return(mockPage, nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/users?q=test&role=USER&page=2&limit=5", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "Test User")
+	suite.NotContains(responseWriter.Body.String(), "password")
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserDemotion_LastAdmin() {
+	userID := primitive.NewObjectID().Hex()
+
+	suite.mockUserUsecase.On("Demote", mock.Anything, userID).Return(domain.ErrLastAdmin).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/demote/"+userID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusConflict, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrLastAdmin.Error())
+}
+
+func (suite *UserControllerTestSuite) TestDisableUser_Success() {
+	userID := primitive.NewObjectID().Hex()
+
+	suite.mockUserUsecase.On("SetDisabled", mock.Anything, userID, true).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/users/"+userID+"/disable", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+}
+
+func (suite *UserControllerTestSuite) TestDeleteUser_DefaultsToOrphan() {
+	userID := primitive.NewObjectID().Hex()
+
+	suite.mockUserUsecase.On("Delete", mock.Anything, userID, domain.TaskPolicyOrphan, "").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/users/"+userID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+}
+
+func (suite *UserControllerTestSuite) TestDeleteUser_Reassign() {
+	userID := primitive.NewObjectID().Hex()
+	replacementID := primitive.NewObjectID().Hex()
+
+	suite.mockUserUsecase.On("Delete", mock.Anything, userID, domain.TaskPolicyReassign, replacementID).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodDelete, "/users/"+userID+"?tasks=reassign&to="+replacementID, nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+}
+
+func (suite *UserControllerTestSuite) TestHandleUserLogin_UnverifiedEmail() {
+	mockUser := &domain.User{
+		Email:    "test@example.com",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     "USER",
+	}
+
+	mockUser.Password, _ = suite.controller.PasswordHasher.Hash(mockUser.Password)
+	suite.controller.Env.RequireEmailVerified = true
+
+	suite.mockAttemptUsecase.On("Attempt", mock.Anything, "test@example.com", mock.Anything).Return(time.Duration(0), nil).Once()
+	suite.mockUserUsecase.On("GetByEmail", mock.Anything, "test@example.com").Return(mockUser, nil).Once()
+	suite.mockAttemptUsecase.On("RecordSuccess", mock.Anything, "test@example.com", mock.Anything).Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"email": "TEST@example.com", "password": "password123"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusForbidden, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrEmailUnverified.Error())
+}
+
+func (suite *UserControllerTestSuite) TestVerifyEmail_Success() {
+	suite.mockVerificationUsecase.On("Verify", mock.Anything, "signed.token").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/verify-email?token=signed.token", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "email verified successfully")
+}
+
+func (suite *UserControllerTestSuite) TestVerifyEmail_InvalidToken() {
+	suite.mockVerificationUsecase.On("Verify", mock.Anything, "forged").Return(domain.ErrInvalidToken).Once()
+
+	request, _ := http.NewRequest(http.MethodGet, "/verify-email?token=forged", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusBadRequest, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), domain.ErrInvalidToken.Error())
+}
+
+func (suite *UserControllerTestSuite) TestResendVerification_Success() {
+	suite.mockVerificationUsecase.On("Resend", mock.Anything, "test@example.com").Return(nil).Once()
+
+	request, _ := http.NewRequest(http.MethodPost, "/verify-email/resend", bytes.NewBufferString(`{"email": "test@example.com"}`))
+	request.Header.Set("Content-Type", "application/json")
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+}
+
 func TestUserControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(UserControllerTestSuite))
 }
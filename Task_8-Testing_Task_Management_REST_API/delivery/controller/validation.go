@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The request bodies are validated with the 'binding' struct tags of their DTOs when they are bound.
+// The validator is set up once, so that invalid fields are reported by their JSON names
+// and the tags specific to this API can be used.
+func init() {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+
+		return name
+	})
+
+	validate.RegisterValidation("objectid", func(fl validator.FieldLevel) bool {
+		return primitive.IsValidObjectID(fl.Field().String())
+	})
+	validate.RegisterValidation("task_status", func(fl validator.FieldLevel) bool {
+		return domain.IsTaskStatus(fl.Field().String())
+	})
+}
+
+// bindJSON binds the JSON body of the request to the request DTO and validates it.
+// A malformed body is answered with a 400 Bad Request response and invalid fields with
+// a 422 Unprocessable Entity response listing all of them. It reports whether the handler can go on.
+func bindJSON(c *gin.Context, request any) bool {
+	validation, ok := bindJSONFields(c, request)
+	if !ok {
+		return false
+	}
+
+	if err := validation.Err(); err != nil {
+		respondWithError(c, err)
+		return false
+	}
+
+	return true
+}
+
+// bindJSONFields is bindJSON for handlers that check more fields themselves: the invalid fields
+// found while binding are returned rather than answered, so that they are reported together with the others.
+// Only a malformed body is answered, with a 400 Bad Request response, in which case it reports false.
+func bindJSONFields(c *gin.Context, request any) (*domain.ValidationError, bool) {
+	err := c.ShouldBindJSON(request)
+	if err == nil {
+		return &domain.ValidationError{}, true
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		return newValidationError(fieldErrors), true
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+	return nil, false
+}
+
+// newValidationError converts the errors of the validator to a domain.ValidationError.
+func newValidationError(fieldErrors validator.ValidationErrors) *domain.ValidationError {
+	var validation domain.ValidationError
+	for _, fieldError := range fieldErrors {
+		// the namespace starts with the name of the DTO, which means nothing to clients
+		field := fieldError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+
+		validation.Add(field, fieldError.Tag(), fieldErrorMessage(field, fieldError))
+	}
+
+	return &validation
+}
+
+// fieldErrorMessage describes a failed validation tag in words.
+func fieldErrorMessage(field string, fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return fmt.Sprintf("%v is required", field)
+	case "email":
+		return fmt.Sprintf("%v must be a valid email address", field)
+	case "objectid":
+		return fmt.Sprintf("%v must be a valid id", field)
+	case "task_status":
+		return fmt.Sprintf("%v must be one of %v", field, strings.Join(domain.TaskStatuses, ", "))
+	case "oneof":
+		return fmt.Sprintf("%v must be one of %v", field, strings.Join(strings.Fields(fieldError.Param()), ", "))
+	case "min", "max":
+		limit := "at least"
+		if fieldError.Tag() == "max" {
+			limit = "at most"
+		}
+
+		switch fieldError.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("%v must be %v %v characters long", field, limit, fieldError.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("%v must have %v %v items", field, limit, fieldError.Param())
+		default:
+			return fmt.Sprintf("%v must be %v %v", field, limit, fieldError.Param())
+		}
+	default:
+		return fmt.Sprintf("%v is invalid", field)
+	}
+}
@@ -2,7 +2,11 @@ package main
 
 import (
 	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/delivery/command"
 	"Task_8-Testing_Task_Management_REST_API/delivery/route"
+	"context"
+	"log/slog"
+	"os"
 
 	"time"
 
@@ -10,16 +14,58 @@ import (
 )
 
 func main() {
-	app := bootstrap.App()
+	// 'config print' shows the configuration the server would run with, with its secrets masked
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "print" {
+		if err := command.PrintConfig(os.Stdout, os.Args[3:]); err != nil {
+			slog.Error("invalid configuration", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// 'migrate' applies the migrations not applied yet, and 'migrate status' lists them
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(os.Args[2:])
+		return
+	}
+
+	app := bootstrap.App(os.Args[1:]...)
 	env := app.Env
 
 	database := app.Mongo.Database(env.DBName)
-	defer app.CloseMongoDBConnection()
 
 	timeout := time.Duration(env.ContextTimeout) * time.Second
 
-	gin := gin.Default()
+	// the routes are only listed in development, and the requests are logged by route.Setup
+	if env.AppEnv != "development" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	gin := gin.New()
+
+	route.Setup(env, timeout, *database, app.Health, gin)
+
+	app.Serve(bootstrap.NewHTTPServer(env, gin))
+	if err := app.Run(); err != nil {
+		app.Logger.Error("The server stopped with an error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func migrate(args []string) {
+	var commandArgs []string
+	if len(args) > 0 && args[0] == "status" {
+		commandArgs, args = args[:1], args[1:]
+	}
+
+	// the migrations are applied below, if at all, rather than when the application starts
+	app := bootstrap.App(append(args, "--migrate-on-startup=false")...)
+	commands := &command.Commands{Migrator: app.Migrator, Out: os.Stdout}
 
-	route.Setup(env, timeout, *database, gin)
-	gin.Run(env.ServerAddress)
+	err := app.RunCommand(func(c context.Context) error {
+		return commands.Migrate(c, commandArgs)
+	})
+	if err != nil {
+		app.Logger.Error("The migrations failed", "error", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/delivery/controller"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewAccessTokenRouter registers the routes users manage their personal access tokens with.
+// The group must refuse requests authenticated with a personal access token.
+func NewAccessTokenRouter(env *bootstrap.Env, tokenUsecase domain.PersonalAccessTokenUsecase, group *gin.RouterGroup) {
+	accessTokenController := &controller.AccessTokenController{
+		PersonalAccessTokenUsecase: tokenUsecase,
+		Env:                        env,
+	}
+
+	group.GET("/me/tokens", accessTokenController.GetMyTokens)
+	group.POST("/me/tokens", accessTokenController.CreateToken)
+	group.DELETE("/me/tokens/:id", accessTokenController.RevokeToken)
+}
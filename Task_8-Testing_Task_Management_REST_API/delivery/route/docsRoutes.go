@@ -0,0 +1,51 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/delivery/controller"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewDocsRouter serves the OpenAPI document of the routes registered on the engine at '/openapi.json',
+// and a Swagger UI at '/docs'. It has to be called after every other route is registered.
+// The unversioned aliases of the routes are documented as deprecated.
+func NewDocsRouter(env *bootstrap.Env, engine *gin.Engine) {
+	docsController := &controller.DocsController{Env: env}
+
+	engine.GET("/openapi.json", docsController.GetOpenAPIDocument)
+	engine.GET("/docs", docsController.GetSwaggerUI)
+
+	operations := map[string]infrastructure.OpenAPIOperation{
+		"GET /openapi.json": {Summary: "Get the OpenAPI document of the API", Tag: "docs", Public: true},
+		"GET /docs":         {Summary: "Browse the API with Swagger UI", Tag: "docs", Public: true},
+		"GET /healthz": {
+			Summary: "Check that the server is up", Tag: "health", Public: true, Response: domain.HealthReport{},
+		},
+		"GET /readyz": {
+			Summary: "Check that the server and its dependencies are ready to serve requests, answering 503 otherwise",
+			Tag:     "health", Public: true, Response: domain.HealthReport{},
+		},
+		"GET /metrics": {Summary: "Get the metrics of the server in the Prometheus text format", Tag: "health", Public: true},
+	}
+	for key, operation := range controller.Operations() {
+		method, path, _ := strings.Cut(key, " ")
+		operations[method+" "+latestVersionPrefix+path] = operation
+
+		// the unversioned aliases of the first version
+		operation.Deprecated = true
+		operations[key] = operation
+	}
+
+	document := infrastructure.NewOpenAPIDocument("Task Management API", "1.0.0", engine.Routes(), operations)
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		// the document is only made of maps, slices, strings and numbers
+		panic(err)
+	}
+	docsController.Document = encoded
+}
@@ -0,0 +1,17 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/delivery/controller"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewHealthRouter serves the liveness probe at '/healthz' and the readiness probe at '/readyz'.
+// They are not versioned, and neither authenticated nor rate limited, so that the orchestrator can always reach them.
+func NewHealthRouter(health domain.HealthUsecase, engine *gin.Engine) {
+	healthController := &controller.HealthController{HealthUsecase: health}
+
+	engine.GET("/healthz", healthController.GetLiveness)
+	engine.GET("/readyz", healthController.GetReadiness)
+}
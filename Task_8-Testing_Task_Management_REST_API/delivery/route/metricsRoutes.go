@@ -0,0 +1,13 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewMetricsRouter serves the Prometheus metrics at '/metrics'. Like the health probes, the route is
+// neither versioned nor authenticated, so it should only be reachable from the monitoring network.
+func NewMetricsRouter(metrics *infrastructure.Metrics, engine *gin.Engine) {
+	engine.GET("/metrics", gin.WrapH(metrics.Handler()))
+}
@@ -0,0 +1,78 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/delivery/controller"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/repository"
+	"Task_8-Testing_Task_Management_REST_API/usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func NewProjectRouter(env *bootstrap.Env, timeout time.Duration, database mongo.Database, metrics domain.RepositoryMetrics, blobStorage domain.BlobStorage, roles domain.Roles, group *gin.RouterGroup) {
+	userRepo := newUserRepo(database, metrics)
+	taskRepo := newTaskRepo(database, metrics)
+	commentRepo := repository.NewCommentRepo(database, domain.CollectionComment)
+	projectRepo := repository.NewProjectRepo(database, domain.CollectionProject)
+
+	projectUsecase := usecases.NewProjectUsecase(projectRepo, taskRepo, userRepo, timeout)
+
+	projectController := &controller.ProjectController{
+		ProjectUsecase: projectUsecase,
+		Env:            env,
+	}
+
+	projectTaskController := &controller.TaskController{
+		TaskUsecase: usecases.NewTaskUsecase(taskRepo, commentRepo, userRepo, projectRepo, blobStorage, roles, timeout),
+		Env:         env,
+	}
+
+	projectCommentController := &controller.CommentController{
+		CommentUsecase: usecases.NewCommentUsecase(commentRepo, taskRepo, roles, timeout),
+		Env:            env,
+	}
+
+	projectAttachmentController := &controller.AttachmentController{
+		AttachmentUsecase: usecases.NewAttachmentUsecase(taskRepo, blobStorage, env.AttachmentMaxSize, env.AllowedAttachmentTypes(), roles, timeout),
+		Env:               env,
+	}
+
+	// within a project, the role of the user in the project decides what they are allowed to do
+	viewer := infrastructure.RequireProjectRole(projectUsecase, roles, domain.ProjectRoleViewer)
+	member := infrastructure.RequireProjectRole(projectUsecase, roles, domain.ProjectRoleMember)
+	owner := infrastructure.RequireProjectRole(projectUsecase, roles, domain.ProjectRoleOwner)
+	inProject := projectTaskController.RequireTaskInProject
+
+	// changes also need a permission, so that a personal access token can't make them without the scope
+	write := infrastructure.RequirePermission(roles, domain.PermissionProjectsWrite)
+	comment := infrastructure.RequirePermission(roles, domain.PermissionCommentsWrite)
+
+	group.POST("/projects", infrastructure.RequirePermission(roles, domain.PermissionProjectsCreate), projectController.CreateProject)
+
+	projectGroup := group.Group("", infrastructure.RequirePermission(roles, domain.PermissionProjectsRead))
+	projectGroup.GET("/projects", projectController.GetMyProjects)
+	projectGroup.GET("/projects/:pid", viewer, projectController.GetProject)
+	projectGroup.PUT("/projects/:pid", write, owner, projectController.UpdateProject)
+	projectGroup.DELETE("/projects/:pid", write, owner, projectController.DeleteProject)
+	projectGroup.PUT("/projects/:pid/members/:uid", write, owner, projectController.SetProjectMember)
+	projectGroup.DELETE("/projects/:pid/members/:uid", write, owner, projectController.RemoveProjectMember)
+
+	projectGroup.GET("/projects/:pid/tasks", viewer, projectTaskController.GetProjectTasks)
+	projectGroup.POST("/projects/:pid/tasks", write, member, projectTaskController.CreateProjectTask)
+	projectGroup.GET("/projects/:pid/tasks/:id", viewer, inProject, projectTaskController.GetTask)
+	projectGroup.PUT("/projects/:pid/tasks/:id", write, member, inProject, projectTaskController.UpdateTask)
+	projectGroup.DELETE("/projects/:pid/tasks/:id", write, member, inProject, projectTaskController.DeleteTask)
+	projectGroup.PUT("/projects/:pid/tasks/:id/assignees", write, owner, inProject, projectTaskController.AssignUsers)
+	projectGroup.PATCH("/projects/:pid/tasks/:id/status", write, viewer, inProject, projectTaskController.UpdateTaskStatus)
+
+	projectGroup.GET("/projects/:pid/tasks/:id/comments", viewer, inProject, projectCommentController.GetTaskComments)
+	projectGroup.POST("/projects/:pid/tasks/:id/comments", comment, member, inProject, projectCommentController.CreateComment)
+
+	projectGroup.POST("/projects/:pid/tasks/:id/attachments", write, member, inProject, projectAttachmentController.UploadAttachment)
+	projectGroup.GET("/projects/:pid/tasks/:id/attachments/:attachmentID", viewer, inProject, projectAttachmentController.DownloadAttachment)
+	projectGroup.DELETE("/projects/:pid/tasks/:id/attachments/:attachmentID", write, member, inProject, projectAttachmentController.DeleteAttachment)
+}
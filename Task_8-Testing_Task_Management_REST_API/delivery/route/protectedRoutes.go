@@ -4,6 +4,7 @@ import (
 	"Task_8-Testing_Task_Management_REST_API/bootstrap"
 	"Task_8-Testing_Task_Management_REST_API/delivery/controller"
 	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
 	"Task_8-Testing_Task_Management_REST_API/repository"
 	"Task_8-Testing_Task_Management_REST_API/usecases"
 	"time"
@@ -12,14 +13,64 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func NewProtectedRouter(env *bootstrap.Env, timeout time.Duration, database mongo.Database, group *gin.RouterGroup) {
-	taskRepo := repository.NewTaskRepo(database, domain.CollectionTask)
+func NewProtectedRouter(env *bootstrap.Env, timeout time.Duration, database mongo.Database, metrics domain.RepositoryMetrics, blobStorage domain.BlobStorage, roles domain.Roles, group *gin.RouterGroup) {
+	userRepo := newUserRepo(database, metrics)
+	taskRepo := newTaskRepo(database, metrics)
+	commentRepo := repository.NewCommentRepo(database, domain.CollectionComment)
+	projectRepo := repository.NewProjectRepo(database, domain.CollectionProject)
+
+	loginAttemptRepo := repository.NewLoginAttemptRepo(database, domain.CollectionLoginAttempt)
+
+	protectedRouteUserController := &controller.UserController{
+		UserUsecase:         newUserUsecase(database, metrics, timeout),
+		LoginAttemptUsecase: usecases.NewLoginAttemptUsecase(loginAttemptRepo, userRepo, env.LoginPolicy(), timeout),
+		Env:                 env,
+	}
 
 	protectedRouteTaskController := &controller.TaskController{
-		TaskUsecase: usecases.NewTaskUsecase(taskRepo, timeout),
+		TaskUsecase: usecases.NewTaskUsecase(taskRepo, commentRepo, userRepo, projectRepo, blobStorage, roles, timeout),
 		Env:         env,
 	}
 
-	group.GET("/tasks", protectedRouteTaskController.GetAllTasks)
-	group.GET("/tasks/:id", protectedRouteTaskController.GetTask)
+	protectedRouteCommentController := &controller.CommentController{
+		CommentUsecase: usecases.NewCommentUsecase(commentRepo, taskRepo, roles, timeout),
+		Env:            env,
+	}
+
+	protectedRouteAttachmentController := &controller.AttachmentController{
+		AttachmentUsecase: usecases.NewAttachmentUsecase(taskRepo, blobStorage, env.AttachmentMaxSize, env.AllowedAttachmentTypes(), roles, timeout),
+		Env:               env,
+	}
+
+	require := func(permissions ...string) gin.HandlerFunc {
+		return infrastructure.RequirePermission(roles, permissions...)
+	}
+
+	group.GET("/users", require(domain.PermissionUsersRead), protectedRouteUserController.GetUsers)
+	group.POST("/promote/:id", require(domain.PermissionUsersPromote), protectedRouteUserController.HandleUserPromotion)
+	group.POST("/demote/:id", require(domain.PermissionUsersDemote), protectedRouteUserController.HandleUserDemotion)
+	group.POST("/users/:id/disable", require(domain.PermissionUsersDisable), protectedRouteUserController.DisableUser)
+	group.POST("/users/:id/enable", require(domain.PermissionUsersDisable), protectedRouteUserController.EnableUser)
+	group.DELETE("/users/:id", require(domain.PermissionUsersDelete), protectedRouteUserController.DeleteUser)
+	group.POST("/users/:id/unlock", require(domain.PermissionUsersUnlock), protectedRouteUserController.UnlockUser)
+
+	group.GET("/tasks", require(domain.PermissionTasksRead), protectedRouteTaskController.GetAllTasks)
+	group.GET("/tasks/:id", require(domain.PermissionTasksRead), protectedRouteTaskController.GetTask)
+	group.POST("/tasks", require(domain.PermissionTasksCreate), protectedRouteTaskController.CreateTask)
+	group.PUT("/tasks/:id", require(domain.PermissionTasksUpdate), protectedRouteTaskController.UpdateTask)
+	group.DELETE("/tasks/:id", require(domain.PermissionTasksDelete), protectedRouteTaskController.DeleteTask)
+	group.PUT("/tasks/:id/assignees", require(domain.PermissionTasksAssign), protectedRouteTaskController.AssignUsers)
+	group.GET("/me/tasks", require(domain.PermissionTasksReadAssigned), protectedRouteTaskController.GetMyTasks)
+
+	// the tasks without a project are reached here, by their assignees and the users allowed to read any task
+	withoutProject := protectedRouteTaskController.RequireTaskWithoutProject
+	group.PATCH("/tasks/:id/status", withoutProject, protectedRouteTaskController.UpdateTaskStatus)
+	group.GET("/tasks/:id/comments", withoutProject, protectedRouteCommentController.GetTaskComments)
+	group.POST("/tasks/:id/comments", require(domain.PermissionCommentsWrite), withoutProject, protectedRouteCommentController.CreateComment)
+	group.POST("/tasks/:id/attachments", withoutProject, protectedRouteAttachmentController.UploadAttachment)
+	group.GET("/tasks/:id/attachments/:attachmentID", withoutProject, protectedRouteAttachmentController.DownloadAttachment)
+	group.DELETE("/tasks/:id/attachments/:attachmentID", withoutProject, protectedRouteAttachmentController.DeleteAttachment)
+
+	group.PUT("/comments/:id", require(domain.PermissionCommentsWrite), protectedRouteCommentController.EditComment)
+	group.DELETE("/comments/:id", require(domain.PermissionCommentsWrite), protectedRouteCommentController.DeleteComment)
 }
@@ -12,14 +12,47 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func NewPublicRouter(env *bootstrap.Env, timeout time.Duration, database mongo.Database, group *gin.RouterGroup) {
-	userRepo := repository.NewUserRepo(database, domain.CollectionUser)
+func NewPublicRouter(env *bootstrap.Env, timeout time.Duration, database mongo.Database, metrics domain.RepositoryMetrics, mailer domain.Mailer, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, group *gin.RouterGroup) {
+	userRepo := newUserRepo(database, metrics)
+	loginAttemptRepo := repository.NewLoginAttemptRepo(database, domain.CollectionLoginAttempt)
 
 	publicRouteUserController := &controller.UserController{
-		UserUsecase: usecases.NewUserUsecase(userRepo, timeout),
-		Env:         env,
+		UserUsecase: newUserUsecase(database, metrics, timeout),
+		EmailVerificationUsecase: usecases.NewEmailVerificationUsecase(
+			userRepo,
+			mailer,
+			env.AccessTokenSecret,
+			env.BaseURL()+latestVersionPrefix,
+			env.EmailVerificationTokenTTL(),
+			env.EmailVerificationResendInterval(),
+			timeout,
+		),
+		LoginAttemptUsecase: usecases.NewLoginAttemptUsecase(loginAttemptRepo, userRepo, env.LoginPolicy(), timeout),
+		TwoFactorUsecase:    newTwoFactorUsecase(env, timeout, userRepo),
+		PasswordHasher:      passwordHasher,
+		PasswordPolicy:      passwordPolicy,
+		Env:                 env,
 	}
 
 	group.POST("/register", publicRouteUserController.HandelUserRegister)
 	group.POST("/login", publicRouteUserController.HandelUserLogin)
+	group.POST("/login/2fa", publicRouteUserController.HandleTwoFactorLogin)
+	group.GET("/verify-email", publicRouteUserController.VerifyEmail)
+	group.POST("/verify-email/resend", publicRouteUserController.ResendVerification)
+
+	passwordController := &controller.PasswordController{
+		PasswordResetUsecase: usecases.NewPasswordResetUsecase(
+			userRepo,
+			repository.NewPasswordResetRepo(database, domain.CollectionPasswordReset),
+			mailer,
+			passwordHasher,
+			passwordPolicy,
+			env.PasswordResetTokenTTL(),
+			timeout,
+		),
+		Env: env,
+	}
+
+	group.POST("/password/forgot", passwordController.ForgotPassword)
+	group.POST("/password/reset", passwordController.ResetPassword)
 }
@@ -2,27 +2,117 @@ package route
 
 import (
 	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
 	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/repository"
+	"Task_8-Testing_Task_Management_REST_API/usecases"
 
+	"log/slog"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, gin *gin.Engine) {
-	publicRouter := gin.Group("")
-	protectedRouter := gin.Group("")
-	adminRouter := gin.Group("")
+// latestVersionPrefix is the prefix of the latest version of the API, which the deprecated aliases
+// and the links mailed to users point to.
+const latestVersionPrefix = "/v1"
 
-	protectedRouter.Use(infrastructure.JWTAuthMiddleware(env.AccessTokenSecret))
+// Setup mounts every version of the API under its own prefix, such as '/v1'. While LEGACY_ROUTES is set,
+// the routes of the first version are also served without the prefix, as deprecated aliases
+// until LEGACY_ROUTES_SUNSET. The health probes report the dependencies registered with health.
+func Setup(env *bootstrap.Env, timeout time.Duration, db mongo.Database, health domain.HealthUsecase, gin *gin.Engine) {
+	// the limits are shared by every version, so that the aliases don't double them
+	rateLimitStore := bootstrap.NewRateLimitStore(env, db)
 
-	adminRouter.Use(
-		infrastructure.JWTAuthMiddleware(env.AccessTokenSecret),
-		infrastructure.AuthenticateAdmin(),
+	// the requests are traced, logged and recorded before any other middleware, so that the rejected ones are too.
+	// The controllers pass the gin context to the usecases, which finds the span and the logger of the request
+	// in the context of the request only when the engine falls back to it.
+	gin.ContextWithFallback = true
+	gin.Use(infrastructure.TracingMiddleware(bootstrap.TracingServiceName))
+	gin.Use(infrastructure.RequestLoggerMiddleware(slog.Default()))
+	metrics := infrastructure.NewMetrics(repository.NewTaskRepo(db, domain.CollectionTask), timeout)
+	gin.Use(metrics.Middleware())
+	gin.Use(infrastructure.RecoveryMiddleware())
+
+	setupVersion(env, timeout, db, metrics, rateLimitStore, gin.Group(latestVersionPrefix, infrastructure.APIVersionMiddleware(1)))
+
+	if env.LegacyRoutes {
+		deprecated := infrastructure.DeprecationMiddleware(env.LegacyRoutesDeprecatedAt(), env.LegacyRoutesSunset(), latestVersionPrefix)
+		setupVersion(env, timeout, db, metrics, rateLimitStore, gin.Group("", infrastructure.APIVersionMiddleware(1), deprecated))
+	}
+
+	NewHealthRouter(health, gin)
+	NewMetricsRouter(metrics, gin)
+
+	// the document describes the routes registered above, so the docs have to come last
+	NewDocsRouter(env, gin)
+}
+
+// setupVersion registers the routes of one version of the API on the group. Controllers that answer
+// differently from one version to another find out which one was requested with infrastructure.GetAPIVersionFromContext.
+func setupVersion(env *bootstrap.Env, timeout time.Duration, db mongo.Database, metrics domain.RepositoryMetrics, rateLimitStore domain.RateLimitStore, group *gin.RouterGroup) {
+	publicRouter := group.Group("")
+	protectedRouter := group.Group("")
+
+	userRepo := newUserRepo(db, metrics)
+	userUsecase := newUserUsecase(db, metrics, timeout)
+	roles := env.Roles()
+	tokenUsecase := usecases.NewPersonalAccessTokenUsecase(
+		repository.NewPersonalAccessTokenRepo(db, domain.CollectionPersonalAccessToken),
+		userRepo,
+		roles,
+		timeout,
+	)
+
+	// every protected route declares the permissions it requires
+	protectedRouter.Use(infrastructure.JWTAuthMiddleware(env.AccessTokenSecret, userUsecase, tokenUsecase))
+
+	// protected routes are limited per user, so the limit has to come after authentication
+	if limit := env.PublicRateLimit(); limit.Enabled() {
+		publicRouter.Use(infrastructure.RateLimitMiddleware(rateLimitStore, limit, "public"))
+	}
+	if limit := env.ProtectedRateLimit(); limit.Enabled() {
+		protectedRouter.Use(infrastructure.RateLimitMiddleware(rateLimitStore, limit, "protected"))
+	}
+
+	// users whose role requires two-factor authentication can only reach the routes enrolling it until they do,
+	// and personal access tokens can't manage the account they belong to
+	twoFactorRouter := protectedRouter.Group("", infrastructure.DenyPersonalAccessTokens())
+	protectedRouter = protectedRouter.Group("", infrastructure.RequireTwoFactor(env.TwoFactorRequiredRoles()...))
+	accountRouter := protectedRouter.Group("", infrastructure.DenyPersonalAccessTokens())
+
+	blobStorage := bootstrap.NewBlobStorage(env)
+	mailer := bootstrap.NewMailer(env)
+	passwordHasher := bootstrap.NewPasswordHasher(env)
+	passwordPolicy := bootstrap.NewPasswordPolicy(env)
+
+	NewPublicRouter(env, timeout, db, metrics, mailer, passwordHasher, passwordPolicy, publicRouter)
+	NewProtectedRouter(env, timeout, db, metrics, blobStorage, roles, protectedRouter)
+	NewProjectRouter(env, timeout, db, metrics, blobStorage, roles, protectedRouter)
+	NewTwoFactorRouter(env, timeout, db, metrics, twoFactorRouter)
+	NewAccessTokenRouter(env, tokenUsecase, accountRouter)
+}
+
+// newUserRepo creates the user repository, recording its operations in the metrics.
+func newUserRepo(db mongo.Database, metrics domain.RepositoryMetrics) domain.UserRepository {
+	return repository.NewInstrumentedUserRepo(repository.NewUserRepo(db, domain.CollectionUser), domain.CollectionUser, metrics)
+}
+
+// newUserUsecase creates the user usecase, along with the repositories of what deleting a user removes.
+func newUserUsecase(db mongo.Database, metrics domain.RepositoryMetrics, timeout time.Duration) domain.UserUsecase {
+	return usecases.NewUserUsecase(
+		newUserRepo(db, metrics),
+		newTaskRepo(db, metrics),
+		repository.NewProjectRepo(db, domain.CollectionProject),
+		repository.NewCommentRepo(db, domain.CollectionComment),
+		repository.NewPersonalAccessTokenRepo(db, domain.CollectionPersonalAccessToken),
+		repository.NewLoginAttemptRepo(db, domain.CollectionLoginAttempt),
+		timeout,
 	)
+}
 
-	NewPublicRouter(env, timeout, db, publicRouter)
-	NewProtectedRouter(env, timeout, db, protectedRouter)
-	NewAdminRouter(env, timeout, db, adminRouter)
+// newTaskRepo creates the task repository, recording its operations in the metrics.
+func newTaskRepo(db mongo.Database, metrics domain.RepositoryMetrics) domain.TaskRepository {
+	return repository.NewInstrumentedTaskRepo(repository.NewTaskRepo(db, domain.CollectionTask), domain.CollectionTask, metrics)
 }
@@ -0,0 +1,181 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/usecases"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RouteTestSuite struct {
+	suite.Suite
+	env    *bootstrap.Env
+	router *gin.Engine
+	health domain.HealthUsecase
+}
+
+func (suite *RouteTestSuite) SetupSuite() {
+	env := bootstrap.NewEnv("--config-dir=../..", "--app-env=test")
+	env.AttachmentDir = suite.T().TempDir()
+	suite.env = env
+
+	// no request reaches the database, so it is never connected
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.health = usecases.NewHealthUsecase(time.Second)
+	Setup(env, time.Second, mongo.Database{}, suite.health, suite.router)
+}
+
+func (suite *RouteTestSuite) getOpenAPIDocument() map[string]any {
+	request, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Require().Equal(http.StatusOK, responseWriter.Code)
+
+	var document map[string]any
+	suite.Require().NoError(json.Unmarshal(responseWriter.Body.Bytes(), &document))
+
+	return document
+}
+
+func (suite *RouteTestSuite) TestOpenAPIDocument_CoversEveryRoute() {
+	paths := suite.getOpenAPIDocument()["paths"].(map[string]any)
+
+	for _, route := range suite.router.Routes() {
+		path, ok := paths[infrastructure.OpenAPIPath(route.Path)].(map[string]any)
+		suite.True(ok && path[strings.ToLower(route.Method)] != nil,
+			"%v %v is missing from the OpenAPI document, document it in controller.Operations", route.Method, route.Path)
+	}
+}
+
+func (suite *RouteTestSuite) TestOpenAPIDocument_DescribesRequestBodies() {
+	document := suite.getOpenAPIDocument()
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+
+	taskRequest := schemas["TaskRequest"].(map[string]any)
+	suite.Equal([]any{"title"}, taskRequest["required"])
+
+	status := taskRequest["properties"].(map[string]any)["status"].(map[string]any)
+	suite.Equal([]any{"pending", "in_progress", "done"}, status["enum"])
+}
+
+func (suite *RouteTestSuite) TestOpenAPIDocument_DeprecatesLegacyRoutes() {
+	paths := suite.getOpenAPIDocument()["paths"].(map[string]any)
+
+	versioned := paths["/v1/tasks/{id}"].(map[string]any)["get"].(map[string]any)
+	suite.NotContains(versioned, "deprecated")
+
+	legacy := paths["/tasks/{id}"].(map[string]any)["get"].(map[string]any)
+	suite.Equal(true, legacy["deprecated"])
+}
+
+func (suite *RouteTestSuite) TestVersionedRoutes() {
+	request, _ := http.NewRequest(http.MethodGet, "/v1/tasks", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	// the route exists, but requires a token
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+	suite.Empty(responseWriter.Header().Get("Deprecation"))
+}
+
+func (suite *RouteTestSuite) TestLegacyRoutes_Deprecated() {
+	request, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusUnauthorized, responseWriter.Code)
+	suite.NotEmpty(responseWriter.Header().Get("Deprecation"))
+	suite.Equal("Fri, 30 Apr 2027 00:00:00 GMT", responseWriter.Header().Get("Sunset"))
+	suite.Equal(`</v1/tasks>; rel="successor-version"`, responseWriter.Header().Get("Link"))
+}
+
+func (suite *RouteTestSuite) TestSwaggerUI() {
+	request, _ := http.NewRequest(http.MethodGet, "/docs", nil)
+	responseWriter := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseWriter, request)
+
+	suite.Equal(http.StatusOK, responseWriter.Code)
+	suite.Contains(responseWriter.Body.String(), "openapi.json")
+}
+
+func (suite *RouteTestSuite) TestHealthProbes_Public() {
+	for _, path := range []string{"/healthz", "/readyz"} {
+		request, _ := http.NewRequest(http.MethodGet, path, nil)
+		responseWriter := httptest.NewRecorder()
+		suite.router.ServeHTTP(responseWriter, request)
+
+		// nothing is registered with the health usecase of the suite, so it is ready
+		suite.Equal(http.StatusOK, responseWriter.Code, path)
+		suite.JSONEq(`{"status": "up"}`, responseWriter.Body.String(), path)
+	}
+}
+
+func (suite *RouteTestSuite) TestTasksWithoutProjectRoutes() {
+	registered := map[string]bool{}
+	for _, route := range suite.router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	// the tasks without a project have no project routes, so these are the only way to reach them
+	for _, route := range []string{
+		"PATCH /v1/tasks/:id/status",
+		"GET /v1/tasks/:id/comments",
+		"POST /v1/tasks/:id/comments",
+		"POST /v1/tasks/:id/attachments",
+		"GET /v1/tasks/:id/attachments/:attachmentID",
+		"DELETE /v1/tasks/:id/attachments/:attachmentID",
+	} {
+		suite.True(registered[route], "%v is not registered", route)
+	}
+}
+
+func (suite *RouteTestSuite) TestProjectRoutes_ReadScopedToken() {
+	// an admin token only scoped to read projects, whose writes are refused before reaching the database
+	router := gin.New()
+	group := router.Group("", func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{
+			"id":     primitive.NewObjectID().Hex(),
+			"role":   domain.RoleAdmin,
+			"scopes": []string{domain.PermissionProjectsRead},
+		})
+	})
+	NewProjectRouter(suite.env, time.Second, mongo.Database{}, nil, bootstrap.NewBlobStorage(suite.env), domain.DefaultRoles(), group)
+
+	projectID := primitive.NewObjectID().Hex()
+	taskID := primitive.NewObjectID().Hex()
+	writes := []struct{ method, path string }{
+		{http.MethodPut, "/projects/" + projectID},
+		{http.MethodDelete, "/projects/" + projectID},
+		{http.MethodPut, "/projects/" + projectID + "/members/" + primitive.NewObjectID().Hex()},
+		{http.MethodPost, "/projects/" + projectID + "/tasks"},
+		{http.MethodDelete, "/projects/" + projectID + "/tasks/" + taskID},
+		{http.MethodPatch, "/projects/" + projectID + "/tasks/" + taskID + "/status"},
+		{http.MethodPost, "/projects/" + projectID + "/tasks/" + taskID + "/comments"},
+	}
+	for _, write := range writes {
+		request, _ := http.NewRequest(write.method, write.path, nil)
+		responseWriter := httptest.NewRecorder()
+		router.ServeHTTP(responseWriter, request)
+
+		suite.Equal(http.StatusForbidden, responseWriter.Code, write.method+" "+write.path)
+		suite.Contains(responseWriter.Body.String(), "scope is required", write.method+" "+write.path)
+	}
+}
+
+func TestRouteTestSuite(t *testing.T) {
+	suite.Run(t, new(RouteTestSuite))
+}
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/bootstrap"
+	"Task_8-Testing_Task_Management_REST_API/delivery/controller"
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/usecases"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewTwoFactorRouter registers the routes users manage their own second factor with.
+// The group must authenticate the user, but not require two-factor authentication already.
+func NewTwoFactorRouter(env *bootstrap.Env, timeout time.Duration, database mongo.Database, metrics domain.RepositoryMetrics, group *gin.RouterGroup) {
+	userRepo := newUserRepo(database, metrics)
+
+	twoFactorController := &controller.TwoFactorController{
+		TwoFactorUsecase: newTwoFactorUsecase(env, timeout, userRepo),
+		Env:              env,
+	}
+
+	group.POST("/me/2fa/enroll", twoFactorController.Enroll)
+	group.POST("/me/2fa/confirm", twoFactorController.Confirm)
+	group.POST("/me/2fa/disable", twoFactorController.Disable)
+}
+
+func newTwoFactorUsecase(env *bootstrap.Env, timeout time.Duration, userRepo domain.UserRepository) domain.TwoFactorUsecase {
+	return usecases.NewTwoFactorUsecase(
+		userRepo,
+		env.TwoFactorIssuer,
+		env.AccessTokenSecret,
+		env.LoginChallengeTTL(),
+		env.TwoFactorRequiredRoles(),
+		timeout,
+	)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Attachment struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	UploaderID  primitive.ObjectID `json:"uploader_id" bson:"uploader_id"`
+	FileName    string             `json:"file_name" bson:"file_name"`
+	ContentType string             `json:"content_type" bson:"content_type"`
+	Size        int64              `json:"size" bson:"size"`
+	Hash        string             `json:"sha256" bson:"hash"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// BlobStorage stores the content of attachments under a key.
+// Implementations must be safe for concurrent use.
+type BlobStorage interface {
+	Put(c context.Context, key string, content io.Reader) error
+	Get(c context.Context, key string) (io.ReadCloser, error)
+	Exists(c context.Context, key string) (bool, error)
+	Delete(c context.Context, key string) error
+}
+
+type AttachmentUsecase interface {
+	Upload(c context.Context, taskID string, uploaderID string, fileName string, content io.Reader) (*Attachment, error)
+	Open(c context.Context, taskID string, attachmentID string) (*Attachment, io.ReadCloser, error)
+	Delete(c context.Context, taskID string, attachmentID string, actorID string, actorRole string) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionComment = "comments"
+
+type Comment struct {
+	ID        primitive.ObjectID  `json:"id" bson:"_id"`
+	TaskID    primitive.ObjectID  `json:"task_id" bson:"task_id"`
+	AuthorID  primitive.ObjectID  `json:"author_id" bson:"author_id"`
+	ParentID  *primitive.ObjectID `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	RootID    *primitive.ObjectID `json:"-" bson:"root_id,omitempty"`
+	Content   string              `json:"content" bson:"content"`
+	Deleted   bool                `json:"deleted" bson:"deleted"`
+	CreatedAt time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at" bson:"updated_at"`
+	Replies   []Comment           `json:"replies,omitempty" bson:"-"`
+}
+
+type CommentPage struct {
+	Comments   []Comment  `json:"comments"`
+	Pagination Pagination `json:"pagination"`
+	Total      int64      `json:"total"`
+}
+
+type CommentRepository interface {
+	Create(c context.Context, comment *Comment) error
+	GetByID(c context.Context, commentID string) (*Comment, error)
+	GetThreads(c context.Context, taskID string, pagination Pagination) ([]Comment, int64, error)
+	GetReplies(c context.Context, rootIDs []primitive.ObjectID) ([]Comment, error)
+	Update(c context.Context, comment *Comment) error
+	DeleteByTaskID(c context.Context, taskID string) error
+	DeleteByAuthor(c context.Context, authorID string, deletedAt time.Time) error
+}
+
+type CommentUsecase interface {
+	Create(c context.Context, taskID string, authorID string, parentID string, content string) (*Comment, error)
+	GetTaskComments(c context.Context, taskID string, pagination Pagination) (CommentPage, error)
+	Edit(c context.Context, commentID string, actorID string, content string) (*Comment, error)
+	Delete(c context.Context, commentID string, actorID string, actorRole string) error
+}
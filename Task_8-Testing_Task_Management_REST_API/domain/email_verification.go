@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// EmailVerificationUsecase proves that users own the email address they registered with,
+// by mailing them a signed link they have to follow.
+type EmailVerificationUsecase interface {
+	SendVerification(c context.Context, user *User) error
+	Resend(c context.Context, email string) error
+	Verify(c context.Context, token string) error
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "errors"
+
+var (
+	ErrForbidden       = errors.New("action not allowed for this user")
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrUserExists      = errors.New("user already exists")
+	ErrProjectNotFound = errors.New("project not found")
+	ErrNotMember       = errors.New("user is not a member of this project")
+	ErrProjectNotEmpty = errors.New("project still has tasks")
+	ErrLastOwner       = errors.New("a project must keep at least one owner")
+	ErrLastAdmin       = errors.New("there must be at least one active admin")
+	ErrUserDisabled    = errors.New("user account is disabled")
+	ErrSessionExpired  = errors.New("session is no longer valid, please log in again")
+	ErrInvalidToken    = errors.New("token is invalid or has expired")
+	ErrEmailUnverified = errors.New("email address has not been verified")
+	ErrBadCredentials  = errors.New("invalid email or password")
+	ErrTooManyAttempts = errors.New("too many failed login attempts, try again later")
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrTokenNotFound   = errors.New("access token not found")
+	ErrInvalidInput    = errors.New("invalid input")
+
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrAttachmentTooLarge   = errors.New("attachment exceeds the maximum allowed size")
+	ErrUnsupportedMediaType = errors.New("attachment type is not allowed")
+
+	ErrInvalidTwoFactorCode    = errors.New("two-factor code is invalid")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequired       = errors.New("two-factor authentication is required for this account")
+)
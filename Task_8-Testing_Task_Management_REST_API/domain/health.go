@@ -0,0 +1,48 @@
+package domain
+
+import "context"
+
+// The statuses of a health report and of the dependencies in it.
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// HealthChecker checks that a dependency of the API, such as the database, can be used.
+type HealthChecker interface {
+	Check(c context.Context) error
+}
+
+// HealthCheckerFunc lets a function be used as a HealthChecker.
+type HealthCheckerFunc func(c context.Context) error
+
+func (f HealthCheckerFunc) Check(c context.Context) error {
+	return f(c)
+}
+
+// DependencyHealth is the result of checking one dependency, with how long the check took.
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport answers the liveness and readiness probes. The liveness probe checks no dependency.
+type HealthReport struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyHealth `json:"checks,omitempty"`
+}
+
+// Up reports whether the API and every dependency checked are up.
+func (report HealthReport) Up() bool {
+	return report.Status == HealthStatusUp
+}
+
+type HealthUsecase interface {
+	// Register adds a dependency to check when probing readiness.
+	Register(name string, checker HealthChecker)
+	// Drain makes the readiness probe fail from then on, while the application shuts down.
+	Drain()
+	Liveness() HealthReport
+	Readiness(c context.Context) HealthReport
+}
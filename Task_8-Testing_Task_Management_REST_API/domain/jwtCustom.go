@@ -6,5 +6,7 @@ type JWTCustomClaims struct {
 	Name string `json:"name"`
 	ID   string `json:"id"`
 	Role string `json:"role"`
+	// Version is the TokenVersion of the user when the token was issued
+	Version int `json:"ver"`
 	jwt.StandardClaims
 }
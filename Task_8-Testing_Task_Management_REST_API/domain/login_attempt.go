@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+const CollectionLoginAttempt = "login_attempts"
+
+// LoginAttempt tracks the recent failed logins for an account or a client IP.
+// Key is the email of the account, or the IP, prefixed with its kind.
+// Attempts are counted as failures before the password is checked, and taken back once they succeed.
+type LoginAttempt struct {
+	Key         string    `bson:"_id"`
+	Failures    int       `bson:"failures"`
+	LastFailure time.Time `bson:"last_failure"`
+}
+
+// LoginPolicy configures how failed logins are throttled.
+// Every failure after the first delays the next attempt twice as long as the previous one, up to MaxDelay,
+// and reaching the maximum number of failures locks the account or the IP out until its last failure
+// is older than Lockout. Failures older than Lockout are forgotten.
+type LoginPolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	Lockout            time.Duration
+	MaxDelay           time.Duration
+}
+
+type LoginAttemptRepository interface {
+	Get(c context.Context, key string) (*LoginAttempt, error)
+	RecordAttempt(c context.Context, key string, at time.Time, forgetBefore time.Time) (*LoginAttempt, error)
+	ForgiveAttempt(c context.Context, key string) error
+	Reset(c context.Context, key string) error
+}
+
+type LoginAttemptUsecase interface {
+	Attempt(c context.Context, email string, ip string) (time.Duration, error)
+	Forgive(c context.Context, email string, ip string) error
+	RecordSuccess(c context.Context, email string, ip string) error
+	Unlock(c context.Context, userID string) error
+}
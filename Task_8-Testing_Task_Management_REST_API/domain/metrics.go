@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+// RepositoryMetrics records how long the operations of the repositories take and which of them fail.
+type RepositoryMetrics interface {
+	ObserveRepositoryOperation(collection string, operation string, duration time.Duration, err error)
+}
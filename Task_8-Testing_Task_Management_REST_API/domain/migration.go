@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const CollectionMigration = "migrations"
+
+// ErrMigrationLocked is returned when another instance kept the migrations locked for too long.
+var ErrMigrationLocked = errors.New("the migrations are locked by another instance")
+
+// MigrationStatus describes one migration of the database, applied at AppliedAt, or not applied yet when it is nil.
+type MigrationStatus struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+// Migrator brings the database to the schema the application expects: its indexes and the shape of its documents.
+type Migrator interface {
+	// Migrate applies the migrations not applied yet in the order of their versions, and returns those it applied.
+	// Instances starting together apply them once: the others wait until the first one is done.
+	Migrate(c context.Context) ([]MigrationStatus, error)
+	// Status lists every migration, applied or not.
+	Status(c context.Context) ([]MigrationStatus, error)
+}
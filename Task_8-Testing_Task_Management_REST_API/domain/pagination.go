@@ -0,0 +1,32 @@
+package domain
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type Pagination struct {
+	Page  int64 `json:"page"`
+	Limit int64 `json:"limit"`
+}
+
+// NewPagination builds a Pagination from the requested page and limit,
+// falling back to the first page and the default limit for missing or out of range values.
+func NewPagination(page, limit int64) Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	return Pagination{Page: page, Limit: limit}
+}
+
+// Skip returns the number of documents to skip to reach the current page.
+func (p Pagination) Skip() int64 {
+	return (p.Page - 1) * p.Limit
+}
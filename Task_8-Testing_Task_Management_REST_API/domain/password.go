@@ -0,0 +1,42 @@
+package domain
+
+import "fmt"
+
+// PasswordHasher hashes passwords into encoded strings that name the algorithm and the parameters they were hashed with,
+// so that hashes made with older settings can still be verified, and upgraded with NeedsRehash.
+// Implementations must be safe for concurrent use.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the encoded hash, of any supported algorithm.
+	Verify(password string, encodedHash string) (bool, error)
+	// NeedsRehash reports whether the encoded hash was made with another algorithm or other parameters than Hash uses.
+	NeedsRehash(encodedHash string) bool
+	// SimulateVerify takes as long as Verify, without any hash to compare against.
+	SimulateVerify(password string)
+}
+
+// PasswordPolicy decides which passwords users may choose. Breached holds passwords known from data breaches,
+// which are refused whatever their length.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	Breached  map[string]bool
+}
+
+// Validate checks that the password complies with the policy.
+// A password that does not is reported as a ValidationError of the 'password' field.
+func (policy PasswordPolicy) Validate(password string) error {
+	var validation ValidationError
+
+	length := len([]rune(password))
+	switch {
+	case length < policy.MinLength:
+		validation.Add("password", "min", fmt.Sprintf("password must be at least %v characters long", policy.MinLength))
+	case policy.MaxLength > 0 && length > policy.MaxLength:
+		validation.Add("password", "max", fmt.Sprintf("password must be at most %v characters long", policy.MaxLength))
+	case policy.Breached[password]:
+		validation.Add("password", "breached", "password has appeared in a data breach, choose another one")
+	}
+
+	return validation.Err()
+}
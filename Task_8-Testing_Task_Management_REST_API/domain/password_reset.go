@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionPasswordReset = "password_resets"
+
+// PasswordReset is a request to reset the password of a user.
+// Only the SHA-256 hash of the token sent to the user is stored.
+type PasswordReset struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	TokenHash string             `bson:"token_hash"`
+	ExpiresAt time.Time          `bson:"expires_at"`
+	UsedAt    *time.Time         `bson:"used_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// Message is an email sent to a user.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages to users.
+// Implementations must be safe for concurrent use.
+type Mailer interface {
+	Send(c context.Context, message Message) error
+}
+
+type PasswordResetRepository interface {
+	Create(c context.Context, reset *PasswordReset) error
+	GetByTokenHash(c context.Context, tokenHash string) (*PasswordReset, error)
+	MarkUsed(c context.Context, resetID primitive.ObjectID, usedAt time.Time) error
+	DeleteByUserID(c context.Context, userID primitive.ObjectID) error
+}
+
+type PasswordResetUsecase interface {
+	RequestReset(c context.Context, email string) error
+	ResetPassword(c context.Context, token string, password string) error
+}
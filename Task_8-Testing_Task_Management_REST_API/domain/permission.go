@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// the roles every deployment has: new users get RoleUser, promoted users get RoleAdmin
+const (
+	RoleUser  = "USER"
+	RoleAdmin = "ADMIN"
+)
+
+// permissions are named '<resource>:<action>'
+const (
+	PermissionTasksRead         = "tasks:read"
+	PermissionTasksReadAssigned = "tasks:read_assigned"
+	PermissionTasksCreate       = "tasks:create"
+	PermissionTasksUpdate       = "tasks:update"
+	PermissionTasksDelete       = "tasks:delete"
+	PermissionTasksAssign       = "tasks:assign"
+
+	PermissionProjectsRead   = "projects:read"
+	PermissionProjectsWrite  = "projects:write"
+	PermissionProjectsCreate = "projects:create"
+	PermissionProjectsManage = "projects:manage"
+
+	PermissionCommentsWrite       = "comments:write"
+	PermissionCommentsModerate    = "comments:moderate"
+	PermissionAttachmentsModerate = "attachments:moderate"
+
+	PermissionUsersRead    = "users:read"
+	PermissionUsersPromote = "users:promote"
+	PermissionUsersDemote  = "users:demote"
+	PermissionUsersDisable = "users:disable"
+	PermissionUsersDelete  = "users:delete"
+	PermissionUsersUnlock  = "users:unlock"
+)
+
+// DefaultRolePermissions is the role configuration used when ROLE_PERMISSIONS is not set.
+const DefaultRolePermissions = "USER=tasks:read_assigned,projects:read,projects:write,projects:create,comments:write;ADMIN=*"
+
+// Roles maps the name of each role to the set of permissions it grants.
+// A permission of '*' grants everything, and '<resource>:*' grants every action on the resource.
+type Roles map[string]map[string]bool
+
+// ParseRoles parses a role configuration of the form 'ROLE=perm,perm;ROLE=perm'.
+// Both RoleUser and RoleAdmin must be defined.
+func ParseRoles(spec string) (Roles, error) {
+	roles := Roles{}
+
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, permissions, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid role definition '%v'", entry)
+		}
+		if _, exists := roles[name]; exists {
+			return nil, fmt.Errorf("role '%v' is defined twice", name)
+		}
+
+		roles[name] = map[string]bool{}
+		for _, permission := range strings.Split(permissions, ",") {
+			permission = strings.TrimSpace(permission)
+			if permission != "" {
+				roles[name][permission] = true
+			}
+		}
+	}
+
+	for _, required := range []string{RoleUser, RoleAdmin} {
+		if _, exists := roles[required]; !exists {
+			return nil, fmt.Errorf("role '%v' is not defined", required)
+		}
+	}
+
+	return roles, nil
+}
+
+// DefaultRoles returns the roles of DefaultRolePermissions.
+func DefaultRoles() Roles {
+	roles, err := ParseRoles(DefaultRolePermissions)
+	if err != nil {
+		panic(err)
+	}
+
+	return roles
+}
+
+// Exists reports whether the role is defined.
+func (roles Roles) Exists(role string) bool {
+	_, exists := roles[role]
+	return exists
+}
+
+// Has reports whether the role grants the permission. Unknown roles grant nothing.
+func (roles Roles) Has(role string, permission string) bool {
+	return grants(roles[role], permission)
+}
+
+// ScopesGrant reports whether the scopes of a personal access token grant the permission.
+// Scopes are written like the permissions of a role, with the same wildcards.
+func ScopesGrant(scopes []string, permission string) bool {
+	granted := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	return grants(granted, permission)
+}
+
+func grants(granted map[string]bool, permission string) bool {
+	if granted["*"] || granted[permission] {
+		return true
+	}
+
+	resource, _, _ := strings.Cut(permission, ":")
+	return granted[resource+":*"]
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionPersonalAccessToken = "personal_access_tokens"
+
+// PersonalAccessTokenPrefix starts every personal access token, which tells them apart from JWT tokens
+// in the 'Authorization' header and makes leaked tokens easy to search for.
+const PersonalAccessTokenPrefix = "tmpat_"
+
+// PersonalAccessToken is a long-lived token a user creates for scripts and bots, so that they don't need the password.
+// It only grants the permissions in Scopes that the role of the user still grants. Only the SHA-256 hash of the token
+// is stored, and Hint keeps its last characters for users to recognise it. A nil ExpiresAt never expires.
+type PersonalAccessToken struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	UserID     primitive.ObjectID `json:"-" bson:"user_id"`
+	Name       string             `json:"name" bson:"name"`
+	Scopes     []string           `json:"scopes" bson:"scopes"`
+	TokenHash  string             `json:"-" bson:"token_hash"`
+	Hint       string             `json:"hint" bson:"hint"`
+	ExpiresAt  *time.Time         `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// PersonalAccessTokenRequest describes the token a user asks for. ExpiresInDays of 0 creates a token that never expires.
+type PersonalAccessTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+// CreatedPersonalAccessToken is returned once when a token is created, as the token itself is not stored.
+type CreatedPersonalAccessToken struct {
+	Token string `json:"token"`
+	PersonalAccessToken
+}
+
+type PersonalAccessTokenRepository interface {
+	Create(c context.Context, token *PersonalAccessToken) error
+	GetByTokenHash(c context.Context, tokenHash string) (*PersonalAccessToken, error)
+	GetByUserID(c context.Context, userID primitive.ObjectID) ([]PersonalAccessToken, error)
+	SetLastUsed(c context.Context, tokenID primitive.ObjectID, usedAt time.Time) error
+	Delete(c context.Context, userID primitive.ObjectID, tokenID string) error
+	DeleteByUserID(c context.Context, userID primitive.ObjectID) error
+}
+
+type PersonalAccessTokenUsecase interface {
+	Create(c context.Context, userID string, request PersonalAccessTokenRequest) (*CreatedPersonalAccessToken, error)
+	GetUserTokens(c context.Context, userID string) ([]PersonalAccessToken, error)
+	Revoke(c context.Context, userID string, tokenID string) error
+	Authenticate(c context.Context, token string) (*PersonalAccessToken, error)
+}
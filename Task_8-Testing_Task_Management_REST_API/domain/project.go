@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const CollectionProject = "projects"
+
+// roles of the members of a project, from the least to the most privileged
+const (
+	ProjectRoleViewer = "viewer"
+	ProjectRoleMember = "member"
+	ProjectRoleOwner  = "owner"
+)
+
+type ProjectMember struct {
+	UserID primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Role   string             `json:"role" bson:"role"`
+}
+
+type Project struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Name        string             `json:"name" bson:"name"`
+	Description string             `json:"description" bson:"description"`
+	Members     []ProjectMember    `json:"members" bson:"members"`
+	CreatedBy   primitive.ObjectID `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// ProjectRoleRank orders the project roles so that they can be compared,
+// unknown roles rank below every valid one.
+func ProjectRoleRank(role string) int {
+	switch role {
+	case ProjectRoleViewer:
+		return 1
+	case ProjectRoleMember:
+		return 2
+	case ProjectRoleOwner:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type ProjectRepository interface {
+	Create(c context.Context, project *Project) error
+	GetByID(c context.Context, projectID string) (*Project, error)
+	GetByMember(c context.Context, userID string) ([]Project, error)
+	Update(c context.Context, project *Project) error
+	Delete(c context.Context, projectID string) error
+	SetMember(c context.Context, projectID string, member ProjectMember) error
+	RemoveMember(c context.Context, projectID string, userID string) error
+}
+
+type ProjectUsecase interface {
+	Create(c context.Context, project *Project, ownerID string) error
+	GetByID(c context.Context, projectID string) (*Project, error)
+	GetUserProjects(c context.Context, userID string) ([]Project, error)
+	Update(c context.Context, projectID string, name string, description string) error
+	Delete(c context.Context, projectID string) error
+	AddMember(c context.Context, projectID string, userID string, role string) error
+	RemoveMember(c context.Context, projectID string, userID string) error
+	GetMemberRole(c context.Context, projectID string, userID string) (string, error)
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const CollectionRateLimit = "rate_limits"
+
+// RateLimit is a token bucket holding up to Requests tokens, refilled evenly over Period.
+// Every request takes a token, so bursts of Requests requests are allowed, and Requests per Period on average.
+// The zero RateLimit means no limit.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket.
+// Reset is how long the bucket takes to be full again, and RetryAfter how long until the next token when none was left.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the token buckets of the clients.
+// Implementations must be safe for concurrent use, and take tokens atomically.
+type RateLimitStore interface {
+	Take(c context.Context, key string, limit RateLimit, now time.Time) (RateLimitResult, error)
+}
+
+var rateLimitPeriods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseRateLimit parses a rate limit of the form '<requests>/<s|m|h>', such as '100/m'.
+// 'off' and an empty string mean no limit.
+func ParseRateLimit(spec string) (RateLimit, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || spec == "off" {
+		return RateLimit{}, nil
+	}
+
+	requests, unit, found := strings.Cut(spec, "/")
+	period, knownUnit := rateLimitPeriods[strings.TrimSpace(unit)]
+	count, err := strconv.Atoi(strings.TrimSpace(requests))
+	if !found || !knownUnit || err != nil || count <= 0 {
+		return RateLimit{}, fmt.Errorf("invalid rate limit '%v', expected '<requests>/<s|m|h>'", spec)
+	}
+
+	return RateLimit{Requests: count, Period: period}, nil
+}
+
+// Enabled reports whether the rate limit restricts anything.
+func (limit RateLimit) Enabled() bool {
+	return limit.Requests > 0
+}
+
+// Refill returns the tokens of a bucket that held the given tokens elapsed ago, which never exceed Requests.
+func (limit RateLimit) Refill(tokens float64, elapsed time.Duration) float64 {
+	if elapsed > 0 {
+		tokens += elapsed.Seconds() * limit.perSecond()
+	}
+
+	return math.Min(tokens, float64(limit.Requests))
+}
+
+// Result describes a bucket left with the given tokens after a request was allowed or not.
+func (limit RateLimit) Result(tokens float64, allowed bool) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Limit:     limit.Requests,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     limit.durationFor(float64(limit.Requests) - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = limit.durationFor(1 - tokens)
+	}
+
+	return result
+}
+
+func (limit RateLimit) perSecond() float64 {
+	return float64(limit.Requests) / limit.Period.Seconds()
+}
+
+// durationFor returns how long the bucket takes to refill the given tokens.
+func (limit RateLimit) durationFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(tokens / limit.perSecond() * float64(time.Second)))
+}
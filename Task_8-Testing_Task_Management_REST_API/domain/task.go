@@ -9,12 +9,36 @@ import (
 
 const CollectionTask = "tasks"
 
+// the statuses a task goes through, new tasks start as pending
+const (
+	TaskStatusPending    = "pending"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusDone       = "done"
+)
+
+// TaskStatuses lists the valid statuses of a task.
+var TaskStatuses = []string{TaskStatusPending, TaskStatusInProgress, TaskStatusDone}
+
+// IsTaskStatus reports whether status is one of TaskStatuses.
+func IsTaskStatus(status string) bool {
+	for _, taskStatus := range TaskStatuses {
+		if status == taskStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Task struct {
-	ID          primitive.ObjectID `json:"-" bson:"_id"`
-	Title       string             `json:"title" bson:"title"`
-	Description string             `json:"description" bson:"description"`
-	DueDate     time.Time          `json:"duedate" bson:"duedate"`
-	Status      string             `json:"status" bson:"status"`
+	ID          primitive.ObjectID   `json:"id" bson:"_id"`
+	ProjectID   primitive.ObjectID   `json:"project_id" bson:"project_id"`
+	Title       string               `json:"title" bson:"title"`
+	Description string               `json:"description" bson:"description"`
+	DueDate     time.Time            `json:"duedate" bson:"duedate"`
+	Status      string               `json:"status" bson:"status"`
+	Assignees   []primitive.ObjectID `json:"assignees,omitempty" bson:"assignees,omitempty"`
+	Attachments []Attachment         `json:"attachments,omitempty" bson:"attachments,omitempty"`
 }
 
 type TaskRepository interface {
@@ -23,6 +47,16 @@ type TaskRepository interface {
 	GetTaskByID(c context.Context, taskID string) (Task, error)
 	UpdateTask(c context.Context, taskID string, updated_task *Task) error
 	DeleteTask(c context.Context, taskID string) error
+	AddAttachment(c context.Context, taskID string, attachment *Attachment) error
+	RemoveAttachment(c context.Context, taskID string, attachmentID string) error
+	CountAttachmentsByHash(c context.Context, hash string) (int64, error)
+	SetAssignees(c context.Context, taskID string, assignees []primitive.ObjectID) error
+	GetTasksByAssignee(c context.Context, userID string) ([]Task, error)
+	UnassignUser(c context.Context, userID string) error
+	ReassignUser(c context.Context, userID string, replacementID string) error
+	GetTasksByProject(c context.Context, projectID string) ([]Task, error)
+	CountTasksByProject(c context.Context, projectID string) (int64, error)
+	CountTasksByStatus(c context.Context) (map[string]int64, error)
 }
 
 type TaskUsecase interface {
@@ -31,4 +65,9 @@ type TaskUsecase interface {
 	GetTaskByID(c context.Context, taskID string) (Task, error)
 	UpdateTask(c context.Context, taskID string, updated_task *Task) error
 	DeleteTask(c context.Context, taskID string) error
+	AssignUsers(c context.Context, taskID string, userIDs []string) error
+	UpdateStatus(c context.Context, taskID string, actorID string, actorRole string, status string) error
+	GetAssignedTasks(c context.Context, userID string) ([]Task, error)
+	GetProjectTasks(c context.Context, projectID string) ([]Task, error)
+	GetTaskWithoutProject(c context.Context, taskID string, actorID string, actorRole string) (Task, error)
 }
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// TwoFactorEnrolment is what an authenticator app needs to generate the codes of a user:
+// the base32 secret, to be typed in, or the 'otpauth://' URI, to be shown as a QR code.
+type TwoFactorEnrolment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// TwoFactorUsecase manages the TOTP second factor of users. Enrolling only takes effect once the user
+// confirms it with a first code, which returns the one-time recovery codes to use if the authenticator is lost.
+// Logins of users with two-factor authentication enabled go through a short-lived challenge token,
+// exchanged for an access token together with a code. A code is either a TOTP code or an unused recovery code.
+type TwoFactorUsecase interface {
+	Enroll(c context.Context, userID string) (*TwoFactorEnrolment, error)
+	Confirm(c context.Context, userID string, code string) ([]string, error)
+	Disable(c context.Context, userID string, code string) error
+	CreateChallenge(user *User) string
+	GetChallengeUser(c context.Context, challengeToken string) (*User, error)
+	VerifyCode(c context.Context, user *User, code string) error
+}
@@ -2,26 +2,115 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const CollectionUser = "users"
 
+// what happens to the tasks of a deleted user: they are either reassigned to another user
+// or left without the deleted user as an assignee
+const (
+	TaskPolicyOrphan   = "orphan"
+	TaskPolicyReassign = "reassign"
+)
+
+// User is an account of the system. Its TokenVersion is embedded in the access tokens issued to it,
+// so incrementing it invalidates every token issued before.
+// VerificationSentAt records when the last email verification link was sent, to throttle resends.
+// TOTPSecret is only used for logins once TwoFactorEnabled is set, after the user confirmed their enrolment.
+// RecoveryCodes holds the hashes of the recovery codes that have not been used yet, and
+// TOTPLastStep the time step of the last accepted code, so that codes cannot be replayed.
 type User struct {
-	UserID   primitive.ObjectID `json:"-" bson:"_id"`
-	Name     string             `json:"name" bson:"name"`
-	Email    string             `json:"email" bson:"email"`
-	Password string             `json:"password" bson:"password"`
-	Role     string             `json:"role" bson:"role"`
+	UserID             primitive.ObjectID `json:"-" bson:"_id"`
+	Name               string             `json:"name" bson:"name"`
+	Email              string             `json:"email" bson:"email"`
+	Password           string             `json:"password" bson:"password"`
+	Role               string             `json:"role" bson:"role"`
+	Disabled           bool               `json:"-" bson:"disabled"`
+	TokenVersion       int                `json:"-" bson:"token_version"`
+	EmailVerified      bool               `json:"-" bson:"email_verified"`
+	VerificationSentAt time.Time          `json:"-" bson:"verification_sent_at"`
+	TOTPSecret         string             `json:"-" bson:"totp_secret"`
+	TwoFactorEnabled   bool               `json:"-" bson:"two_factor_enabled"`
+	RecoveryCodes      []string           `json:"-" bson:"recovery_codes"`
+	TOTPLastStep       int64              `json:"-" bson:"totp_last_step"`
+}
+
+// UserProfile is the view of a user returned by the API, without the password hash.
+type UserProfile struct {
+	ID               primitive.ObjectID `json:"id"`
+	Name             string             `json:"name"`
+	Email            string             `json:"email"`
+	Role             string             `json:"role"`
+	Disabled         bool               `json:"disabled"`
+	EmailVerified    bool               `json:"email_verified"`
+	TwoFactorEnabled bool               `json:"two_factor_enabled"`
+}
+
+// Profile returns the public view of the user.
+func (user *User) Profile() UserProfile {
+	return UserProfile{
+		ID:               user.UserID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Role:             user.Role,
+		Disabled:         user.Disabled,
+		EmailVerified:    user.EmailVerified,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+	}
+}
+
+// NormalizeEmail checks that email is a bare address, such as "name@example.com",
+// and returns it without surrounding spaces and in lower case.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Name != "" || address.Address != email {
+		return "", fmt.Errorf("%w: '%v' is not a valid email address", ErrInvalidInput, email)
+	}
+
+	return email, nil
+}
+
+// UserFilter narrows down a user listing. Query matches the name or the email, ignoring case.
+type UserFilter struct {
+	Query string
+	Role  string
+}
+
+type UserPage struct {
+	Users      []UserProfile `json:"users"`
+	Pagination Pagination    `json:"pagination"`
+	Total      int64         `json:"total"`
 }
 
+// UserRepository stores the users. UpdateUser rewrites the whole user, so the password, the role,
+// the status and the token version are only changed with the methods updating that field alone,
+// which cannot undo a concurrent change of another one.
 type UserRepository interface {
 	Create(c context.Context, user *User) error
 	GetByEmail(c context.Context, email string) (*User, error)
 	GetByID(c context.Context, id string) (*User, error)
 	UpdateUser(c context.Context, user *User) error
+	SetPassword(c context.Context, id string, hashedPassword string) error
+	ReplacePasswordHash(c context.Context, id string, oldHash string, newHash string) (bool, error)
+	SetRole(c context.Context, id string, role string) error
+	SetDisabled(c context.Context, id string, disabled bool) error
+	SetEmailVerified(c context.Context, id string) error
+	ClaimVerificationSend(c context.Context, id string, sentBefore time.Time, sentAt time.Time) (bool, error)
+	UpdateTwoFactor(c context.Context, user *User) error
+	UseTOTPStep(c context.Context, id string, step int64) (bool, error)
+	UseRecoveryCode(c context.Context, id string, hash string) (bool, error)
 	AreThereAnyUsers(c context.Context) (bool, error)
+	GetUsers(c context.Context, filter UserFilter, pagination Pagination) ([]User, int64, error)
+	CountActiveByRole(c context.Context, role string) (int64, error)
+	DeleteUser(c context.Context, id string) error
 }
 
 type UserUsecase interface {
@@ -29,6 +118,13 @@ type UserUsecase interface {
 	GetByEmail(c context.Context, email string) (*User, error)
 	GetByID(c context.Context, id string) (*User, error)
 	UpdateUser(c context.Context, user *User) error
+	SetPassword(c context.Context, id string, hashedPassword string) error
+	ReplacePasswordHash(c context.Context, id string, oldHash string, newHash string) (bool, error)
 	AreThereAnyUsers(c context.Context) (bool, error)
 	CreateAccessToken(user *User, secret string, expiry int) (string, error)
+	GetUsers(c context.Context, filter UserFilter, pagination Pagination) (*UserPage, error)
+	Promote(c context.Context, id string) error
+	Demote(c context.Context, id string) error
+	SetDisabled(c context.Context, id string, disabled bool) error
+	Delete(c context.Context, id string, taskPolicy string, replacementID string) error
 }
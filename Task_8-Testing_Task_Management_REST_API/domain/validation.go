@@ -0,0 +1,64 @@
+package domain
+
+import "strings"
+
+// FieldError describes why one field of a request is invalid. Code is meant for programs,
+// such as "required" or "max", and Message for people.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every invalid field of a request, rather than only the first one found.
+// It wraps ErrInvalidInput, so it can be checked with errors.Is like any other invalid input.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Add records one more invalid field.
+func (e *ValidationError) Add(field string, code string, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Merge records the invalid fields of other that are not listed yet, so that
+// a field checked twice is only reported once, with the reason found first.
+func (e *ValidationError) Merge(other *ValidationError) {
+	for _, field := range other.Fields {
+		if !e.has(field.Field) {
+			e.Fields = append(e.Fields, field)
+		}
+	}
+}
+
+func (e *ValidationError) has(field string) bool {
+	for _, fieldError := range e.Fields {
+		if fieldError.Field == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns the validation error if any field was found invalid, and nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+
+	return ErrInvalidInput.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
@@ -0,0 +1,46 @@
+package infrastructure
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiVersionKey = "api_version"
+
+// APIVersionMiddleware records the version of the API the routes of a group belong to,
+// so that controllers can answer with the response shapes of that version.
+func APIVersionMiddleware(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Next()
+	}
+}
+
+// GetAPIVersionFromContext returns the version of the API the request was made to.
+// Requests to routes outside of any version get the first one.
+func GetAPIVersionFromContext(c *gin.Context) int {
+	version, ok := c.Get(apiVersionKey)
+	if !ok {
+		return 1
+	}
+
+	return version.(int)
+}
+
+// DeprecationMiddleware marks the responses of routes that are going away with a 'Deprecation' header
+// (RFC 9745) telling since when they are deprecated, a 'Sunset' header (RFC 8594) telling when they stop working,
+// and a 'Link' header pointing to the same route under the successorPrefix, such as "/v1".
+func DeprecationMiddleware(deprecatedAt time.Time, sunset time.Time, successorPrefix string) gin.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		c.Header("Sunset", sunsetDate)
+		c.Header("Link", "<"+successorPrefix+c.Request.URL.Path+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
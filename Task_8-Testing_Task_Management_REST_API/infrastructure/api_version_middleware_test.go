@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIVersionMiddlewareSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *APIVersionMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+
+	// the same handler answers with the shape of the version of the route
+	handler := func(c *gin.Context) {
+		c.String(http.StatusOK, strconv.Itoa(GetAPIVersionFromContext(c)))
+	}
+
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	suite.router.Group("/v2", APIVersionMiddleware(2)).GET("/tasks/:id", handler)
+	suite.router.Group("", DeprecationMiddleware(deprecatedAt, sunset, "/v1")).GET("/tasks/:id", handler)
+}
+
+func (suite *APIVersionMiddlewareSuite) serve(path string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	recorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (suite *APIVersionMiddlewareSuite) TestVersionedRoute() {
+	recorder := suite.serve("/v2/tasks/42")
+
+	suite.Equal("2", recorder.Body.String())
+	suite.Empty(recorder.Header().Get("Deprecation"))
+}
+
+func (suite *APIVersionMiddlewareSuite) TestDeprecatedRoute() {
+	recorder := suite.serve("/tasks/42")
+
+	// routes outside of a version are the first version
+	suite.Equal("1", recorder.Body.String())
+	suite.Equal("@1792368000", recorder.Header().Get("Deprecation"))
+	suite.Equal("Fri, 30 Apr 2027 00:00:00 GMT", recorder.Header().Get("Sunset"))
+	suite.Equal(`</v1/tasks/42>; rel="successor-version"`, recorder.Header().Get("Link"))
+}
+
+func TestAPIVersionMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(APIVersionMiddlewareSuite))
+}
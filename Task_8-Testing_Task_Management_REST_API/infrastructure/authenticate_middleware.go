@@ -1,6 +1,8 @@
 package infrastructure
 
 import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -12,7 +14,11 @@ import (
 // It checks the Authorization header for a valid JWT token and sets the claims to the context.
 // If the token is invalid or missing, it returns an error response.
 // The secret parameter is used to validate the token's signature.
-func JWTAuthMiddleware(secret string) gin.HandlerFunc {
+// Bearer tokens starting with domain.PersonalAccessTokenPrefix are personal access tokens instead, checked by tokens,
+// whose claims also carry the 'scopes' the token is limited to.
+// The user of the token is looked up on every request, so that deleted or disabled users are rejected
+// and role changes take effect immediately rather than when the token expires.
+func JWTAuthMiddleware(secret string, users domain.UserUsecase, tokens domain.PersonalAccessTokenUsecase) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.Request.Header.Get("Authorization")
 		if authHeader == "" {
@@ -28,25 +34,91 @@ func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 			return
 		}
 
-		// check if token is authorized
 		tokenString := splitted[1]
-		authorizedToken, err := IsAuthorized(tokenString, secret)
-		// check if the token is valid
-		if authorizedToken != nil && !authorizedToken.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "token has expired"})
+		var claims jwt.MapClaims
+		isAccessToken := strings.HasPrefix(tokenString, domain.PersonalAccessTokenPrefix)
+		if isAccessToken {
+			// check that the personal access token exists and has not expired
+			token, err := tokens.Authenticate(c, tokenString)
+			if errors.Is(err, domain.ErrInvalidToken) {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "access token is invalid or has expired"})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+				c.Abort()
+				return
+			}
+
+			claims = jwt.MapClaims{"id": token.UserID.Hex(), "scopes": token.Scopes}
+		} else {
+			// check if token is authorized
+			authorizedToken, err := IsAuthorized(tokenString, secret)
+			// check if the token is valid
+			if authorizedToken != nil && !authorizedToken.Valid {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "token has expired"})
+				c.Abort()
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+				c.Abort()
+				return
+			}
+
+			claims = authorizedToken.Claims.(jwt.MapClaims)
+		}
+
+		// check that the user of the token still exists and is allowed in
+		user_id, _ := claims["id"].(string)
+		user, err := users.GetByID(c, user_id)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
 			c.Abort()
 			return
 		}
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 			c.Abort()
 			return
 		}
+		if user.Disabled {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": domain.ErrUserDisabled.Error()})
+			c.Abort()
+			return
+		}
+
+		// tokens issued before the sessions of the user were invalidated, such as by a password reset, are rejected
+		// personal access tokens are not sessions, they stay valid until revoked
+		if !isAccessToken {
+			version, _ := claims["ver"].(float64)
+			if int(version) != user.TokenVersion {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": domain.ErrSessionExpired.Error()})
+				c.Abort()
+				return
+			}
+		}
 
-		// set the claims to the context
-		claims := authorizedToken.Claims.(jwt.MapClaims)
+		// set the claims to the context, with the current role and two-factor state of the user
+		claims["role"] = user.Role
+		claims["2fa"] = user.TwoFactorEnabled
 		c.Set("claims", claims)
 
 		c.Next()
 	}
 }
+
+// DenyPersonalAccessTokens is a middleware function that refuses requests authenticated with a personal access token,
+// for the routes that manage the account itself. It has to run after JWTAuthMiddleware.
+func DenyPersonalAccessTokens() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, isAccessToken := GetScopesFromContext(c); isAccessToken {
+			c.JSON(http.StatusForbidden, gin.H{"error": "this endpoint can't be used with a personal access token"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
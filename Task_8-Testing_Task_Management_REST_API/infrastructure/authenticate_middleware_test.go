@@ -2,20 +2,26 @@ package infrastructure
 
 import (
 	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type AuthMiddlewareSuite struct {
 	suite.Suite
-	router   *gin.Engine
-	secret   string
-	mockUser *domain.User
+	router           *gin.Engine
+	secret           string
+	mockUser         *domain.User
+	mockUserUsecase  *mocks.UserUsecase
+	mockTokenUsecase *mocks.PersonalAccessTokenUsecase
 }
 
 func (suite *AuthMiddlewareSuite) SetupTest() {
@@ -23,7 +29,10 @@ func (suite *AuthMiddlewareSuite) SetupTest() {
 
 	suite.router = gin.Default()
 	suite.secret = "this is a test secret"
+	suite.mockUserUsecase = new(mocks.UserUsecase)
+	suite.mockTokenUsecase = new(mocks.PersonalAccessTokenUsecase)
 	suite.mockUser = &domain.User{
+		UserID:   primitive.NewObjectID(),
 		Email:    "test@example.com",
 		Password: "password123",
 		Name:     "Test User",
@@ -31,8 +40,31 @@ func (suite *AuthMiddlewareSuite) SetupTest() {
 	}
 }
 
+func (suite *AuthMiddlewareSuite) TearDownTest() {
+	suite.mockUserUsecase.AssertExpectations(suite.T())
+	suite.mockTokenUsecase.AssertExpectations(suite.T())
+}
+
+// serveWithToken makes a request to the '/test' route authenticated with a token of the mock user
+func (suite *AuthMiddlewareSuite) serveWithToken() *httptest.ResponseRecorder {
+	accessToken, err := CreateAccessToken(suite.mockUser, suite.secret, 24)
+	if err != nil {
+		suite.Fail("Failed to generate token", err)
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+	response := httptest.NewRecorder()
+	suite.router.ServeHTTP(response, request)
+
+	return response
+}
+
 func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_Success() {
-	suite.router.Use(JWTAuthMiddleware(suite.secret))
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(suite.mockUser, nil).Once()
+
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
 	suite.router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusOK)
 	})
@@ -52,7 +84,7 @@ func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_Success() {
 }
 
 func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_NoAuthHeader() {
-	suite.router.Use(JWTAuthMiddleware(suite.secret))
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
 	suite.router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusUnauthorized)
 	})
@@ -67,7 +99,7 @@ func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_NoAuthHeader() {
 }
 
 func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_InvalidAuthHeader() {
-	suite.router.Use(JWTAuthMiddleware(suite.secret))
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
 	suite.router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusUnauthorized)
 	})
@@ -83,7 +115,7 @@ func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_InvalidAuthHeader() {
 }
 
 func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_UnauthorizedToken() {
-	suite.router.Use(JWTAuthMiddleware(suite.secret))
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
 	suite.router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusUnauthorized)
 	})
@@ -100,7 +132,7 @@ func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_UnauthorizedToken() {
 }
 
 func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_TokenExpired() {
-	suite.router.Use(JWTAuthMiddleware(suite.secret))
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
 	suite.router.GET("/test", func(c *gin.Context) {
 		c.Status(http.StatusUnauthorized)
 	})
@@ -120,6 +152,157 @@ func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_TokenExpired() {
 	suite.Contains(response.Body.String(), "token has expired")
 }
 
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_DisabledUser() {
+	disabledUser := *suite.mockUser
+	disabledUser.Disabled = true
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(&disabledUser, nil).Once()
+
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serveWithToken()
+
+	suite.Equal(http.StatusUnauthorized, response.Code)
+	suite.Contains(response.Body.String(), "user account is disabled")
+}
+
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_DeletedUser() {
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(&domain.User{}, domain.ErrUserNotFound).Once()
+
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serveWithToken()
+
+	suite.Equal(http.StatusUnauthorized, response.Code)
+	suite.Contains(response.Body.String(), "unauthorized user")
+}
+
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_UsesCurrentRole() {
+	demotedUser := *suite.mockUser
+	suite.mockUser.Role = "ADMIN"
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(&demotedUser, nil).Once()
+
+	var role interface{}
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		claims, _ := c.Get("claims")
+		role = claims.(jwt.MapClaims)["role"]
+		c.Status(http.StatusOK)
+	})
+
+	// the token still claims the 'ADMIN' role the user had when logging in
+	response := suite.serveWithToken()
+
+	suite.Equal(http.StatusOK, response.Code)
+	suite.Equal("USER", role)
+}
+
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_StaleTokenVersion() {
+	resetUser := *suite.mockUser
+	resetUser.TokenVersion = 1
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(&resetUser, nil).Once()
+
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// the token was issued before the password of the user was reset
+	response := suite.serveWithToken()
+
+	suite.Equal(http.StatusUnauthorized, response.Code)
+	suite.Contains(response.Body.String(), domain.ErrSessionExpired.Error())
+}
+
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_SetsTwoFactorState() {
+	enrolledUser := *suite.mockUser
+	enrolledUser.TwoFactorEnabled = true
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(&enrolledUser, nil).Once()
+
+	var twoFactor interface{}
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		claims, _ := c.Get("claims")
+		twoFactor = claims.(jwt.MapClaims)["2fa"]
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serveWithToken()
+
+	suite.Equal(http.StatusOK, response.Code)
+	suite.Equal(true, twoFactor)
+}
+
+// serveWithAccessToken makes a request to the '/test' route authenticated with the given personal access token
+func (suite *AuthMiddlewareSuite) serveWithAccessToken(token string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response := httptest.NewRecorder()
+	suite.router.ServeHTTP(response, request)
+
+	return response
+}
+
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_PersonalAccessToken() {
+	token := &domain.PersonalAccessToken{UserID: suite.mockUser.UserID, Scopes: []string{domain.PermissionTasksRead}}
+	suite.mockTokenUsecase.On("Authenticate", mock.Anything, "tmpat_secret").Return(token, nil).Once()
+
+	// access tokens are not sessions, so invalidating the sessions of the user doesn't revoke them
+	resetUser := *suite.mockUser
+	resetUser.TokenVersion = 3
+	suite.mockUserUsecase.On("GetByID", mock.Anything, suite.mockUser.UserID.Hex()).Return(&resetUser, nil).Once()
+
+	var claims jwt.MapClaims
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		value, _ := c.Get("claims")
+		claims = value.(jwt.MapClaims)
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serveWithAccessToken("tmpat_secret")
+
+	suite.Equal(http.StatusOK, response.Code)
+	suite.Equal(suite.mockUser.UserID.Hex(), claims["id"])
+	suite.Equal("USER", claims["role"])
+	suite.Equal([]string{domain.PermissionTasksRead}, claims["scopes"])
+}
+
+func (suite *AuthMiddlewareSuite) TestJWTAuthMiddleware_InvalidPersonalAccessToken() {
+	suite.mockTokenUsecase.On("Authenticate", mock.Anything, "tmpat_revoked").Return(nil, domain.ErrInvalidToken).Once()
+
+	suite.router.Use(JWTAuthMiddleware(suite.secret, suite.mockUserUsecase, suite.mockTokenUsecase))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serveWithAccessToken("tmpat_revoked")
+
+	suite.Equal(http.StatusUnauthorized, response.Code)
+	suite.Contains(response.Body.String(), "access token is invalid or has expired")
+}
+
+func (suite *AuthMiddlewareSuite) TestDenyPersonalAccessTokens() {
+	serve := func(claims jwt.MapClaims) int {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Set("claims", claims)
+
+		DenyPersonalAccessTokens()(c)
+
+		return recorder.Code
+	}
+
+	suite.Equal(http.StatusOK, serve(jwt.MapClaims{"id": "id", "role": "USER"}))
+	suite.Equal(http.StatusForbidden, serve(jwt.MapClaims{"id": "id", "role": "USER", "scopes": []string{"*"}}))
+}
+
 func TestAuthMiddlewareSuite(t *testing.T) {
 	suite.Run(t, new(AuthMiddlewareSuite))
 }
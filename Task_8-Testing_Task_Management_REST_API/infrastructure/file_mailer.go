@@ -0,0 +1,44 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileMailer is a domain.Mailer for local use that writes the messages to a file, or to the standard output,
+// instead of delivering them.
+type FileMailer struct {
+	from   string
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewFileMailer creates a mailer appending the messages to the file at path.
+// An empty path or "-" writes the messages to the standard output.
+func NewFileMailer(path string, from string) (*FileMailer, error) {
+	if path == "" || path == "-" {
+		return &FileMailer{from: from, writer: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileMailer{from: from, writer: file}, nil
+}
+
+// Send writes the message with its headers, followed by a blank line.
+func (mailer *FileMailer) Send(c context.Context, message domain.Message) error {
+	mailer.mu.Lock()
+	defer mailer.mu.Unlock()
+
+	_, err := fmt.Fprintf(mailer.writer, "Date: %v\nFrom: %v\nTo: %v\nSubject: %v\n\n%v\n\n",
+		time.Now().UTC().Format(time.RFC1123Z), mailer.from, message.To, message.Subject, message.Body)
+	return err
+}
@@ -0,0 +1,42 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileMailerSuite struct {
+	suite.Suite
+	path   string
+	mailer *FileMailer
+}
+
+func (suite *FileMailerSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "mail.log")
+
+	mailer, err := NewFileMailer(suite.path, "no-reply@example.com")
+	suite.Require().NoError(err)
+	suite.mailer = mailer
+}
+
+func (suite *FileMailerSuite) TestSendAppendsMessages() {
+	err := suite.mailer.Send(context.Background(), domain.Message{To: "first@example.com", Subject: "First", Body: "first body"})
+	suite.NoError(err)
+	err = suite.mailer.Send(context.Background(), domain.Message{To: "second@example.com", Subject: "Second", Body: "second body"})
+	suite.NoError(err)
+
+	content, err := os.ReadFile(suite.path)
+	suite.Require().NoError(err)
+	suite.Contains(string(content), "From: no-reply@example.com")
+	suite.Contains(string(content), "To: first@example.com\nSubject: First\n\nfirst body")
+	suite.Contains(string(content), "To: second@example.com\nSubject: Second\n\nsecond body")
+}
+
+func TestFileMailerSuite(t *testing.T) {
+	suite.Run(t, new(FileMailerSuite))
+}
@@ -17,9 +17,10 @@ func CreateAccessToken(user *domain.User, secret string, expiry int) (accessToke
 	exp := time.Now().Add(time.Hour * time.Duration(expiry)).Unix()
 
 	claims := &domain.JWTCustomClaims{
-		Name: user.Name,
-		ID:   user.UserID.Hex(),
-		Role: user.Role,
+		Name:    user.Name,
+		ID:      user.UserID.Hex(),
+		Role:    user.Role,
+		Version: user.TokenVersion,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: exp,
 		},
@@ -100,3 +101,42 @@ func GetUserRoleFromContext(context *gin.Context) (string, error) {
 
 	return user_role, nil
 }
+
+// GetUserIDFromContext retrieves the ID of the authenticated user from the provided Gin context.
+// It expects the context to contain the "claims" set by JWTAuthMiddleware.
+// An error is returned if the claims are missing or do not carry a string "id".
+func GetUserIDFromContext(context *gin.Context) (string, error) {
+	claimsValue, exists := context.Get("claims")
+	if !exists {
+		return "", errors.New("no claims found")
+	}
+
+	claims, ok := claimsValue.(jwt.MapClaims)
+	if !ok {
+		return "", errors.New("claims are not valid")
+	}
+
+	user_id, ok := claims["id"].(string)
+	if !ok {
+		return "", errors.New("no user_id found in claims")
+	}
+
+	return user_id, nil
+}
+
+// GetScopesFromContext retrieves the scopes of the personal access token the request was authenticated with.
+// The boolean is false for requests authenticated otherwise, whose permissions are not limited by scopes.
+func GetScopesFromContext(context *gin.Context) ([]string, bool) {
+	claimsValue, exists := context.Get("claims")
+	if !exists {
+		return nil, false
+	}
+
+	claims, ok := claimsValue.(jwt.MapClaims)
+	if !ok {
+		return nil, false
+	}
+
+	scopes, ok := claims["scopes"].([]string)
+	return scopes, ok
+}
@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlobStorage is a domain.BlobStorage that keeps blobs as files under a root directory.
+// Blobs are spread over sub-directories named after the first two characters of their key.
+type LocalBlobStorage struct {
+	root string
+}
+
+func NewLocalBlobStorage(root string) (*LocalBlobStorage, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalBlobStorage{root: root}, nil
+}
+
+// Put writes the content under the given key.
+// The content is written to a temporary file first and moved into place once complete,
+// so that readers never observe a partially written blob.
+func (storage *LocalBlobStorage) Put(c context.Context, key string, content io.Reader) error {
+	path, err := storage.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get opens the blob stored under the given key.
+// It returns domain.ErrAttachmentNotFound if there is no such blob.
+func (storage *LocalBlobStorage) Get(c context.Context, key string) (io.ReadCloser, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrAttachmentNotFound
+	}
+
+	return file, err
+}
+
+// Exists reports whether a blob is stored under the given key.
+func (storage *LocalBlobStorage) Exists(c context.Context, key string) (bool, error) {
+	path, err := storage.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// Delete removes the blob stored under the given key. Deleting a missing blob is not an error.
+func (storage *LocalBlobStorage) Delete(c context.Context, key string) error {
+	path, err := storage.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// path maps a key to a file path inside the root directory, rejecting keys that could escape it.
+func (storage *LocalBlobStorage) path(key string) (string, error) {
+	if len(key) < 3 || strings.ContainsAny(key, `/\.`) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return filepath.Join(storage.root, key[:2], key), nil
+}
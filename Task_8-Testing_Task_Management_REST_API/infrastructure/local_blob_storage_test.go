@@ -0,0 +1,65 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalBlobStorageSuite struct {
+	suite.Suite
+	storage *LocalBlobStorage
+}
+
+func (suite *LocalBlobStorageSuite) SetupTest() {
+	storage, err := NewLocalBlobStorage(suite.T().TempDir())
+	suite.Require().NoError(err)
+	suite.storage = storage
+}
+
+func (suite *LocalBlobStorageSuite) TestPutGetDelete() {
+	ctx := context.Background()
+
+	err := suite.storage.Put(ctx, "abcdef", strings.NewReader("content"))
+	suite.NoError(err)
+
+	exists, err := suite.storage.Exists(ctx, "abcdef")
+	suite.NoError(err)
+	suite.True(exists)
+
+	reader, err := suite.storage.Get(ctx, "abcdef")
+	suite.Require().NoError(err)
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+	suite.Equal("content", string(content))
+
+	err = suite.storage.Delete(ctx, "abcdef")
+	suite.NoError(err)
+
+	exists, err = suite.storage.Exists(ctx, "abcdef")
+	suite.NoError(err)
+	suite.False(exists)
+}
+
+func (suite *LocalBlobStorageSuite) TestGet_Missing() {
+	_, err := suite.storage.Get(context.Background(), "abcdef")
+	suite.ErrorIs(err, domain.ErrAttachmentNotFound)
+}
+
+func (suite *LocalBlobStorageSuite) TestDelete_Missing() {
+	err := suite.storage.Delete(context.Background(), "abcdef")
+	suite.NoError(err)
+}
+
+func (suite *LocalBlobStorageSuite) TestRejectsKeysEscapingRoot() {
+	err := suite.storage.Put(context.Background(), "../../etc/passwd", strings.NewReader("content"))
+	suite.Error(err)
+}
+
+func TestLocalBlobStorageSuite(t *testing.T) {
+	suite.Run(t, new(LocalBlobStorageSuite))
+}
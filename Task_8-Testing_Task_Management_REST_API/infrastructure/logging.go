@@ -0,0 +1,48 @@
+package infrastructure
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// loggerKey is the key of the request-scoped logger in the context of a request.
+type loggerKey struct{}
+
+// ContextWithLogger returns a copy of the context carrying the logger.
+func ContextWithLogger(c context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(c, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger of the request the context belongs to, which adds the
+// ID of the request to every record, or the default logger outside of a request.
+func LoggerFromContext(c context.Context) *slog.Logger {
+	if logger, ok := c.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// redacted replaces the values of the attributes that may hold secrets.
+const redacted = "[REDACTED]"
+
+// secretKeys are the parts of the attribute keys whose values are never logged.
+var secretKeys = []string{"password", "secret", "token", "authorization", "cookie", "api_key"}
+
+// RedactSecrets is meant as the ReplaceAttr function of the log handlers. It hides the values of the
+// attributes whose key mentions a password, a secret or a token, such as "password" or "access_token",
+// and of the two-factor codes, whatever the level and the group they are logged with.
+func RedactSecrets(groups []string, attr slog.Attr) slog.Attr {
+	key := strings.ToLower(attr.Key)
+	if key == "code" || key == "recovery_codes" {
+		return slog.String(attr.Key, redacted)
+	}
+	for _, secret := range secretKeys {
+		if strings.Contains(key, secret) {
+			return slog.String(attr.Key, redacted)
+		}
+	}
+
+	return attr
+}
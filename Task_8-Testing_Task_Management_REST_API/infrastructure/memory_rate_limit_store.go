@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"sync"
+	"time"
+)
+
+// how often full buckets are dropped, so that clients seen once don't stay in memory
+const rateLimitSweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     domain.RateLimit
+}
+
+// MemoryRateLimitStore keeps token buckets in the memory of the process.
+// Every replica of the API counts requests on its own, so it only suits a single instance.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Take takes a token from the bucket with the given key, which starts full.
+func (store *MemoryRateLimitStore) Take(c context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitResult, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.sweep(now)
+
+	tokens := float64(limit.Requests)
+	if current, ok := store.buckets[key]; ok {
+		tokens = limit.Refill(current.tokens, now.Sub(current.updatedAt))
+	}
+
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	store.buckets[key] = &bucket{tokens: tokens, updatedAt: now, limit: limit}
+
+	return limit.Result(tokens, allowed), nil
+}
+
+// sweep drops the buckets that have refilled completely, since they are the same as missing ones.
+func (store *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	store.lastSweep = now
+
+	for key, current := range store.buckets {
+		if current.limit.Refill(current.tokens, now.Sub(current.updatedAt)) >= float64(current.limit.Requests) {
+			delete(store.buckets, key)
+		}
+	}
+}
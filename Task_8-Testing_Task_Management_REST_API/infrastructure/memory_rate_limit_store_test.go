@@ -0,0 +1,73 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryRateLimitStoreSuite struct {
+	suite.Suite
+	store *MemoryRateLimitStore
+	limit domain.RateLimit
+	now   time.Time
+}
+
+func (suite *MemoryRateLimitStoreSuite) SetupTest() {
+	suite.store = NewMemoryRateLimitStore()
+	suite.limit = domain.RateLimit{Requests: 3, Period: time.Minute}
+	suite.now = time.Now()
+}
+
+func (suite *MemoryRateLimitStoreSuite) TestTake_AllowsBurstThenRejects() {
+	for remaining := 2; remaining >= 0; remaining-- {
+		result, err := suite.store.Take(context.Background(), "client", suite.limit, suite.now)
+		suite.NoError(err)
+		suite.True(result.Allowed)
+		suite.Equal(remaining, result.Remaining)
+	}
+
+	result, err := suite.store.Take(context.Background(), "client", suite.limit, suite.now)
+	suite.NoError(err)
+	suite.False(result.Allowed)
+	suite.Equal(3, result.Limit)
+	suite.Equal(time.Second*20, result.RetryAfter)
+	suite.Equal(time.Minute, result.Reset)
+}
+
+func (suite *MemoryRateLimitStoreSuite) TestTake_Refills() {
+	for i := 0; i < 3; i++ {
+		suite.store.Take(context.Background(), "client", suite.limit, suite.now)
+	}
+
+	// one token is back after a third of the period
+	result, err := suite.store.Take(context.Background(), "client", suite.limit, suite.now.Add(time.Second*20))
+	suite.NoError(err)
+	suite.True(result.Allowed)
+	suite.Equal(0, result.Remaining)
+}
+
+func (suite *MemoryRateLimitStoreSuite) TestTake_SeparateKeys() {
+	for i := 0; i < 3; i++ {
+		suite.store.Take(context.Background(), "client", suite.limit, suite.now)
+	}
+
+	result, err := suite.store.Take(context.Background(), "another client", suite.limit, suite.now)
+	suite.NoError(err)
+	suite.True(result.Allowed)
+}
+
+func (suite *MemoryRateLimitStoreSuite) TestSweepDropsFullBuckets() {
+	suite.store.Take(context.Background(), "client", suite.limit, suite.now)
+	suite.store.Take(context.Background(), "another client", suite.limit, suite.now.Add(time.Minute*2))
+
+	suite.Len(suite.store.buckets, 1)
+	suite.Contains(suite.store.buckets, "another client")
+}
+
+func TestMemoryRateLimitStoreSuite(t *testing.T) {
+	suite.Run(t, new(MemoryRateLimitStoreSuite))
+}
@@ -0,0 +1,170 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "taskmanager"
+
+// Metrics collects the Prometheus metrics of the API: the requests served, the operations of the
+// repositories, and gauges of the data, such as the number of tasks by status, computed when scraped.
+type Metrics struct {
+	registry                  *prometheus.Registry
+	requests                  *prometheus.CounterVec
+	requestDuration           *prometheus.HistogramVec
+	repositoryOperations      *prometheus.CounterVec
+	repositoryErrors          *prometheus.CounterVec
+	repositoryOperationLength *prometheus.HistogramVec
+}
+
+func NewMetrics(taskRepository domain.TaskRepository, timeout time.Duration) *Metrics {
+	metrics := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "The HTTP requests served, by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "How long the HTTP requests took to serve, by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		repositoryOperations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "repository_operations_total",
+			Help:      "The operations of the repositories, by collection and operation.",
+		}, []string{"collection", "operation"}),
+		repositoryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "repository_operation_errors_total",
+			Help:      "The operations of the repositories that failed, by collection and operation. Documents not found are not failures.",
+		}, []string{"collection", "operation"}),
+		repositoryOperationLength: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "How long the operations of the repositories took, by collection and operation.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"collection", "operation"}),
+	}
+
+	metrics.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.requests,
+		metrics.requestDuration,
+		metrics.repositoryOperations,
+		metrics.repositoryErrors,
+		metrics.repositoryOperationLength,
+		&taskStatusCollector{
+			taskRepository: taskRepository,
+			timeout:        timeout,
+			desc: prometheus.NewDesc(
+				prometheus.BuildFQName(metricsNamespace, "", "tasks"),
+				"The tasks of every project, by status.",
+				[]string{"status"}, nil,
+			),
+		},
+	)
+
+	return metrics
+}
+
+// Handler serves the metrics in the Prometheus text format. A gauge that can't be computed
+// is left out and reported in the logs, rather than failing the whole scrape.
+func (metrics *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{ErrorLog: slog.NewLogLogger(slog.Default().Handler(), slog.LevelError), ErrorHandling: promhttp.ContinueOnError})
+}
+
+// Middleware records the requests served by their method, the route they matched and their status.
+// Requests matching no route are recorded under the 'unmatched' route, so that clients can't
+// create new series by requesting random paths.
+func (metrics *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := prometheus.Labels{"method": c.Request.Method, "route": route, "status": strconv.Itoa(c.Writer.Status())}
+
+		metrics.requests.With(labels).Inc()
+		metrics.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveRepositoryOperation records an operation of a repository, implementing domain.RepositoryMetrics.
+func (metrics *Metrics) ObserveRepositoryOperation(collection string, operation string, duration time.Duration, err error) {
+	labels := prometheus.Labels{"collection": collection, "operation": operation}
+
+	metrics.repositoryOperations.With(labels).Inc()
+	metrics.repositoryOperationLength.With(labels).Observe(duration.Seconds())
+	if err != nil && !isNotFound(err) {
+		metrics.repositoryErrors.With(labels).Inc()
+	}
+}
+
+// isNotFound reports whether the error only means the document looked for doesn't exist,
+// which is an answer of the database rather than a failure.
+func isNotFound(err error) bool {
+	for _, notFound := range []error{
+		domain.ErrTaskNotFound,
+		domain.ErrUserNotFound,
+		domain.ErrProjectNotFound,
+		domain.ErrCommentNotFound,
+		domain.ErrAttachmentNotFound,
+		domain.ErrTokenNotFound,
+	} {
+		if errors.Is(err, notFound) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// taskStatusCollector counts the tasks by status every time the metrics are scraped.
+type taskStatusCollector struct {
+	taskRepository domain.TaskRepository
+	timeout        time.Duration
+	desc           *prometheus.Desc
+}
+
+func (collector *taskStatusCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- collector.desc
+}
+
+func (collector *taskStatusCollector) Collect(metrics chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collector.timeout)
+	defer cancel()
+
+	counts, err := collector.taskRepository.CountTasksByStatus(ctx)
+	if err != nil {
+		metrics <- prometheus.NewInvalidMetric(collector.desc, err)
+		return
+	}
+
+	// every status is reported, even without tasks, so that the series don't disappear
+	for _, status := range domain.TaskStatuses {
+		if _, ok := counts[status]; !ok {
+			counts[status] = 0
+		}
+	}
+	for status, count := range counts {
+		metrics <- prometheus.MustNewConstMetric(collector.desc, prometheus.GaugeValue, float64(count), status)
+	}
+}
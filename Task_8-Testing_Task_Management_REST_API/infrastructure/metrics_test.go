@@ -0,0 +1,100 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+	taskRepository *mocks.TaskRepository
+	metrics        *Metrics
+	router         *gin.Engine
+}
+
+func (suite *MetricsSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.taskRepository = new(mocks.TaskRepository)
+	suite.metrics = NewMetrics(suite.taskRepository, time.Second)
+
+	suite.router = gin.New()
+	suite.router.Use(suite.metrics.Middleware())
+	suite.router.GET("/tasks/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+	suite.router.GET("/metrics", gin.WrapH(suite.metrics.Handler()))
+}
+
+func (suite *MetricsSuite) TearDownTest() {
+	suite.taskRepository.AssertExpectations(suite.T())
+}
+
+func (suite *MetricsSuite) serve(path string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	recorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (suite *MetricsSuite) TestMiddleware_RecordsRequestsByRoute() {
+	suite.serve("/tasks/1")
+	suite.serve("/tasks/2")
+	suite.serve("/random/path")
+
+	// the requests are recorded by route, not by path
+	suite.Equal(float64(2), testutil.ToFloat64(suite.metrics.requests.WithLabelValues("GET", "/tasks/:id", "404")))
+	suite.Equal(float64(1), testutil.ToFloat64(suite.metrics.requests.WithLabelValues("GET", "unmatched", "404")))
+	suite.Equal(2, testutil.CollectAndCount(suite.metrics.requestDuration))
+}
+
+func (suite *MetricsSuite) TestObserveRepositoryOperation() {
+	suite.metrics.ObserveRepositoryOperation(domain.CollectionTask, "GetTaskByID", time.Millisecond, nil)
+	suite.metrics.ObserveRepositoryOperation(domain.CollectionTask, "GetTaskByID", time.Millisecond, domain.ErrTaskNotFound)
+	suite.metrics.ObserveRepositoryOperation(domain.CollectionTask, "GetTaskByID", time.Millisecond, errors.New("connection refused"))
+
+	suite.Equal(float64(3), testutil.ToFloat64(suite.metrics.repositoryOperations.WithLabelValues(domain.CollectionTask, "GetTaskByID")))
+	// a task not found is not a failure
+	suite.Equal(float64(1), testutil.ToFloat64(suite.metrics.repositoryErrors.WithLabelValues(domain.CollectionTask, "GetTaskByID")))
+	suite.Equal(1, testutil.CollectAndCount(suite.metrics.repositoryOperationLength))
+}
+
+func (suite *MetricsSuite) TestHandler_TasksByStatus() {
+	suite.taskRepository.On("CountTasksByStatus", mock.Anything).Return(map[string]int64{domain.TaskStatusPending: 3, domain.TaskStatusDone: 1}, nil).Once()
+
+	recorder := suite.serve("/metrics")
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	suite.Contains(body, `taskmanager_tasks{status="pending"} 3`)
+	suite.Contains(body, `taskmanager_tasks{status="in_progress"} 0`)
+	suite.Contains(body, `taskmanager_tasks{status="done"} 1`)
+	suite.Contains(body, "go_goroutines")
+}
+
+func (suite *MetricsSuite) TestHandler_TasksByStatusFailure() {
+	suite.taskRepository.On("CountTasksByStatus", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	suite.serve("/tasks/1")
+
+	recorder := suite.serve("/metrics")
+
+	// the other metrics are still served
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.False(strings.Contains(recorder.Body.String(), "taskmanager_tasks{"))
+	suite.Contains(recorder.Body.String(), `taskmanager_http_requests_total{method="GET",route="/tasks/:id",status="404"} 1`)
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
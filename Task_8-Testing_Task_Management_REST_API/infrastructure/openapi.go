@@ -0,0 +1,323 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OpenAPIOperation documents one route of the API. Request and Response are values of the types of the
+// JSON bodies, whose schemas are derived from their 'json' and 'binding' struct tags; a nil Request means
+// the route takes no body. Status is the status of the successful response, 200 when it is not set.
+// Public routes can be called without a token, and Permission names the permission the others require, if any.
+// Deprecated operations are still served, but are going away.
+type OpenAPIOperation struct {
+	Summary     string
+	Deprecated  bool
+	Tag         string
+	Public      bool
+	Permission  string
+	Query       []string
+	Request     any
+	Response    any
+	Status      int
+	ContentType string
+}
+
+// OpenAPIPath converts a gin route path, such as "/tasks/:id", to an OpenAPI path, such as "/tasks/{id}".
+func OpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// NewOpenAPIDocument generates an OpenAPI 3 document describing the registered routes.
+// The operations are looked up by the method and gin path of the routes, such as "GET /tasks/:id",
+// and routes without an operation are left out of the document.
+func NewOpenAPIDocument(title string, version string, routes gin.RoutesInfo, operations map[string]OpenAPIOperation) map[string]any {
+	generator := &openAPIGenerator{schemas: map[string]any{}}
+	paths := map[string]map[string]any{}
+
+	for _, route := range routes {
+		operation, ok := operations[route.Method+" "+route.Path]
+		if !ok {
+			continue
+		}
+
+		path := OpenAPIPath(route.Path)
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(route.Method)] = generator.operation(route.Path, operation)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": title, "version": version},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": generator.schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":         "http",
+					"scheme":       "bearer",
+					"description":  "A JWT token returned by '/login', or a personal access token",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}
+}
+
+type openAPIGenerator struct {
+	schemas map[string]any
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+)
+
+const objectIDPattern = "^[0-9a-fA-F]{24}$"
+
+func (generator *openAPIGenerator) operation(path string, operation OpenAPIOperation) map[string]any {
+	result := map[string]any{"summary": operation.Summary}
+	if operation.Tag != "" {
+		result["tags"] = []string{operation.Tag}
+	}
+	if operation.Deprecated {
+		result["deprecated"] = true
+	}
+
+	var parameters []any
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			parameters = append(parameters, map[string]any{
+				"name": segment[1:], "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+			})
+		}
+	}
+	for _, name := range operation.Query {
+		parameters = append(parameters, map[string]any{
+			"name": name, "in": "query", "schema": map[string]any{"type": "string"},
+		})
+	}
+	if parameters != nil {
+		result["parameters"] = parameters
+	}
+
+	errorResponse := func(description string) map[string]any {
+		return map[string]any{
+			"description": description,
+			"content":     map[string]any{"application/json": map[string]any{"schema": generator.schema(reflect.TypeOf(openAPIError{}), "")}},
+		}
+	}
+
+	responses := map[string]any{}
+	if operation.Request != nil {
+		contentType := operation.ContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		result["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{contentType: map[string]any{"schema": generator.schema(reflect.TypeOf(operation.Request), "")}},
+		}
+		responses["400"] = errorResponse("Malformed request body")
+		responses["422"] = errorResponse("Invalid fields, all listed in 'fields'")
+	}
+
+	if operation.Public {
+		result["security"] = []any{}
+	} else {
+		result["security"] = []any{map[string]any{"bearerAuth": []string{}}}
+		responses["401"] = errorResponse("Missing or invalid token")
+		forbidden := "Not allowed for this user"
+		if operation.Permission != "" {
+			forbidden += ", requires the '" + operation.Permission + "' permission"
+		}
+		responses["403"] = errorResponse(forbidden)
+	}
+	if parameters != nil {
+		responses["404"] = errorResponse("Not found")
+	}
+
+	status := operation.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	if operation.Response != nil {
+		success["content"] = map[string]any{"application/json": map[string]any{"schema": generator.schema(reflect.TypeOf(operation.Response), "")}}
+	}
+	responses[strconv.Itoa(status)] = success
+	result["responses"] = responses
+
+	return result
+}
+
+// openAPIError is the body of the error responses.
+type openAPIError struct {
+	Error  string              `json:"error"`
+	Fields []domain.FieldError `json:"fields,omitempty"`
+}
+
+// schema returns the schema of the type, restricted by the rules of a 'binding' tag.
+// Named structs are added to the components and referred to.
+func (generator *openAPIGenerator) schema(t reflect.Type, binding string) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	rules, itemRules, _ := strings.Cut(binding, ",dive")
+	itemRules = strings.TrimPrefix(itemRules, ",")
+
+	var schema map[string]any
+	switch {
+	case t == timeType:
+		schema = map[string]any{"type": "string", "format": "date-time"}
+	case t == objectIDType:
+		schema = map[string]any{"type": "string", "pattern": objectIDPattern}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		name := componentName(t)
+		if _, ok := generator.schemas[name]; !ok {
+			// registered before the fields, so that recursive types refer to themselves
+			generator.schemas[name] = map[string]any{}
+			generator.schemas[name] = generator.object(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	case t.Kind() == reflect.Struct:
+		schema = generator.object(t)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			schema = map[string]any{"type": "string", "format": "binary"}
+			break
+		}
+		schema = map[string]any{"type": "array", "items": generator.schema(t.Elem(), itemRules)}
+	case t.Kind() == reflect.Map:
+		schema = map[string]any{"type": "object", "additionalProperties": generator.schema(t.Elem(), itemRules)}
+	case t.Kind() == reflect.String:
+		schema = map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		schema = map[string]any{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		schema = map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		schema = map[string]any{"type": "number"}
+	default:
+		schema = map[string]any{}
+	}
+
+	applyBindingRules(schema, rules)
+	return schema
+}
+
+// object returns the schema of the JSON object a struct is encoded to.
+func (generator *openAPIGenerator) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+				addFields(field.Type)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			binding := field.Tag.Get("binding")
+			properties[name] = generator.schema(field.Type, binding)
+			if hasRule(strings.Split(binding, ",dive")[0], "required") {
+				required = append(required, name)
+			}
+		}
+	}
+	addFields(t)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if required != nil {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// applyBindingRules translates the validation rules of a 'binding' tag to the schema.
+func applyBindingRules(schema map[string]any, rules string) {
+	if _, ok := schema["$ref"]; ok || rules == "" {
+		return
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "min", "max":
+			limit, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+
+			key := map[string]string{"string": "Length", "array": "Items", "object": "Properties"}[schemaType(schema)]
+			if key == "" {
+				key = map[string]string{"min": "minimum", "max": "maximum"}[name]
+			} else {
+				key = name + key
+			}
+			schema[key] = limit
+		case "email":
+			schema["format"] = "email"
+		case "objectid":
+			schema["pattern"] = objectIDPattern
+		case "oneof":
+			schema["enum"] = strings.Fields(param)
+		case "task_status":
+			schema["enum"] = domain.TaskStatuses
+		}
+	}
+}
+
+func schemaType(schema map[string]any) string {
+	schemaType, _ := schema["type"].(string)
+	return schemaType
+}
+
+func hasRule(rules string, rule string) bool {
+	for _, r := range strings.Split(rules, ",") {
+		if r == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// componentName names the schema of a struct in the components, starting with a capital letter.
+func componentName(t reflect.Type) string {
+	name := []rune(t.Name())
+	name[0] = unicode.ToUpper(name[0])
+
+	return string(name)
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAPISuite struct {
+	suite.Suite
+}
+
+type openAPITestRequest struct {
+	Name      string    `json:"name" binding:"required,max=100"`
+	Status    string    `json:"status" binding:"omitempty,task_status"`
+	Role      string    `json:"role" binding:"oneof=viewer owner"`
+	Assignees []string  `json:"assignees" binding:"omitempty,min=1,dive,objectid"`
+	DueDate   time.Time `json:"duedate"`
+	Secret    string    `json:"-"`
+}
+
+func (suite *OpenAPISuite) TestOpenAPIPath() {
+	suite.Equal("/projects/{pid}/tasks/{id}", OpenAPIPath("/projects/:pid/tasks/:id"))
+	suite.Equal("/files/{filepath}", OpenAPIPath("/files/*filepath"))
+	suite.Equal("/tasks", OpenAPIPath("/tasks"))
+}
+
+func (suite *OpenAPISuite) TestNewOpenAPIDocument_Operations() {
+	routes := gin.RoutesInfo{
+		{Method: http.MethodPost, Path: "/items/:id"},
+		{Method: http.MethodGet, Path: "/undocumented"},
+	}
+	operations := map[string]OpenAPIOperation{
+		"POST /items/:id": {Summary: "Create an item", Request: openAPITestRequest{}, Status: http.StatusCreated},
+		"GET /health":     {Summary: "Not registered", Public: true},
+	}
+
+	document := NewOpenAPIDocument("Test", "1.0.0", routes, operations)
+	paths := document["paths"].(map[string]map[string]any)
+
+	// only the documented routes that are registered are part of the document
+	suite.Len(paths, 1)
+
+	operation := paths["/items/{id}"]["post"].(map[string]any)
+	suite.Equal("Create an item", operation["summary"])
+	suite.Equal([]any{map[string]any{"bearerAuth": []string{}}}, operation["security"])
+
+	responses := operation["responses"].(map[string]any)
+	suite.Contains(responses, "201")
+	suite.Contains(responses, "401")
+	suite.Contains(responses, "422")
+}
+
+func (suite *OpenAPISuite) TestNewOpenAPIDocument_RequestSchema() {
+	routes := gin.RoutesInfo{{Method: http.MethodPost, Path: "/items"}}
+	operations := map[string]OpenAPIOperation{"POST /items": {Summary: "Create an item", Request: openAPITestRequest{}}}
+
+	document := NewOpenAPIDocument("Test", "1.0.0", routes, operations)
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+
+	schema := schemas["OpenAPITestRequest"].(map[string]any)
+	suite.Equal([]string{"name"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	suite.NotContains(properties, "Secret")
+	suite.Equal(map[string]any{"type": "string", "maxLength": 100}, properties["name"])
+	suite.Equal(map[string]any{"type": "string", "enum": []string{"pending", "in_progress", "done"}}, properties["status"])
+	suite.Equal(map[string]any{"type": "string", "enum": []string{"viewer", "owner"}}, properties["role"])
+	suite.Equal(map[string]any{
+		"type":     "array",
+		"minItems": 1,
+		"items":    map[string]any{"type": "string", "pattern": objectIDPattern},
+	}, properties["assignees"])
+	suite.Equal(map[string]any{"type": "string", "format": "date-time"}, properties["duedate"])
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPISuite))
+}
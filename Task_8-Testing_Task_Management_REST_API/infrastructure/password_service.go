@@ -1,17 +1,190 @@
 package infrastructure
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
 
-// HashPassword hashes the given password using bcrypt algorithm.
-// It returns the hashed password as a byte slice and any error encountered during the hashing process.
-func HashPassword(password string) ([]byte, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return hashedPassword, err
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// the names of the supported password hashing algorithms
+const (
+	PasswordAlgorithmArgon2id = "argon2id"
+	PasswordAlgorithmBcrypt   = "bcrypt"
+)
+
+var errUnknownPasswordHash = errors.New("unknown password hash format")
+
+// Argon2idParams tunes argon2id: Memory is in KiB, and Iterations and Parallelism are the number of passes
+// and of threads. SaltLength and KeyLength are in bytes.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2idParams are the parameters recommended by RFC 9106 for memory-constrained environments.
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// passwordHasher hashes new passwords with the preferred algorithm, and verifies hashes of every supported algorithm.
+type passwordHasher struct {
+	algorithm  string
+	argon2id   Argon2idParams
+	bcryptCost int
+
+	dummyHash     string
+	dummyHashOnce sync.Once
+}
+
+// NewArgon2idHasher creates a password hasher hashing with argon2id, in the PHC string format
+// '$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>'. It still verifies bcrypt hashes, which then need a rehash.
+func NewArgon2idHasher(params Argon2idParams) domain.PasswordHasher {
+	return &passwordHasher{algorithm: PasswordAlgorithmArgon2id, argon2id: params}
+}
+
+// NewBcryptHasher creates a password hasher hashing with bcrypt at the given cost.
+// It still verifies argon2id hashes, which then need a rehash.
+func NewBcryptHasher(cost int) domain.PasswordHasher {
+	return &passwordHasher{algorithm: PasswordAlgorithmBcrypt, bcryptCost: cost}
+}
+
+// Hash hashes the password with the preferred algorithm and a random salt.
+func (hasher *passwordHasher) Hash(password string) (string, error) {
+	if hasher.algorithm == PasswordAlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), hasher.bcryptCost)
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", fmt.Errorf("%w: password must be at most 72 bytes long", domain.ErrInvalidInput)
+		}
+
+		return string(hash), err
+	}
+
+	params := hasher.argon2id
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify reports whether the password matches the encoded hash, which may be an argon2id or a bcrypt hash.
+// It returns an error if the hash is not in a known format.
+func (hasher *passwordHasher) Verify(password string, encodedHash string) (bool, error) {
+	if strings.HasPrefix(encodedHash, "$argon2id$") {
+		params, salt, key, err := decodeArgon2idHash(encodedHash)
+		if err != nil {
+			return false, err
+		}
+
+		computed := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+		return subtle.ConstantTimeCompare(computed, key) == 1, nil
+	}
+
+	if _, err := bcrypt.Cost([]byte(encodedHash)); err == nil {
+		err = bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		return err == nil, err
+	}
+
+	return false, errUnknownPasswordHash
+}
+
+// NeedsRehash reports whether the encoded hash was made with another algorithm or other parameters than Hash uses.
+func (hasher *passwordHasher) NeedsRehash(encodedHash string) bool {
+	if hasher.algorithm == PasswordAlgorithmBcrypt {
+		cost, err := bcrypt.Cost([]byte(encodedHash))
+		return err != nil || cost != hasher.bcryptCost
+	}
+
+	params, _, _, err := decodeArgon2idHash(encodedHash)
+	return err != nil || params != hasher.argon2id
+}
+
+// SimulateVerify takes as long as a failed Verify, without any password to compare against.
+// It is used when no user matches a login, so that response times don't reveal which emails are registered.
+func (hasher *passwordHasher) SimulateVerify(password string) {
+	hasher.dummyHashOnce.Do(func() {
+		hasher.dummyHash, _ = hasher.Hash("not the password of anyone")
+	})
+
+	hasher.Verify(password, hasher.dummyHash)
 }
 
-// ValidatePassword compares the current password with the existing password hash.
-// It uses bcrypt.CompareHashAndPassword to perform the comparison.
-// If the passwords match, it returns nil. Otherwise, it returns an error.
-func ValidatePassword(curr_password string, existing_password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(existing_password), []byte(curr_password))
+// decodeArgon2idHash parses an argon2id hash in the PHC string format.
+func decodeArgon2idHash(encodedHash string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errUnknownPasswordHash
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, errUnknownPasswordHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errUnknownPasswordHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errUnknownPasswordHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
+
+// LoadPasswordList reads a list of passwords, one per line, such as a list of breached passwords.
+// Empty lines are skipped.
+func LoadPasswordList(path string) (map[string]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	passwords := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if password := strings.TrimRight(scanner.Text(), "\r"); password != "" {
+			passwords[password] = true
+		}
+	}
+
+	return passwords, scanner.Err()
 }
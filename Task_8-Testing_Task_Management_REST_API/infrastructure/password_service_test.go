@@ -0,0 +1,111 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordServiceSuite struct {
+	suite.Suite
+	params Argon2idParams
+}
+
+func (suite *PasswordServiceSuite) SetupTest() {
+	// cheap parameters, so that the tests stay fast
+	suite.params = Argon2idParams{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+func (suite *PasswordServiceSuite) TestArgon2id_RoundTrip() {
+	hasher := NewArgon2idHasher(suite.params)
+
+	hash, err := hasher.Hash("password123")
+	suite.NoError(err)
+	suite.True(strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	valid, err := hasher.Verify("password123", hash)
+	suite.NoError(err)
+	suite.True(valid)
+
+	valid, err = hasher.Verify("wrong password", hash)
+	suite.NoError(err)
+	suite.False(valid)
+
+	// the salt is random
+	other, err := hasher.Hash("password123")
+	suite.NoError(err)
+	suite.NotEqual(hash, other)
+}
+
+func (suite *PasswordServiceSuite) TestBcrypt_RoundTrip() {
+	hasher := NewBcryptHasher(4)
+
+	hash, err := hasher.Hash("password123")
+	suite.NoError(err)
+
+	valid, err := hasher.Verify("password123", hash)
+	suite.NoError(err)
+	suite.True(valid)
+
+	valid, err = hasher.Verify("wrong password", hash)
+	suite.NoError(err)
+	suite.False(valid)
+}
+
+func (suite *PasswordServiceSuite) TestVerifiesEveryAlgorithm() {
+	argon2idHasher := NewArgon2idHasher(suite.params)
+	bcryptHasher := NewBcryptHasher(4)
+
+	bcryptHash, _ := bcryptHasher.Hash("password123")
+	valid, err := argon2idHasher.Verify("password123", bcryptHash)
+	suite.NoError(err)
+	suite.True(valid)
+
+	argon2idHash, _ := argon2idHasher.Hash("password123")
+	valid, err = bcryptHasher.Verify("password123", argon2idHash)
+	suite.NoError(err)
+	suite.True(valid)
+}
+
+func (suite *PasswordServiceSuite) TestNeedsRehash() {
+	hasher := NewArgon2idHasher(suite.params)
+
+	current, _ := hasher.Hash("password123")
+	suite.False(hasher.NeedsRehash(current))
+
+	// other parameters or another algorithm
+	stronger := suite.params
+	stronger.Iterations = 2
+	suite.True(NewArgon2idHasher(stronger).NeedsRehash(current))
+
+	bcryptHash, _ := NewBcryptHasher(4).Hash("password123")
+	suite.True(hasher.NeedsRehash(bcryptHash))
+	suite.False(NewBcryptHasher(4).NeedsRehash(bcryptHash))
+	suite.True(NewBcryptHasher(5).NeedsRehash(bcryptHash))
+}
+
+func (suite *PasswordServiceSuite) TestUnknownHash() {
+	hasher := NewArgon2idHasher(suite.params)
+
+	for _, hash := range []string{"plain text", "$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$"} {
+		valid, err := hasher.Verify("password123", hash)
+		suite.Error(err, hash)
+		suite.False(valid)
+	}
+}
+
+func (suite *PasswordServiceSuite) TestLoadPasswordList() {
+	path := filepath.Join(suite.T().TempDir(), "breached.txt")
+	suite.NoError(os.WriteFile(path, []byte("123456\r\npassword\n\nqwerty\n"), 0o600))
+
+	passwords, err := LoadPasswordList(path)
+	suite.NoError(err)
+	suite.Equal(map[string]bool{"123456": true, "password": true, "qwerty": true}, passwords)
+}
+
+func TestPasswordServiceSuite(t *testing.T) {
+	suite.Run(t, new(PasswordServiceSuite))
+}
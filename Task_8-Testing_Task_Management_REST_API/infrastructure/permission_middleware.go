@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission is a middleware function that checks if the role of the user grants every one of the given permissions.
+// It retrieves the user role from the context, so it has to run after JWTAuthMiddleware.
+// Requests made with a personal access token also need the permissions among the scopes of the token.
+// If a permission is missing, it returns a forbidden error naming it.
+func RequirePermission(roles domain.Roles, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// get user role from the context
+		user_role, err := GetUserRoleFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+			c.Abort()
+			return
+		}
+
+		scopes, scoped := GetScopesFromContext(c)
+		for _, permission := range permissions {
+			if !roles.Has(user_role, permission) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "the '" + permission + "' permission is required"})
+				c.Abort()
+				return
+			}
+			if scoped && !domain.ScopesGrant(scopes, permission) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "the '" + permission + "' scope is required"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
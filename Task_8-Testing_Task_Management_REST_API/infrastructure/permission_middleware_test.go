@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type PermissionMiddlewareSuite struct {
+	suite.Suite
+	roles domain.Roles
+}
+
+func (suite *PermissionMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	roles, err := domain.ParseRoles("USER=projects:read; MANAGER=tasks:*,projects:read; ADMIN=*")
+	suite.Require().NoError(err)
+	suite.roles = roles
+}
+
+// serve runs the middleware requiring the given permissions for a user with the given role
+func (suite *PermissionMiddlewareSuite) serve(role string, permissions ...string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Set("claims", jwt.MapClaims{"role": role})
+
+	RequirePermission(suite.roles, permissions...)(c)
+
+	return recorder
+}
+
+func (suite *PermissionMiddlewareSuite) TestGrantedPermission() {
+	recorder := suite.serve("USER", domain.PermissionProjectsRead)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *PermissionMiddlewareSuite) TestMissingPermission() {
+	recorder := suite.serve("USER", domain.PermissionProjectsRead, domain.PermissionTasksDelete)
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Contains(recorder.Body.String(), "'tasks:delete' permission is required")
+}
+
+func (suite *PermissionMiddlewareSuite) TestResourceWildcard() {
+	suite.Equal(http.StatusOK, suite.serve("MANAGER", domain.PermissionTasksDelete).Code)
+	suite.Equal(http.StatusForbidden, suite.serve("MANAGER", domain.PermissionUsersPromote).Code)
+}
+
+func (suite *PermissionMiddlewareSuite) TestWildcard() {
+	recorder := suite.serve("ADMIN", domain.PermissionUsersPromote, domain.PermissionTasksDelete)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *PermissionMiddlewareSuite) TestUnknownRole() {
+	recorder := suite.serve("GUEST", domain.PermissionProjectsRead)
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *PermissionMiddlewareSuite) TestPersonalAccessTokenScopes() {
+	serve := func(scopes []string, permission string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(recorder)
+		c.Set("claims", jwt.MapClaims{"role": "ADMIN", "scopes": scopes})
+
+		RequirePermission(suite.roles, permission)(c)
+
+		return recorder
+	}
+
+	suite.Equal(http.StatusOK, serve([]string{domain.PermissionTasksRead}, domain.PermissionTasksRead).Code)
+	suite.Equal(http.StatusOK, serve([]string{"tasks:*"}, domain.PermissionTasksDelete).Code)
+
+	// the role grants everything, but the token is limited to its scopes
+	recorder := serve([]string{domain.PermissionTasksRead}, domain.PermissionUsersPromote)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Contains(recorder.Body.String(), "'users:promote' scope is required")
+}
+
+func (suite *PermissionMiddlewareSuite) TestMissingClaims() {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+
+	RequirePermission(suite.roles, domain.PermissionProjectsRead)(c)
+
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (suite *PermissionMiddlewareSuite) TestParseRoles_RequiresBuiltInRoles() {
+	_, err := domain.ParseRoles("USER=projects:read")
+	suite.Error(err)
+
+	_, err = domain.ParseRoles("USER=projects:read;USER=*;ADMIN=*")
+	suite.Error(err)
+}
+
+func TestPermissionMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(PermissionMiddlewareSuite))
+}
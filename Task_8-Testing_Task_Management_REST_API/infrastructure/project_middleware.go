@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireProjectRole is a middleware function that checks if the user is a member of the project
+// whose ID is in the 'pid' path parameter, with at least the given role.
+// Users whose role grants the 'projects:manage' permission are treated as owners of every project,
+// unless the request is made with a personal access token lacking the permission among its scopes.
+// The role of the user in the project is set to the context as "project_role".
+func RequireProjectRole(projects domain.ProjectUsecase, roles domain.Roles, minimumRole string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user_role, err := GetUserRoleFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+			c.Abort()
+			return
+		}
+
+		scopes, scoped := GetScopesFromContext(c)
+		manager := roles.Has(user_role, domain.PermissionProjectsManage) &&
+			(!scoped || domain.ScopesGrant(scopes, domain.PermissionProjectsManage))
+
+		projectRole := domain.ProjectRoleOwner
+		if manager {
+			if _, err = projects.GetByID(c, c.Param("pid")); err != nil {
+				abortWithProjectError(c, err)
+				return
+			}
+		} else {
+			user_id, err := GetUserIDFromContext(c)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+				c.Abort()
+				return
+			}
+
+			projectRole, err = projects.GetMemberRole(c, c.Param("pid"), user_id)
+			if err != nil {
+				abortWithProjectError(c, err)
+				return
+			}
+		}
+
+		if domain.ProjectRoleRank(projectRole) < domain.ProjectRoleRank(minimumRole) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "the '" + minimumRole + "' project role is required"})
+			c.Abort()
+			return
+		}
+
+		c.Set("project_role", projectRole)
+		c.Next()
+	}
+}
+
+// abortWithProjectError aborts the request with the response matching an error of the project lookup.
+func abortWithProjectError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrNotMember):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+	c.Abort()
+}
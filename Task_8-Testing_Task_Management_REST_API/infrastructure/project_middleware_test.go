@@ -0,0 +1,117 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProjectMiddlewareSuite struct {
+	suite.Suite
+	mockProjectUsecase *mocks.ProjectUsecase
+	projectID          string
+	userID             string
+	scopes             []string
+}
+
+func (suite *ProjectMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockProjectUsecase = new(mocks.ProjectUsecase)
+	suite.projectID = primitive.NewObjectID().Hex()
+	suite.userID = primitive.NewObjectID().Hex()
+	suite.scopes = nil
+}
+
+func (suite *ProjectMiddlewareSuite) TearDownTest() {
+	suite.mockProjectUsecase.AssertExpectations(suite.T())
+}
+
+// serve runs the middleware requiring the given role for a user with the given global role,
+// authenticated with a personal access token if the suite has scopes
+func (suite *ProjectMiddlewareSuite) serve(role string, minimumRole string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Params = gin.Params{{Key: "pid", Value: suite.projectID}}
+	claims := jwt.MapClaims{"id": suite.userID, "role": role}
+	if suite.scopes != nil {
+		claims["scopes"] = suite.scopes
+	}
+	c.Set("claims", claims)
+
+	RequireProjectRole(suite.mockProjectUsecase, domain.DefaultRoles(), minimumRole)(c)
+
+	return recorder
+}
+
+func (suite *ProjectMiddlewareSuite) TestSufficientRole() {
+	suite.mockProjectUsecase.On("GetMemberRole", mock.Anything, suite.projectID, suite.userID).Return(domain.ProjectRoleMember, nil).Once()
+
+	recorder := suite.serve("USER", domain.ProjectRoleViewer)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *ProjectMiddlewareSuite) TestInsufficientRole() {
+	suite.mockProjectUsecase.On("GetMemberRole", mock.Anything, suite.projectID, suite.userID).Return(domain.ProjectRoleViewer, nil).Once()
+
+	recorder := suite.serve("USER", domain.ProjectRoleMember)
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Contains(recorder.Body.String(), "'member' project role is required")
+}
+
+func (suite *ProjectMiddlewareSuite) TestNotMember() {
+	suite.mockProjectUsecase.On("GetMemberRole", mock.Anything, suite.projectID, suite.userID).Return("", domain.ErrNotMember).Once()
+
+	recorder := suite.serve("USER", domain.ProjectRoleViewer)
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *ProjectMiddlewareSuite) TestProjectNotFound() {
+	suite.mockProjectUsecase.On("GetMemberRole", mock.Anything, suite.projectID, suite.userID).Return("", domain.ErrProjectNotFound).Once()
+
+	recorder := suite.serve("USER", domain.ProjectRoleViewer)
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *ProjectMiddlewareSuite) TestAdminIsOwner() {
+	suite.mockProjectUsecase.On("GetByID", mock.Anything, suite.projectID).Return(&domain.Project{}, nil).Once()
+
+	recorder := suite.serve("ADMIN", domain.ProjectRoleOwner)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.mockProjectUsecase.AssertNotCalled(suite.T(), "GetMemberRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ProjectMiddlewareSuite) TestAdminTokenWithoutManageScope() {
+	suite.scopes = []string{domain.PermissionProjectsRead, domain.PermissionProjectsWrite}
+	suite.mockProjectUsecase.On("GetMemberRole", mock.Anything, suite.projectID, suite.userID).Return(domain.ProjectRoleViewer, nil).Once()
+
+	recorder := suite.serve("ADMIN", domain.ProjectRoleOwner)
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.mockProjectUsecase.AssertNotCalled(suite.T(), "GetByID", mock.Anything, mock.Anything)
+}
+
+func (suite *ProjectMiddlewareSuite) TestAdminTokenWithManageScope() {
+	suite.scopes = []string{"projects:*"}
+	suite.mockProjectUsecase.On("GetByID", mock.Anything, suite.projectID).Return(&domain.Project{}, nil).Once()
+
+	recorder := suite.serve("ADMIN", domain.ProjectRoleOwner)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestProjectMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(ProjectMiddlewareSuite))
+}
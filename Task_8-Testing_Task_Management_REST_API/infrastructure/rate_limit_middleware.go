@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware limits the requests of each client to the routes of a group.
+// Clients are identified by the user ID in the claims set by JWTAuthMiddleware when there is one,
+// and by their IP otherwise; scope separates the buckets of groups sharing a store.
+// Responses carry 'RateLimit-Limit', 'RateLimit-Remaining' and 'RateLimit-Reset' headers,
+// and rejected requests get a 429 status with a 'Retry-After' header.
+// Requests are let through when the store fails, rather than making the API unavailable.
+func RateLimitMiddleware(store domain.RateLimitStore, limit domain.RateLimit, scope string) gin.HandlerFunc {
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Period.Seconds()))
+
+	return func(c *gin.Context) {
+		key := scope + ":ip:" + c.ClientIP()
+		if userID, err := GetUserIDFromContext(c); err == nil {
+			key = scope + ":user:" + userID
+		}
+
+		result, err := store.Take(c, key, limit, time.Now().UTC())
+		if err != nil {
+			LoggerFromContext(c).Warn("rate limit store failed, letting the request through", "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", headerSeconds(result.Reset))
+
+		if !result.Allowed {
+			c.Header("Retry-After", headerSeconds(result.RetryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many requests, try again later"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// headerSeconds formats a duration as the whole number of seconds a client should wait.
+func headerSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitMiddlewareSuite struct {
+	suite.Suite
+	router *gin.Engine
+	limit  domain.RateLimit
+	store  *MemoryRateLimitStore
+}
+
+func (suite *RateLimitMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.router = gin.New()
+	suite.limit = domain.RateLimit{Requests: 2, Period: time.Minute}
+	suite.store = NewMemoryRateLimitStore()
+}
+
+// serve makes a request to the '/test' route from the given IP
+func (suite *RateLimitMiddlewareSuite) serve(ip string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/test", nil)
+	request.RemoteAddr = ip + ":1234"
+
+	response := httptest.NewRecorder()
+	suite.router.ServeHTTP(response, request)
+
+	return response
+}
+
+func (suite *RateLimitMiddlewareSuite) TestLimitsPerIP() {
+	suite.router.Use(RateLimitMiddleware(suite.store, suite.limit, "public"))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serve("10.0.0.1")
+	suite.Equal(http.StatusOK, response.Code)
+	suite.Equal("2", response.Header().Get("RateLimit-Limit"))
+	suite.Equal("1", response.Header().Get("RateLimit-Remaining"))
+	suite.Equal("30", response.Header().Get("RateLimit-Reset"))
+	suite.Equal("2;w=60", response.Header().Get("RateLimit-Policy"))
+
+	suite.Equal(http.StatusOK, suite.serve("10.0.0.1").Code)
+
+	response = suite.serve("10.0.0.1")
+	suite.Equal(http.StatusTooManyRequests, response.Code)
+	suite.Equal("0", response.Header().Get("RateLimit-Remaining"))
+	suite.Equal("30", response.Header().Get("Retry-After"))
+
+	// other clients have their own bucket
+	suite.Equal(http.StatusOK, suite.serve("10.0.0.2").Code)
+}
+
+func (suite *RateLimitMiddlewareSuite) TestLimitsPerUser() {
+	userID := "user id"
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"id": userID})
+	})
+	suite.router.Use(RateLimitMiddleware(suite.store, suite.limit, "protected"))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	suite.Equal(http.StatusOK, suite.serve("10.0.0.1").Code)
+	suite.Equal(http.StatusOK, suite.serve("10.0.0.2").Code)
+
+	// the same user is limited whatever their IP
+	suite.Equal(http.StatusTooManyRequests, suite.serve("10.0.0.3").Code)
+
+	userID = "another user id"
+	suite.Equal(http.StatusOK, suite.serve("10.0.0.3").Code)
+}
+
+func (suite *RateLimitMiddlewareSuite) TestStoreFailureLetsRequestsThrough() {
+	store := new(mocks.RateLimitStore)
+	store.On("Take", mock.Anything, "public:ip:10.0.0.1", suite.limit, mock.AnythingOfType("time.Time")).
+		Return(domain.RateLimitResult{}, errors.New("database is down")).Once()
+
+	suite.router.Use(RateLimitMiddleware(store, suite.limit, "public"))
+	suite.router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	response := suite.serve("10.0.0.1")
+
+	suite.Equal(http.StatusOK, response.Code)
+	suite.Empty(response.Header().Get("RateLimit-Limit"))
+	store.AssertExpectations(suite.T())
+}
+
+func TestRateLimitMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitMiddlewareSuite))
+}
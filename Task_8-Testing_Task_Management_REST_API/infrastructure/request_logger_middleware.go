@@ -0,0 +1,98 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID of a request, given by the client or a proxy in front of
+// the API, or assigned by RequestLoggerMiddleware otherwise.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestLoggerMiddleware makes sure every request has an ID: the one in its 'X-Request-ID' header
+// when it is valid, a new random one otherwise, returned in the same header of the response.
+// It stores a logger adding the ID, and the ID of the trace if the request is traced, in the context
+// of the request, for LoggerFromContext. Once the request is served, it is logged with its route,
+// status and duration and the ID of the authenticated user. Only the path is logged, as the query
+// can hold tokens, such as the one verifying an email.
+func RequestLoggerMiddleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set("request_id", requestID)
+
+		requestLogger := logger.With("request_id", requestID)
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			requestLogger = requestLogger.With("trace_id", span.TraceID().String())
+		}
+		c.Request = c.Request.WithContext(ContextWithLogger(c.Request.Context(), requestLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", c.Request.URL.Path,
+			"status", status,
+			"duration", time.Since(start),
+			"client_ip", c.ClientIP(),
+		}
+		if userID, err := GetUserIDFromContext(c); err == nil {
+			attrs = append(attrs, "user_id", userID)
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "errors", c.Errors.String())
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		requestLogger.Log(c.Request.Context(), level, "request served", attrs...)
+	}
+}
+
+// RecoveryMiddleware answers a request whose handler panicked with a 500 Internal Server Error
+// response, logging the panic and the stack with the logger of the request rather than to the standard error.
+func RecoveryMiddleware() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		LoggerFromContext(c).Error("request handler panicked", "panic", recovered, "stack", string(debug.Stack()))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	})
+}
+
+// validRequestID reports whether an ID given by the client can be trusted in the logs:
+// not empty, not too long and made of printable ASCII characters only.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,142 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestLoggerMiddlewareSuite struct {
+	suite.Suite
+	output *bytes.Buffer
+	router *gin.Engine
+}
+
+func (suite *RequestLoggerMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.output = &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(suite.output, &slog.HandlerOptions{ReplaceAttr: RedactSecrets}))
+
+	suite.router = gin.New()
+	suite.router.ContextWithFallback = true
+	suite.router.Use(RequestLoggerMiddleware(logger), RecoveryMiddleware())
+
+	suite.router.GET("/tasks/:id", func(c *gin.Context) {
+		// simulate the claims set by the authentication middleware
+		c.Set("claims", jwt.MapClaims{"id": "user-1", "role": "USER"})
+		LoggerFromContext(c).Info("handling", "password", "hunter2")
+		c.Status(http.StatusOK)
+	})
+	suite.router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+}
+
+func (suite *RequestLoggerMiddlewareSuite) serve(path string, requestID string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	if requestID != "" {
+		request.Header.Set(RequestIDHeader, requestID)
+	}
+	recorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+// records decodes the JSON records logged so far.
+func (suite *RequestLoggerMiddlewareSuite) records() []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(suite.output.String()), "\n") {
+		var record map[string]any
+		suite.Require().NoError(json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func (suite *RequestLoggerMiddlewareSuite) TestAssignsARequestID() {
+	recorder := suite.serve("/tasks/1?token=secret", "")
+
+	requestID := recorder.Header().Get(RequestIDHeader)
+	suite.Len(requestID, 32)
+
+	records := suite.records()
+	suite.Require().Len(records, 2)
+
+	// the logger of the request adds its ID to the records of the handler
+	suite.Equal("handling", records[0]["msg"])
+	suite.Equal(requestID, records[0]["request_id"])
+	suite.Equal("[REDACTED]", records[0]["password"])
+
+	suite.Equal("request served", records[1]["msg"])
+	suite.Equal(requestID, records[1]["request_id"])
+	suite.Equal("/tasks/:id", records[1]["route"])
+	suite.Equal("/tasks/1", records[1]["path"])
+	suite.Equal(float64(http.StatusOK), records[1]["status"])
+	suite.Equal("user-1", records[1]["user_id"])
+	suite.NotContains(suite.output.String(), "secret")
+}
+
+func (suite *RequestLoggerMiddlewareSuite) TestPropagatesTheRequestIDOfTheClient() {
+	recorder := suite.serve("/tasks/1", "abc-123")
+
+	suite.Equal("abc-123", recorder.Header().Get(RequestIDHeader))
+	suite.Equal("abc-123", suite.records()[1]["request_id"])
+}
+
+func (suite *RequestLoggerMiddlewareSuite) TestReplacesAnInvalidRequestID() {
+	recorder := suite.serve("/tasks/1", "forged\tid")
+
+	suite.NotEqual("forged\tid", recorder.Header().Get(RequestIDHeader))
+	suite.Len(recorder.Header().Get(RequestIDHeader), 32)
+}
+
+func (suite *RequestLoggerMiddlewareSuite) TestPanicIsLoggedAsAnError() {
+	recorder := suite.serve("/panic", "abc-123")
+
+	suite.Equal(http.StatusInternalServerError, recorder.Code)
+	records := suite.records()
+	suite.Require().Len(records, 2)
+	suite.Equal("request handler panicked", records[0]["msg"])
+	suite.Equal("boom", records[0]["panic"])
+	suite.Equal("abc-123", records[0]["request_id"])
+	suite.Equal("ERROR", records[1]["level"])
+	suite.Equal(float64(http.StatusInternalServerError), records[1]["status"])
+	suite.Nil(records[1]["user_id"])
+}
+
+func (suite *RequestLoggerMiddlewareSuite) TestRedactSecrets() {
+	logger := slog.New(slog.NewJSONHandler(suite.output, &slog.HandlerOptions{ReplaceAttr: RedactSecrets}))
+
+	logger.Info("login",
+		"email", "user@example.com",
+		"Authorization", "Bearer abc",
+		"code", "123456",
+		slog.Group("user", "access_token", "abc", "name", "user"),
+	)
+
+	record := suite.records()[0]
+	suite.Equal("user@example.com", record["email"])
+	suite.Equal("[REDACTED]", record["Authorization"])
+	suite.Equal("[REDACTED]", record["code"])
+	suite.Equal(map[string]any{"access_token": "[REDACTED]", "name": "user"}, record["user"])
+}
+
+func (suite *RequestLoggerMiddlewareSuite) TestLoggerFromContext_Default() {
+	request, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+	suite.Equal(slog.Default(), LoggerFromContext(request.Context()))
+}
+
+func TestRequestLoggerMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(RequestLoggerMiddlewareSuite))
+}
@@ -0,0 +1,100 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// the purpose is mixed into the signature, so that a token signed for one purpose
+// is never accepted for another one, even though they share the secret
+const (
+	emailVerificationPurpose = "email-verification"
+	loginChallengePurpose    = "login-challenge"
+)
+
+type signedPayload struct {
+	Fields    []string `json:"f"`
+	ExpiresAt int64    `json:"exp"`
+}
+
+// CreateEmailVerificationToken signs a token proving that the user with the given ID owns the email address.
+// The token stops being valid at expiresAt, or as soon as the email of the user changes.
+func CreateEmailVerificationToken(userID string, email string, expiresAt time.Time, secret string) string {
+	return createSignedToken(emailVerificationPurpose, []string{userID, email}, expiresAt, secret)
+}
+
+// ParseEmailVerificationToken checks the signature and the expiry of a token created by CreateEmailVerificationToken
+// and returns the user ID and the email it was issued for. It returns domain.ErrInvalidToken if the token is not valid at now.
+func ParseEmailVerificationToken(token string, secret string, now time.Time) (string, string, error) {
+	fields, err := parseSignedToken(emailVerificationPurpose, token, secret, now, 2)
+	if err != nil {
+		return "", "", err
+	}
+
+	return fields[0], fields[1], nil
+}
+
+// CreateLoginChallengeToken signs a token proving that the user with the given ID has entered their password,
+// to be exchanged for an access token together with a second factor before expiresAt.
+func CreateLoginChallengeToken(userID string, expiresAt time.Time, secret string) string {
+	return createSignedToken(loginChallengePurpose, []string{userID}, expiresAt, secret)
+}
+
+// ParseLoginChallengeToken checks the signature and the expiry of a token created by CreateLoginChallengeToken
+// and returns the user ID it was issued for. It returns domain.ErrInvalidToken if the token is not valid at now.
+func ParseLoginChallengeToken(token string, secret string, now time.Time) (string, error) {
+	fields, err := parseSignedToken(loginChallengePurpose, token, secret, now, 1)
+	if err != nil {
+		return "", err
+	}
+
+	return fields[0], nil
+}
+
+func createSignedToken(purpose string, fields []string, expiresAt time.Time, secret string) string {
+	payload, _ := json.Marshal(signedPayload{Fields: fields, ExpiresAt: expiresAt.Unix()})
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encodedPayload + "." + signPayload(purpose, encodedPayload, secret)
+}
+
+// parseSignedToken returns the fields of a token signed for the purpose,
+// which must carry exactly fieldCount fields and not be expired at now.
+func parseSignedToken(purpose string, token string, secret string, now time.Time, fieldCount int) ([]string, error) {
+	encodedPayload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(signPayload(purpose, encodedPayload, secret))) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	rawPayload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+
+	var payload signedPayload
+	if err = json.Unmarshal(rawPayload, &payload); err != nil || len(payload.Fields) != fieldCount {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if !now.Before(time.Unix(payload.ExpiresAt, 0)) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return payload.Fields, nil
+}
+
+func signPayload(purpose string, encodedPayload string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose + "." + encodedPayload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SignedTokenSuite struct {
+	suite.Suite
+	secret string
+	now    time.Time
+}
+
+func (suite *SignedTokenSuite) SetupTest() {
+	suite.secret = "this is a test secret"
+	suite.now = time.Now()
+}
+
+func (suite *SignedTokenSuite) TestRoundTrip() {
+	token := CreateEmailVerificationToken("user id", "test@example.com", suite.now.Add(time.Hour), suite.secret)
+
+	userID, email, err := ParseEmailVerificationToken(token, suite.secret, suite.now)
+
+	suite.NoError(err)
+	suite.Equal("user id", userID)
+	suite.Equal("test@example.com", email)
+}
+
+func (suite *SignedTokenSuite) TestExpired() {
+	token := CreateEmailVerificationToken("user id", "test@example.com", suite.now.Add(time.Hour), suite.secret)
+
+	_, _, err := ParseEmailVerificationToken(token, suite.secret, suite.now.Add(2*time.Hour))
+
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func (suite *SignedTokenSuite) TestWrongSecret() {
+	token := CreateEmailVerificationToken("user id", "test@example.com", suite.now.Add(time.Hour), suite.secret)
+
+	_, _, err := ParseEmailVerificationToken(token, "another secret", suite.now)
+
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func (suite *SignedTokenSuite) TestTamperedPayload() {
+	token := CreateEmailVerificationToken("user id", "test@example.com", suite.now.Add(time.Hour), suite.secret)
+	forged := CreateEmailVerificationToken("user id", "attacker@example.com", suite.now.Add(time.Hour), "another secret")
+
+	// keep the original signature on a payload for another email
+	_, signature, _ := strings.Cut(token, ".")
+	payload, _, _ := strings.Cut(forged, ".")
+	_, _, err := ParseEmailVerificationToken(payload+"."+signature, suite.secret, suite.now)
+
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func (suite *SignedTokenSuite) TestMalformed() {
+	for _, token := range []string{"", "no separator", "bm90IGVub3VnaCBmaWVsZHM.signature"} {
+		_, _, err := ParseEmailVerificationToken(token, suite.secret, suite.now)
+		suite.ErrorIs(err, domain.ErrInvalidToken, token)
+	}
+}
+
+func (suite *SignedTokenSuite) TestLoginChallengeRoundTrip() {
+	token := CreateLoginChallengeToken("user id", suite.now.Add(time.Minute), suite.secret)
+
+	userID, err := ParseLoginChallengeToken(token, suite.secret, suite.now)
+
+	suite.NoError(err)
+	suite.Equal("user id", userID)
+}
+
+func (suite *SignedTokenSuite) TestPurposesAreNotInterchangeable() {
+	challenge := CreateLoginChallengeToken("user id", suite.now.Add(time.Minute), suite.secret)
+	verification := CreateEmailVerificationToken("user id", "test@example.com", suite.now.Add(time.Minute), suite.secret)
+
+	_, _, err := ParseEmailVerificationToken(challenge, suite.secret, suite.now)
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+
+	_, err = ParseLoginChallengeToken(verification, suite.secret, suite.now)
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func TestSignedTokenSuite(t *testing.T) {
+	suite.Run(t, new(SignedTokenSuite))
+}
@@ -0,0 +1,90 @@
+package infrastructure
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TOTPSuite struct {
+	suite.Suite
+	secret string
+}
+
+func (suite *TOTPSuite) SetupTest() {
+	// the secret of the test vectors of RFC 6238
+	suite.secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+}
+
+func (suite *TOTPSuite) TestRFCVectors() {
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, code := range vectors {
+		_, valid := ValidateTOTP(suite.secret, code, time.Unix(unix, 0), 0)
+		suite.True(valid, "code at %v", unix)
+	}
+}
+
+func (suite *TOTPSuite) TestClockDrift() {
+	now := time.Unix(1111111109, 0)
+
+	// the code of the previous period is still accepted, but not older ones
+	_, valid := ValidateTOTP(suite.secret, "081804", now.Add(30*time.Second), 0)
+	suite.True(valid)
+
+	_, valid = ValidateTOTP(suite.secret, "081804", now.Add(90*time.Second), 0)
+	suite.False(valid)
+}
+
+func (suite *TOTPSuite) TestReplayRefused() {
+	now := time.Unix(1111111109, 0)
+
+	step, valid := ValidateTOTP(suite.secret, "081804", now, 0)
+	suite.True(valid)
+
+	_, valid = ValidateTOTP(suite.secret, "081804", now, step)
+	suite.False(valid)
+}
+
+func (suite *TOTPSuite) TestWrongCode() {
+	_, valid := ValidateTOTP(suite.secret, "123456", time.Unix(59, 0), 0)
+	suite.False(valid)
+
+	_, valid = ValidateTOTP(suite.secret, "28708", time.Unix(59, 0), 0)
+	suite.False(valid)
+}
+
+func (suite *TOTPSuite) TestGeneratedSecretRoundTrip() {
+	secret, err := GenerateTOTPSecret()
+	suite.NoError(err)
+
+	now := time.Now()
+	code, err := TOTPCode(secret, now)
+	suite.NoError(err)
+
+	_, valid := ValidateTOTP(secret, code, now, 0)
+	suite.True(valid)
+}
+
+func (suite *TOTPSuite) TestURI() {
+	uri, err := url.Parse(TOTPURI("Task Manager", "test@example.com", "SECRET"))
+	suite.NoError(err)
+
+	suite.Equal("otpauth", uri.Scheme)
+	suite.Equal("totp", uri.Host)
+	suite.Equal("/Task Manager:test@example.com", uri.Path)
+	suite.Equal("SECRET", uri.Query().Get("secret"))
+	suite.Equal("Task Manager", uri.Query().Get("issuer"))
+}
+
+func TestTOTPSuite(t *testing.T) {
+	suite.Run(t, new(TOTPSuite))
+}
@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// untracedPaths are probed or scraped every few seconds, and would drown the traces of the API.
+var untracedPaths = map[string]bool{"/healthz": true, "/readyz": true, "/metrics": true}
+
+// TracingMiddleware starts a span for every request, named after the route it matched, continuing
+// the trace of the W3C 'traceparent' header when the client sent one. The span is in the context
+// of the request, which the engine has to fall back to for the usecases to trace under it.
+func TracingMiddleware(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		return !untracedPaths[r.URL.Path]
+	}))
+}
@@ -0,0 +1,86 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewareSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	router   *gin.Engine
+}
+
+func (suite *TracingMiddlewareSuite) SetupTest() {
+	suite.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.ContextWithFallback = true
+	suite.router.Use(TracingMiddleware("task-manager"))
+
+	// the handler starts a span under the gin context, as the usecases do
+	handler := func(c *gin.Context) {
+		_, span := otel.Tracer("test").Start(c, "TaskUsecase.GetTaskByID")
+		span.End()
+		c.Status(http.StatusOK)
+	}
+	suite.router.GET("/v1/tasks/:id", handler)
+	suite.router.GET("/healthz", handler)
+}
+
+func (suite *TracingMiddlewareSuite) serve(path string, traceparent string) {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	if traceparent != "" {
+		request.Header.Set("traceparent", traceparent)
+	}
+	suite.router.ServeHTTP(httptest.NewRecorder(), request)
+}
+
+func (suite *TracingMiddlewareSuite) TestContinuesTheTraceOfTheClient() {
+	suite.serve("/v1/tasks/1", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 2)
+	usecaseSpan, requestSpan := spans[0], spans[1]
+
+	suite.Equal("/v1/tasks/:id", requestSpan.Name())
+	suite.Equal("4bf92f3577b34da6a3ce929d0e0e4736", requestSpan.SpanContext().TraceID().String())
+	suite.Equal("00f067aa0ba902b7", requestSpan.Parent().SpanID().String())
+	suite.Equal(trace.SpanKindServer, requestSpan.SpanKind())
+
+	suite.Equal(requestSpan.SpanContext().SpanID(), usecaseSpan.Parent().SpanID())
+}
+
+func (suite *TracingMiddlewareSuite) TestStartsATraceWithoutHeader() {
+	suite.serve("/v1/tasks/1", "")
+
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 2)
+	suite.False(spans[1].Parent().IsValid())
+	suite.Equal(spans[1].SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
+
+func (suite *TracingMiddlewareSuite) TestProbesAreNotTraced() {
+	suite.serve("/healthz", "")
+
+	// only the span of the handler, without a parent
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.False(spans[0].Parent().IsValid())
+}
+
+func TestTracingMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TracingMiddlewareSuite))
+}
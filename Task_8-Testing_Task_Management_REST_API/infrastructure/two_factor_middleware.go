@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+	"slices"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireTwoFactor is a middleware function that refuses users with one of the given roles
+// until they have enabled two-factor authentication. It reads the claims set by JWTAuthMiddleware,
+// so it has to run after it. Without roles, it lets every user through.
+func RequireTwoFactor(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user_role, err := GetUserRoleFromContext(c)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized user"})
+			c.Abort()
+			return
+		}
+
+		if !slices.Contains(roles, user_role) {
+			c.Next()
+			return
+		}
+
+		claims, _ := c.MustGet("claims").(jwt.MapClaims)
+		if enabled, _ := claims["2fa"].(bool); !enabled {
+			c.JSON(http.StatusForbidden, gin.H{"error": domain.ErrTwoFactorRequired.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type TwoFactorMiddlewareSuite struct {
+	suite.Suite
+}
+
+func (suite *TwoFactorMiddlewareSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+}
+
+// serve runs the middleware requiring two-factor authentication for admins, for a user with the given claims
+func (suite *TwoFactorMiddlewareSuite) serve(claims jwt.MapClaims) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	if claims != nil {
+		c.Set("claims", claims)
+	}
+
+	RequireTwoFactor(domain.RoleAdmin)(c)
+
+	return recorder
+}
+
+func (suite *TwoFactorMiddlewareSuite) TestRoleNotRequired() {
+	recorder := suite.serve(jwt.MapClaims{"role": "USER", "2fa": false})
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *TwoFactorMiddlewareSuite) TestRequiredAndEnabled() {
+	recorder := suite.serve(jwt.MapClaims{"role": "ADMIN", "2fa": true})
+
+	suite.Equal(http.StatusOK, recorder.Code)
+}
+
+func (suite *TwoFactorMiddlewareSuite) TestRequiredButNotEnabled() {
+	recorder := suite.serve(jwt.MapClaims{"role": "ADMIN", "2fa": false})
+
+	suite.Equal(http.StatusForbidden, recorder.Code)
+	suite.Contains(recorder.Body.String(), domain.ErrTwoFactorRequired.Error())
+}
+
+func (suite *TwoFactorMiddlewareSuite) TestMissingClaims() {
+	recorder := suite.serve(nil)
+
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func TestTwoFactorMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorMiddlewareSuite))
+}
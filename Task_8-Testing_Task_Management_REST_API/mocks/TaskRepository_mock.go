@@ -5,6 +5,7 @@ package mocks
 import (
 	domain "Task_8-Testing_Task_Management_REST_API/domain"
 	context "context"
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,6 +15,85 @@ type TaskRepository struct {
 	mock.Mock
 }
 
+// AddAttachment provides a mock function with given fields: c, taskID, attachment
+func (_m *TaskRepository) AddAttachment(c context.Context, taskID string, attachment *domain.Attachment) error {
+	ret := _m.Called(c, taskID, attachment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.Attachment) error); ok {
+		r0 = rf(c, taskID, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountAttachmentsByHash provides a mock function with given fields: c, hash
+func (_m *TaskRepository) CountAttachmentsByHash(c context.Context, hash string) (int64, error) {
+	ret := _m.Called(c, hash)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(c, hash)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTasksByProject provides a mock function with given fields: c, projectID
+func (_m *TaskRepository) CountTasksByProject(c context.Context, projectID string) (int64, error) {
+	ret := _m.Called(c, projectID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(c, projectID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTasksByStatus provides a mock function with given fields: c
+func (_m *TaskRepository) CountTasksByStatus(c context.Context) (map[string]int64, error) {
+	ret := _m.Called(c)
+
+	var r0 map[string]int64
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]int64); ok {
+		r0 = rf(c)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: c, task
 func (_m *TaskRepository) Create(c context.Context, task *domain.Task) error {
 	ret := _m.Called(c, task)
@@ -86,6 +166,108 @@ func (_m *TaskRepository) GetTasks(c context.Context) ([]domain.Task, error) {
 	return r0, r1
 }
 
+// GetTasksByAssignee provides a mock function with given fields: c, userID
+func (_m *TaskRepository) GetTasksByAssignee(c context.Context, userID string) ([]domain.Task, error) {
+	ret := _m.Called(c, userID)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTasksByProject provides a mock function with given fields: c, projectID
+func (_m *TaskRepository) GetTasksByProject(c context.Context, projectID string) ([]domain.Task, error) {
+	ret := _m.Called(c, projectID)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(c, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignUser provides a mock function with given fields: c, userID, replacementID
+func (_m *TaskRepository) ReassignUser(c context.Context, userID string, replacementID string) error {
+	ret := _m.Called(c, userID, replacementID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, userID, replacementID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveAttachment provides a mock function with given fields: c, taskID, attachmentID
+func (_m *TaskRepository) RemoveAttachment(c context.Context, taskID string, attachmentID string) error {
+	ret := _m.Called(c, taskID, attachmentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, taskID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetAssignees provides a mock function with given fields: c, taskID, assignees
+func (_m *TaskRepository) SetAssignees(c context.Context, taskID string, assignees []primitive.ObjectID) error {
+	ret := _m.Called(c, taskID, assignees)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []primitive.ObjectID) error); ok {
+		r0 = rf(c, taskID, assignees)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnassignUser provides a mock function with given fields: c, userID
+func (_m *TaskRepository) UnassignUser(c context.Context, userID string) error {
+	ret := _m.Called(c, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTask provides a mock function with given fields: c, taskID, updated_task
 func (_m *TaskRepository) UpdateTask(c context.Context, taskID string, updated_task *domain.Task) error {
 	ret := _m.Called(c, taskID, updated_task)
@@ -14,6 +14,20 @@ type TaskUsecase struct {
 	mock.Mock
 }
 
+// AssignUsers provides a mock function with given fields: c, taskID, userIDs
+func (_m *TaskUsecase) AssignUsers(c context.Context, taskID string, userIDs []string) error {
+	ret := _m.Called(c, taskID, userIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(c, taskID, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: c, task
 func (_m *TaskUsecase) Create(c context.Context, task *domain.Task) error {
 	ret := _m.Called(c, task)
@@ -42,6 +56,52 @@ func (_m *TaskUsecase) DeleteTask(c context.Context, taskID string) error {
 	return r0
 }
 
+// GetAssignedTasks provides a mock function with given fields: c, userID
+func (_m *TaskUsecase) GetAssignedTasks(c context.Context, userID string) ([]domain.Task, error) {
+	ret := _m.Called(c, userID)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(c, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProjectTasks provides a mock function with given fields: c, projectID
+func (_m *TaskUsecase) GetProjectTasks(c context.Context, projectID string) ([]domain.Task, error) {
+	ret := _m.Called(c, projectID)
+
+	var r0 []domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Task); ok {
+		r0 = rf(c, projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskByID provides a mock function with given fields: c, taskID
 func (_m *TaskUsecase) GetTaskByID(c context.Context, taskID string) (domain.Task, error) {
 	ret := _m.Called(c, taskID)
@@ -63,6 +123,27 @@ func (_m *TaskUsecase) GetTaskByID(c context.Context, taskID string) (domain.Tas
 	return r0, r1
 }
 
+// GetTaskWithoutProject provides a mock function with given fields: c, taskID, actorID, actorRole
+func (_m *TaskUsecase) GetTaskWithoutProject(c context.Context, taskID string, actorID string, actorRole string) (domain.Task, error) {
+	ret := _m.Called(c, taskID, actorID, actorRole)
+
+	var r0 domain.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) domain.Task); ok {
+		r0 = rf(c, taskID, actorID, actorRole)
+	} else {
+		r0 = ret.Get(0).(domain.Task)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(c, taskID, actorID, actorRole)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTasks provides a mock function with given fields: c
 func (_m *TaskUsecase) GetTasks(c context.Context) ([]domain.Task, error) {
 	ret := _m.Called(c)
@@ -86,6 +167,20 @@ func (_m *TaskUsecase) GetTasks(c context.Context) ([]domain.Task, error) {
 	return r0, r1
 }
 
+// UpdateStatus provides a mock function with given fields: c, taskID, actorID, actorRole, status
+func (_m *TaskUsecase) UpdateStatus(c context.Context, taskID string, actorID string, actorRole string, status string) error {
+	ret := _m.Called(c, taskID, actorID, actorRole, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(c, taskID, actorID, actorRole, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTask provides a mock function with given fields: c, taskID, updated_task
 func (_m *TaskUsecase) UpdateTask(c context.Context, taskID string, updated_task *domain.Task) error {
 	ret := _m.Called(c, taskID, updated_task)
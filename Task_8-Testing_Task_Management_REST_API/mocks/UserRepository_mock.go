@@ -5,6 +5,7 @@ package mocks
 import (
 	domain "Task_8-Testing_Task_Management_REST_API/domain"
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -35,6 +36,48 @@ func (_m *UserRepository) AreThereAnyUsers(c context.Context) (bool, error) {
 	return r0, r1
 }
 
+// ClaimVerificationSend provides a mock function with given fields: c, id, sentBefore, sentAt
+func (_m *UserRepository) ClaimVerificationSend(c context.Context, id string, sentBefore time.Time, sentAt time.Time) (bool, error) {
+	ret := _m.Called(c, id, sentBefore, sentAt)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) bool); ok {
+		r0 = rf(c, id, sentBefore, sentAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r1 = rf(c, id, sentBefore, sentAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountActiveByRole provides a mock function with given fields: c, role
+func (_m *UserRepository) CountActiveByRole(c context.Context, role string) (int64, error) {
+	ret := _m.Called(c, role)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(c, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(c, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: c, user
 func (_m *UserRepository) Create(c context.Context, user *domain.User) error {
 	ret := _m.Called(c, user)
@@ -49,6 +92,20 @@ func (_m *UserRepository) Create(c context.Context, user *domain.User) error {
 	return r0
 }
 
+// DeleteUser provides a mock function with given fields: c, id
+func (_m *UserRepository) DeleteUser(c context.Context, id string) error {
+	ret := _m.Called(c, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByEmail provides a mock function with given fields: c, email
 func (_m *UserRepository) GetByEmail(c context.Context, email string) (*domain.User, error) {
 	ret := _m.Called(c, email)
@@ -95,6 +152,127 @@ func (_m *UserRepository) GetByID(c context.Context, id string) (*domain.User, e
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: c, filter, pagination
+func (_m *UserRepository) GetUsers(c context.Context, filter domain.UserFilter, pagination domain.Pagination) ([]domain.User, int64, error) {
+	ret := _m.Called(c, filter, pagination)
+
+	var r0 []domain.User
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter, domain.Pagination) []domain.User); ok {
+		r0 = rf(c, filter, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter, domain.Pagination) int64); ok {
+		r1 = rf(c, filter, pagination)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, domain.UserFilter, domain.Pagination) error); ok {
+		r2 = rf(c, filter, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReplacePasswordHash provides a mock function with given fields: c, id, oldHash, newHash
+func (_m *UserRepository) ReplacePasswordHash(c context.Context, id string, oldHash string, newHash string) (bool, error) {
+	ret := _m.Called(c, id, oldHash, newHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(c, id, oldHash, newHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(c, id, oldHash, newHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDisabled provides a mock function with given fields: c, id, disabled
+func (_m *UserRepository) SetDisabled(c context.Context, id string, disabled bool) error {
+	ret := _m.Called(c, id, disabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(c, id, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEmailVerified provides a mock function with given fields: c, id
+func (_m *UserRepository) SetEmailVerified(c context.Context, id string) error {
+	ret := _m.Called(c, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPassword provides a mock function with given fields: c, id, hashedPassword
+func (_m *UserRepository) SetPassword(c context.Context, id string, hashedPassword string) error {
+	ret := _m.Called(c, id, hashedPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, id, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRole provides a mock function with given fields: c, id, role
+func (_m *UserRepository) SetRole(c context.Context, id string, role string) error {
+	ret := _m.Called(c, id, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTwoFactor provides a mock function with given fields: c, user
+func (_m *UserRepository) UpdateTwoFactor(c context.Context, user *domain.User) error {
+	ret := _m.Called(c, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User) error); ok {
+		r0 = rf(c, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUser provides a mock function with given fields: c, user
 func (_m *UserRepository) UpdateUser(c context.Context, user *domain.User) error {
 	ret := _m.Called(c, user)
@@ -109,6 +287,48 @@ func (_m *UserRepository) UpdateUser(c context.Context, user *domain.User) error
 	return r0
 }
 
+// UseRecoveryCode provides a mock function with given fields: c, id, hash
+func (_m *UserRepository) UseRecoveryCode(c context.Context, id string, hash string) (bool, error) {
+	ret := _m.Called(c, id, hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(c, id, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(c, id, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UseTOTPStep provides a mock function with given fields: c, id, step
+func (_m *UserRepository) UseTOTPStep(c context.Context, id string, step int64) (bool, error) {
+	ret := _m.Called(c, id, step)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) bool); ok {
+		r0 = rf(c, id, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(c, id, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
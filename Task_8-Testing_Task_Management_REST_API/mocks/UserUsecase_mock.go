@@ -70,6 +70,34 @@ func (_m *UserUsecase) CreateAccessToken(user *domain.User, secret string, expir
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: c, id, taskPolicy, replacementID
+func (_m *UserUsecase) Delete(c context.Context, id string, taskPolicy string, replacementID string) error {
+	ret := _m.Called(c, id, taskPolicy, replacementID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(c, id, taskPolicy, replacementID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Demote provides a mock function with given fields: c, id
+func (_m *UserUsecase) Demote(c context.Context, id string) error {
+	ret := _m.Called(c, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetByEmail provides a mock function with given fields: c, email
 func (_m *UserUsecase) GetByEmail(c context.Context, email string) (*domain.User, error) {
 	ret := _m.Called(c, email)
@@ -116,6 +144,92 @@ func (_m *UserUsecase) GetByID(c context.Context, id string) (*domain.User, erro
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: c, filter, pagination
+func (_m *UserUsecase) GetUsers(c context.Context, filter domain.UserFilter, pagination domain.Pagination) (*domain.UserPage, error) {
+	ret := _m.Called(c, filter, pagination)
+
+	var r0 *domain.UserPage
+	if rf, ok := ret.Get(0).(func(context.Context, domain.UserFilter, domain.Pagination) *domain.UserPage); ok {
+		r0 = rf(c, filter, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.UserFilter, domain.Pagination) error); ok {
+		r1 = rf(c, filter, pagination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Promote provides a mock function with given fields: c, id
+func (_m *UserUsecase) Promote(c context.Context, id string) error {
+	ret := _m.Called(c, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(c, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplacePasswordHash provides a mock function with given fields: c, id, oldHash, newHash
+func (_m *UserUsecase) ReplacePasswordHash(c context.Context, id string, oldHash string, newHash string) (bool, error) {
+	ret := _m.Called(c, id, oldHash, newHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = rf(c, id, oldHash, newHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(c, id, oldHash, newHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetDisabled provides a mock function with given fields: c, id, disabled
+func (_m *UserUsecase) SetDisabled(c context.Context, id string, disabled bool) error {
+	ret := _m.Called(c, id, disabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(c, id, disabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPassword provides a mock function with given fields: c, id, hashedPassword
+func (_m *UserUsecase) SetPassword(c context.Context, id string, hashedPassword string) error {
+	ret := _m.Called(c, id, hashedPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(c, id, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUser provides a mock function with given fields: c, user
 func (_m *UserUsecase) UpdateUser(c context.Context, user *domain.User) error {
 	ret := _m.Called(c, user)
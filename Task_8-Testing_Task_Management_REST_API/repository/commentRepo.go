@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commentRepo struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewCommentRepo(database mongo.Database, collection string) domain.CommentRepository {
+	return &commentRepo{
+		database:   database,
+		collection: collection,
+	}
+}
+
+// Create inserts a new comment into the database.
+// It assigns a fresh ID to the comment before inserting it.
+func (commentRepo *commentRepo) Create(c context.Context, comment *domain.Comment) error {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	comment.ID = primitive.NewObjectID()
+	_, err := collection.InsertOne(c, comment)
+	return err
+}
+
+// GetByID retrieves a comment by its ID.
+// It returns domain.ErrCommentNotFound if the ID is malformed or no comment matches it.
+func (commentRepo *commentRepo) GetByID(c context.Context, commentID string) (*domain.Comment, error) {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, domain.ErrCommentNotFound
+	}
+
+	var comment domain.Comment
+	err = collection.FindOne(c, bson.M{"_id": objID}).Decode(&comment)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// GetThreads retrieves one page of top-level comments of a task, oldest first,
+// together with the total number of top-level comments on the task.
+func (commentRepo *commentRepo) GetThreads(c context.Context, taskID string, pagination domain.Pagination) ([]domain.Comment, int64, error) {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	taskObjID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return nil, 0, domain.ErrTaskNotFound
+	}
+
+	filter := bson.M{"task_id": taskObjID, "parent_id": bson.M{"$exists": false}}
+
+	total, err := collection.CountDocuments(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cursor, err := collection.Find(c, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	comments := []domain.Comment{}
+	if err = cursor.All(c, &comments); err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// GetReplies retrieves every reply that belongs to one of the given threads, oldest first.
+func (commentRepo *commentRepo) GetReplies(c context.Context, rootIDs []primitive.ObjectID) ([]domain.Comment, error) {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	if len(rootIDs) == 0 {
+		return []domain.Comment{}, nil
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := collection.Find(c, bson.M{"root_id": bson.M{"$in": rootIDs}}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	replies := []domain.Comment{}
+	if err = cursor.All(c, &replies); err != nil {
+		return nil, err
+	}
+
+	return replies, nil
+}
+
+// Update overwrites the content and moderation state of an existing comment.
+func (commentRepo *commentRepo) Update(c context.Context, comment *domain.Comment) error {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	update := bson.M{
+		"$set": bson.M{
+			"content":    comment.Content,
+			"deleted":    comment.Deleted,
+			"updated_at": comment.UpdatedAt,
+		},
+	}
+
+	updateResult, err := collection.UpdateOne(c, bson.M{"_id": comment.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// DeleteByTaskID removes every comment that belongs to the given task.
+func (commentRepo *commentRepo) DeleteByTaskID(c context.Context, taskID string) error {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	taskObjID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+
+	_, err = collection.DeleteMany(c, bson.M{"task_id": taskObjID})
+	return err
+}
+
+// DeleteByAuthor turns every comment of the given author into a tombstone, as if they deleted it,
+// so that the replies of other users stay in place.
+func (commentRepo *commentRepo) DeleteByAuthor(c context.Context, authorID string, deletedAt time.Time) error {
+	collection := commentRepo.database.Collection(commentRepo.collection)
+
+	authorObjID, err := primitive.ObjectIDFromHex(authorID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = collection.UpdateMany(c,
+		bson.M{"author_id": authorObjID, "deleted": false},
+		bson.M{"$set": bson.M{"content": "", "deleted": true, "updated_at": deletedAt}},
+	)
+	return err
+}
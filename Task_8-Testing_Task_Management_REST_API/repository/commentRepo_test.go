@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type CommentRepoTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	repo       *commentRepo
+	collection *mongo.Collection
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *CommentRepoTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_db")
+	suite.repo = &commentRepo{
+		database:   *suite.db,
+		collection: "test_comments",
+	}
+	suite.collection = suite.db.Collection("test_comments")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *CommentRepoTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *CommentRepoTestSuite) SetupTest() {
+	// clear the comment collection before each test
+	suite.collection.Drop(context.Background())
+}
+
+func (suite *CommentRepoTestSuite) newComment(taskID primitive.ObjectID, parent *domain.Comment, createdAt time.Time) *domain.Comment {
+	comment := &domain.Comment{
+		TaskID:    taskID,
+		AuthorID:  primitive.NewObjectID(),
+		Content:   "Test Comment",
+		CreatedAt: createdAt.UTC().Truncate(time.Millisecond),
+	}
+	if parent != nil {
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+	}
+
+	err := suite.repo.Create(context.Background(), comment)
+	suite.NoError(err)
+
+	return comment
+}
+
+func (suite *CommentRepoTestSuite) TestCreateAndGetByID() {
+	comment := suite.newComment(primitive.NewObjectID(), nil, time.Now())
+
+	retrievedComment, err := suite.repo.GetByID(context.Background(), comment.ID.Hex())
+	suite.NoError(err)
+
+	// check if the retrieved comment contains the right parameters
+	suite.Equal(comment.TaskID, retrievedComment.TaskID)
+	suite.Equal(comment.AuthorID, retrievedComment.AuthorID)
+	suite.Equal(comment.Content, retrievedComment.Content)
+	suite.Nil(retrievedComment.ParentID)
+}
+
+func (suite *CommentRepoTestSuite) TestGetByID_NotFound() {
+	_, err := suite.repo.GetByID(context.Background(), primitive.NewObjectID().Hex())
+	suite.ErrorIs(err, domain.ErrCommentNotFound)
+}
+
+func (suite *CommentRepoTestSuite) TestGetThreadsAndReplies() {
+	taskID := primitive.NewObjectID()
+	now := time.Now()
+
+	first := suite.newComment(taskID, nil, now)
+	second := suite.newComment(taskID, nil, now.Add(time.Minute))
+	reply := suite.newComment(taskID, first, now.Add(2*time.Minute))
+	suite.newComment(taskID, reply, now.Add(3*time.Minute))
+	suite.newComment(primitive.NewObjectID(), nil, now)
+
+	// only top-level comments of the task are paginated
+	threads, total, err := suite.repo.GetThreads(context.Background(), taskID.Hex(), domain.NewPagination(1, 1))
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Len(threads, 1)
+	suite.Equal(first.ID, threads[0].ID)
+
+	threads, _, err = suite.repo.GetThreads(context.Background(), taskID.Hex(), domain.NewPagination(2, 1))
+	suite.NoError(err)
+	suite.Len(threads, 1)
+	suite.Equal(second.ID, threads[0].ID)
+
+	// every reply of the thread is returned, however deep it is nested
+	replies, err := suite.repo.GetReplies(context.Background(), []primitive.ObjectID{first.ID})
+	suite.NoError(err)
+	suite.Len(replies, 2)
+}
+
+func (suite *CommentRepoTestSuite) TestUpdate() {
+	comment := suite.newComment(primitive.NewObjectID(), nil, time.Now())
+
+	comment.Content = ""
+	comment.Deleted = true
+	err := suite.repo.Update(context.Background(), comment)
+	suite.NoError(err)
+
+	var updatedComment domain.Comment
+	err = suite.collection.FindOne(context.Background(), bson.M{"_id": comment.ID}).Decode(&updatedComment)
+	suite.NoError(err)
+	suite.True(updatedComment.Deleted)
+	suite.Empty(updatedComment.Content)
+}
+
+func (suite *CommentRepoTestSuite) TestDeleteByTaskID() {
+	taskID := primitive.NewObjectID()
+	otherTaskID := primitive.NewObjectID()
+
+	suite.newComment(taskID, nil, time.Now())
+	suite.newComment(taskID, nil, time.Now())
+	suite.newComment(otherTaskID, nil, time.Now())
+
+	err := suite.repo.DeleteByTaskID(context.Background(), taskID.Hex())
+	suite.NoError(err)
+
+	// check only the comments of the deleted task are removed
+	count, err := suite.collection.CountDocuments(context.Background(), bson.M{"task_id": taskID})
+	suite.NoError(err)
+	suite.Zero(count)
+
+	count, err = suite.collection.CountDocuments(context.Background(), bson.M{"task_id": otherTaskID})
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *CommentRepoTestSuite) TestDeleteByAuthor() {
+	thread := suite.newComment(primitive.NewObjectID(), nil, time.Now())
+	reply := suite.newComment(thread.TaskID, thread, time.Now())
+
+	err := suite.repo.DeleteByAuthor(context.Background(), thread.AuthorID.Hex(), time.Now())
+	suite.NoError(err)
+
+	// the comment is left as a tombstone, and the replies of other users stay as they are
+	deleted, err := suite.repo.GetByID(context.Background(), thread.ID.Hex())
+	suite.NoError(err)
+	suite.True(deleted.Deleted)
+	suite.Empty(deleted.Content)
+
+	kept, err := suite.repo.GetByID(context.Background(), reply.ID.Hex())
+	suite.NoError(err)
+	suite.False(kept.Deleted)
+	suite.Equal("Test Comment", kept.Content)
+}
+
+func TestCommentRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentRepoTestSuite))
+}
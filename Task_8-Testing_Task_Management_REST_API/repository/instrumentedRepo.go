@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"time"
+)
+
+// instrumentation records the operations of a repository on a collection with the metrics.
+type instrumentation struct {
+	metrics    domain.RepositoryMetrics
+	collection string
+}
+
+// observe is deferred by the instrumented operations, with the time they started and their named error.
+func (i instrumentation) observe(operation string, start time.Time, err *error) {
+	i.metrics.ObserveRepositoryOperation(i.collection, operation, time.Since(start), *err)
+}
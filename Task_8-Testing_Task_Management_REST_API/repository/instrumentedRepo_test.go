@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// observedOperation is an operation recorded by recordingMetrics.
+type observedOperation struct {
+	collection string
+	operation  string
+	err        error
+}
+
+type recordingMetrics struct {
+	operations []observedOperation
+}
+
+func (metrics *recordingMetrics) ObserveRepositoryOperation(collection string, operation string, duration time.Duration, err error) {
+	metrics.operations = append(metrics.operations, observedOperation{collection: collection, operation: operation, err: err})
+}
+
+type InstrumentedRepoTestSuite struct {
+	suite.Suite
+	metrics      *recordingMetrics
+	taskMockRepo *mocks.TaskRepository
+	userMockRepo *mocks.UserRepository
+}
+
+func (suite *InstrumentedRepoTestSuite) SetupTest() {
+	suite.metrics = &recordingMetrics{}
+	suite.taskMockRepo = new(mocks.TaskRepository)
+	suite.userMockRepo = new(mocks.UserRepository)
+}
+
+func (suite *InstrumentedRepoTestSuite) TearDownTest() {
+	suite.taskMockRepo.AssertExpectations(suite.T())
+	suite.userMockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *InstrumentedRepoTestSuite) TestTaskRepo() {
+	repo := NewInstrumentedTaskRepo(suite.taskMockRepo, domain.CollectionTask, suite.metrics)
+	task := domain.Task{Title: "Task"}
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, "1").Return(task, nil).Once()
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, "2").Return(domain.Task{}, domain.ErrTaskNotFound).Once()
+
+	// the results of the wrapped repository are returned as they are
+	result, err := repo.GetTaskByID(context.Background(), "1")
+	suite.NoError(err)
+	suite.Equal(task, result)
+
+	_, err = repo.GetTaskByID(context.Background(), "2")
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	suite.Equal([]observedOperation{
+		{collection: domain.CollectionTask, operation: "GetTaskByID"},
+		{collection: domain.CollectionTask, operation: "GetTaskByID", err: domain.ErrTaskNotFound},
+	}, suite.metrics.operations)
+}
+
+func (suite *InstrumentedRepoTestSuite) TestUserRepo() {
+	repo := NewInstrumentedUserRepo(suite.userMockRepo, domain.CollectionUser, suite.metrics)
+	failure := errors.New("connection refused")
+	suite.userMockRepo.On("GetUsers", mock.Anything, domain.UserFilter{}, domain.Pagination{}).Return(nil, int64(0), failure).Once()
+
+	_, _, err := repo.GetUsers(context.Background(), domain.UserFilter{}, domain.Pagination{})
+
+	suite.ErrorIs(err, failure)
+	suite.Equal([]observedOperation{{collection: domain.CollectionUser, operation: "GetUsers", err: failure}}, suite.metrics.operations)
+}
+
+func TestInstrumentedRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(InstrumentedRepoTestSuite))
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type instrumentedTaskRepo struct {
+	instrumentation
+	repo domain.TaskRepository
+}
+
+// NewInstrumentedTaskRepo wraps a task repository, recording the duration and the errors
+// of every operation on the collection with the metrics.
+func NewInstrumentedTaskRepo(repo domain.TaskRepository, collection string, metrics domain.RepositoryMetrics) domain.TaskRepository {
+	return &instrumentedTaskRepo{
+		instrumentation: instrumentation{metrics: metrics, collection: collection},
+		repo:            repo,
+	}
+}
+
+func (repo *instrumentedTaskRepo) Create(c context.Context, task *domain.Task) (err error) {
+	defer repo.observe("Create", time.Now(), &err)
+	return repo.repo.Create(c, task)
+}
+
+func (repo *instrumentedTaskRepo) GetTasks(c context.Context) (result []domain.Task, err error) {
+	defer repo.observe("GetTasks", time.Now(), &err)
+	return repo.repo.GetTasks(c)
+}
+
+func (repo *instrumentedTaskRepo) GetTaskByID(c context.Context, taskID string) (result domain.Task, err error) {
+	defer repo.observe("GetTaskByID", time.Now(), &err)
+	return repo.repo.GetTaskByID(c, taskID)
+}
+
+func (repo *instrumentedTaskRepo) UpdateTask(c context.Context, taskID string, updated_task *domain.Task) (err error) {
+	defer repo.observe("UpdateTask", time.Now(), &err)
+	return repo.repo.UpdateTask(c, taskID, updated_task)
+}
+
+func (repo *instrumentedTaskRepo) DeleteTask(c context.Context, taskID string) (err error) {
+	defer repo.observe("DeleteTask", time.Now(), &err)
+	return repo.repo.DeleteTask(c, taskID)
+}
+
+func (repo *instrumentedTaskRepo) AddAttachment(c context.Context, taskID string, attachment *domain.Attachment) (err error) {
+	defer repo.observe("AddAttachment", time.Now(), &err)
+	return repo.repo.AddAttachment(c, taskID, attachment)
+}
+
+func (repo *instrumentedTaskRepo) RemoveAttachment(c context.Context, taskID string, attachmentID string) (err error) {
+	defer repo.observe("RemoveAttachment", time.Now(), &err)
+	return repo.repo.RemoveAttachment(c, taskID, attachmentID)
+}
+
+func (repo *instrumentedTaskRepo) CountAttachmentsByHash(c context.Context, hash string) (result int64, err error) {
+	defer repo.observe("CountAttachmentsByHash", time.Now(), &err)
+	return repo.repo.CountAttachmentsByHash(c, hash)
+}
+
+func (repo *instrumentedTaskRepo) SetAssignees(c context.Context, taskID string, assignees []primitive.ObjectID) (err error) {
+	defer repo.observe("SetAssignees", time.Now(), &err)
+	return repo.repo.SetAssignees(c, taskID, assignees)
+}
+
+func (repo *instrumentedTaskRepo) GetTasksByAssignee(c context.Context, userID string) (result []domain.Task, err error) {
+	defer repo.observe("GetTasksByAssignee", time.Now(), &err)
+	return repo.repo.GetTasksByAssignee(c, userID)
+}
+
+func (repo *instrumentedTaskRepo) UnassignUser(c context.Context, userID string) (err error) {
+	defer repo.observe("UnassignUser", time.Now(), &err)
+	return repo.repo.UnassignUser(c, userID)
+}
+
+func (repo *instrumentedTaskRepo) ReassignUser(c context.Context, userID string, replacementID string) (err error) {
+	defer repo.observe("ReassignUser", time.Now(), &err)
+	return repo.repo.ReassignUser(c, userID, replacementID)
+}
+
+func (repo *instrumentedTaskRepo) GetTasksByProject(c context.Context, projectID string) (result []domain.Task, err error) {
+	defer repo.observe("GetTasksByProject", time.Now(), &err)
+	return repo.repo.GetTasksByProject(c, projectID)
+}
+
+func (repo *instrumentedTaskRepo) CountTasksByProject(c context.Context, projectID string) (result int64, err error) {
+	defer repo.observe("CountTasksByProject", time.Now(), &err)
+	return repo.repo.CountTasksByProject(c, projectID)
+}
+
+func (repo *instrumentedTaskRepo) CountTasksByStatus(c context.Context) (result map[string]int64, err error) {
+	defer repo.observe("CountTasksByStatus", time.Now(), &err)
+	return repo.repo.CountTasksByStatus(c)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+)
+
+type instrumentedUserRepo struct {
+	instrumentation
+	repo domain.UserRepository
+}
+
+// NewInstrumentedUserRepo wraps a user repository, recording the duration and the errors
+// of every operation on the collection with the metrics.
+func NewInstrumentedUserRepo(repo domain.UserRepository, collection string, metrics domain.RepositoryMetrics) domain.UserRepository {
+	return &instrumentedUserRepo{
+		instrumentation: instrumentation{metrics: metrics, collection: collection},
+		repo:            repo,
+	}
+}
+
+func (repo *instrumentedUserRepo) Create(c context.Context, user *domain.User) (err error) {
+	defer repo.observe("Create", time.Now(), &err)
+	return repo.repo.Create(c, user)
+}
+
+func (repo *instrumentedUserRepo) GetByEmail(c context.Context, email string) (result *domain.User, err error) {
+	defer repo.observe("GetByEmail", time.Now(), &err)
+	return repo.repo.GetByEmail(c, email)
+}
+
+func (repo *instrumentedUserRepo) GetByID(c context.Context, id string) (result *domain.User, err error) {
+	defer repo.observe("GetByID", time.Now(), &err)
+	return repo.repo.GetByID(c, id)
+}
+
+func (repo *instrumentedUserRepo) UpdateUser(c context.Context, user *domain.User) (err error) {
+	defer repo.observe("UpdateUser", time.Now(), &err)
+	return repo.repo.UpdateUser(c, user)
+}
+
+func (repo *instrumentedUserRepo) SetPassword(c context.Context, id string, hashedPassword string) (err error) {
+	defer repo.observe("SetPassword", time.Now(), &err)
+	return repo.repo.SetPassword(c, id, hashedPassword)
+}
+
+func (repo *instrumentedUserRepo) ReplacePasswordHash(c context.Context, id string, oldHash string, newHash string) (result bool, err error) {
+	defer repo.observe("ReplacePasswordHash", time.Now(), &err)
+	return repo.repo.ReplacePasswordHash(c, id, oldHash, newHash)
+}
+
+func (repo *instrumentedUserRepo) SetRole(c context.Context, id string, role string) (err error) {
+	defer repo.observe("SetRole", time.Now(), &err)
+	return repo.repo.SetRole(c, id, role)
+}
+
+func (repo *instrumentedUserRepo) SetDisabled(c context.Context, id string, disabled bool) (err error) {
+	defer repo.observe("SetDisabled", time.Now(), &err)
+	return repo.repo.SetDisabled(c, id, disabled)
+}
+
+func (repo *instrumentedUserRepo) SetEmailVerified(c context.Context, id string) (err error) {
+	defer repo.observe("SetEmailVerified", time.Now(), &err)
+	return repo.repo.SetEmailVerified(c, id)
+}
+
+func (repo *instrumentedUserRepo) ClaimVerificationSend(c context.Context, id string, sentBefore time.Time, sentAt time.Time) (result bool, err error) {
+	defer repo.observe("ClaimVerificationSend", time.Now(), &err)
+	return repo.repo.ClaimVerificationSend(c, id, sentBefore, sentAt)
+}
+
+func (repo *instrumentedUserRepo) UpdateTwoFactor(c context.Context, user *domain.User) (err error) {
+	defer repo.observe("UpdateTwoFactor", time.Now(), &err)
+	return repo.repo.UpdateTwoFactor(c, user)
+}
+
+func (repo *instrumentedUserRepo) UseTOTPStep(c context.Context, id string, step int64) (result bool, err error) {
+	defer repo.observe("UseTOTPStep", time.Now(), &err)
+	return repo.repo.UseTOTPStep(c, id, step)
+}
+
+func (repo *instrumentedUserRepo) UseRecoveryCode(c context.Context, id string, hash string) (result bool, err error) {
+	defer repo.observe("UseRecoveryCode", time.Now(), &err)
+	return repo.repo.UseRecoveryCode(c, id, hash)
+}
+
+func (repo *instrumentedUserRepo) AreThereAnyUsers(c context.Context) (result bool, err error) {
+	defer repo.observe("AreThereAnyUsers", time.Now(), &err)
+	return repo.repo.AreThereAnyUsers(c)
+}
+
+func (repo *instrumentedUserRepo) GetUsers(c context.Context, filter domain.UserFilter, pagination domain.Pagination) (result []domain.User, total int64, err error) {
+	defer repo.observe("GetUsers", time.Now(), &err)
+	return repo.repo.GetUsers(c, filter, pagination)
+}
+
+func (repo *instrumentedUserRepo) CountActiveByRole(c context.Context, role string) (result int64, err error) {
+	defer repo.observe("CountActiveByRole", time.Now(), &err)
+	return repo.repo.CountActiveByRole(c, role)
+}
+
+func (repo *instrumentedUserRepo) DeleteUser(c context.Context, id string) (err error) {
+	defer repo.observe("DeleteUser", time.Now(), &err)
+	return repo.repo.DeleteUser(c, id)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type loginAttemptRepo struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewLoginAttemptRepo(database mongo.Database, collection string) domain.LoginAttemptRepository {
+	return &loginAttemptRepo{
+		database:   database,
+		collection: collection,
+	}
+}
+
+// Get retrieves the failed login attempts tracked under the given key.
+// It returns nil and no error if nothing is tracked under the key.
+func (attemptRepo *loginAttemptRepo) Get(c context.Context, key string) (*domain.LoginAttempt, error) {
+	collection := attemptRepo.database.Collection(attemptRepo.collection)
+
+	var attempt domain.LoginAttempt
+	err := collection.FindOne(c, bson.M{"_id": key}).Decode(&attempt)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// RecordAttempt counts a login attempt under the given key as a failure, until it is forgiven,
+// and returns the attempts as they were before, or nil if none were tracked under the key.
+// The count starts over when the previous failure happened before forgetBefore.
+// The update is atomic, so that concurrent attempts are all counted and each sees the ones counted before it.
+// The attempts expire once they would be forgotten, as long after at as forgetBefore is before it.
+func (attemptRepo *loginAttemptRepo) RecordAttempt(c context.Context, key string, at time.Time, forgetBefore time.Time) (*domain.LoginAttempt, error) {
+	collection := attemptRepo.database.Collection(attemptRepo.collection)
+
+	// a missing 'last_failure' compares lower than any date, so a new key starts at one
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$lt": bson.A{"$last_failure", forgetBefore}},
+				1,
+				bson.M{"$add": bson.A{"$failures", 1}},
+			}},
+			"last_failure": at,
+			"expires_at":   at.Add(at.Sub(forgetBefore)),
+		}}},
+	}
+	updateOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var attempt domain.LoginAttempt
+	err := collection.FindOneAndUpdate(c, bson.M{"_id": key}, update, updateOptions).Decode(&attempt)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// ForgiveAttempt takes back one of the attempts counted under the given key, once it succeeded.
+func (attemptRepo *loginAttemptRepo) ForgiveAttempt(c context.Context, key string) error {
+	collection := attemptRepo.database.Collection(attemptRepo.collection)
+
+	_, err := collection.UpdateOne(c,
+		bson.M{"_id": key, "failures": bson.M{"$gt": 0}},
+		bson.M{"$inc": bson.M{"failures": -1}},
+	)
+	return err
+}
+
+// Reset forgets the failed logins tracked under the given key, lifting any lockout.
+func (attemptRepo *loginAttemptRepo) Reset(c context.Context, key string) error {
+	collection := attemptRepo.database.Collection(attemptRepo.collection)
+
+	_, err := collection.DeleteOne(c, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type LoginAttemptRepoTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	repo       *loginAttemptRepo
+	collection *mongo.Collection
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *LoginAttemptRepoTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_db")
+	suite.repo = &loginAttemptRepo{
+		database:   *suite.db,
+		collection: "test_login_attempts",
+	}
+	suite.collection = suite.db.Collection("test_login_attempts")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *LoginAttemptRepoTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *LoginAttemptRepoTestSuite) SetupTest() {
+	// clear the login attempt collection before each test
+	suite.collection.Drop(context.Background())
+}
+
+func (suite *LoginAttemptRepoTestSuite) TestRecordAttempt_Counts() {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	// each attempt returns the attempts counted before it
+	attempt, err := suite.repo.RecordAttempt(context.Background(), "account:test@example.com", now, now.Add(-time.Hour))
+	suite.NoError(err)
+	suite.Nil(attempt)
+
+	attempt, err = suite.repo.RecordAttempt(context.Background(), "account:test@example.com", now.Add(time.Second), now.Add(-time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, attempt.Failures)
+	suite.Equal(now, attempt.LastFailure)
+
+	attempt, err = suite.repo.Get(context.Background(), "account:test@example.com")
+	suite.NoError(err)
+	suite.Equal(2, attempt.Failures)
+	suite.Equal(now.Add(time.Second), attempt.LastFailure)
+
+	// the attempts are dropped once the last failure is forgotten
+	var expiry struct {
+		ExpiresAt time.Time `bson:"expires_at"`
+	}
+	suite.NoError(suite.collection.FindOne(context.Background(), bson.M{"_id": "account:test@example.com"}).Decode(&expiry))
+	suite.Equal(now.Add(time.Hour+time.Second), expiry.ExpiresAt.UTC())
+}
+
+func (suite *LoginAttemptRepoTestSuite) TestRecordAttempt_ForgetsOldFailures() {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	_, err := suite.repo.RecordAttempt(context.Background(), "ip:10.0.0.1", now.Add(-time.Hour*2), now.Add(-time.Hour*3))
+	suite.NoError(err)
+
+	// the previous failure is older than the window
+	_, err = suite.repo.RecordAttempt(context.Background(), "ip:10.0.0.1", now, now.Add(-time.Hour))
+	suite.NoError(err)
+
+	attempt, err := suite.repo.Get(context.Background(), "ip:10.0.0.1")
+	suite.NoError(err)
+	suite.Equal(1, attempt.Failures)
+}
+
+func (suite *LoginAttemptRepoTestSuite) TestForgiveAttemptAndReset() {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.repo.RecordAttempt(context.Background(), "account:test@example.com", now, now.Add(-time.Hour))
+		suite.NoError(err)
+	}
+
+	err := suite.repo.ForgiveAttempt(context.Background(), "account:test@example.com")
+	suite.NoError(err)
+
+	attempt, err := suite.repo.Get(context.Background(), "account:test@example.com")
+	suite.NoError(err)
+	suite.Equal(1, attempt.Failures)
+
+	err = suite.repo.Reset(context.Background(), "account:test@example.com")
+	suite.NoError(err)
+
+	attempt, err = suite.repo.Get(context.Background(), "account:test@example.com")
+	suite.NoError(err)
+	suite.Nil(attempt)
+}
+
+func TestLoginAttemptRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptRepoTestSuite))
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migrations are the migrations of the database, in the order they are applied. New ones are added
+// at the end with the next version, and the ones already released are never changed.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "normalize the emails of the users registered before emails were normalized",
+		Up: func(c context.Context, database mongo.Database) error {
+			_, err := database.Collection(domain.CollectionUser).UpdateMany(c,
+				bson.M{"email": bson.M{"$type": "string"}},
+				mongo.Pipeline{{{Key: "$set", Value: bson.M{"email": bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$email"}}}}}}},
+			)
+			return err
+		},
+	},
+	{
+		// fails if two users share an email, who must be merged by hand before the migration is applied again
+		Version:     2,
+		Description: "make the emails of the users unique",
+		Up: createIndexes(domain.CollectionUser,
+			mongo.IndexModel{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+		),
+	},
+	{
+		Version:     3,
+		Description: "index the tasks by project and assignee",
+		Up: createIndexes(domain.CollectionTask,
+			mongo.IndexModel{Keys: bson.D{{Key: "project_id", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "assignees", Value: 1}}},
+		),
+	},
+	{
+		Version:     4,
+		Description: "index the projects by member",
+		Up: createIndexes(domain.CollectionProject,
+			mongo.IndexModel{Keys: bson.D{{Key: "members.user_id", Value: 1}}},
+		),
+	},
+	{
+		Version:     5,
+		Description: "index the comments by task and thread",
+		Up: createIndexes(domain.CollectionComment,
+			mongo.IndexModel{Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "created_at", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "root_id", Value: 1}}},
+		),
+	},
+	{
+		Version:     6,
+		Description: "index the personal access tokens by hash and user",
+		Up: createIndexes(domain.CollectionPersonalAccessToken,
+			mongo.IndexModel{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		),
+	},
+	{
+		// MongoDB drops the expired documents about once a minute, so the usecase still checks the expiry
+		Version:     7,
+		Description: "index the password resets by hash and user, and drop them once they expire",
+		Up: createIndexes(domain.CollectionPasswordReset,
+			mongo.IndexModel{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},
+			mongo.IndexModel{Keys: bson.D{{Key: "user_id", Value: 1}}},
+			mongo.IndexModel{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		),
+	},
+	{
+		Version:     8,
+		Description: "drop the rate limit buckets once they are full again",
+		Up: createIndexes(domain.CollectionRateLimit,
+			mongo.IndexModel{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		),
+	},
+	{
+		// the attempts recorded before expires_at was set are never dropped, they are few
+		Version:     9,
+		Description: "drop the login attempts once their failures are forgotten",
+		Up: createIndexes(domain.CollectionLoginAttempt,
+			mongo.IndexModel{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+		),
+	},
+}
+
+// createIndexes returns a migration creating indexes on a collection, which does nothing for the indexes that already exist.
+func createIndexes(collection string, indexes ...mongo.IndexModel) func(c context.Context, database mongo.Database) error {
+	return func(c context.Context, database mongo.Database) error {
+		_, err := database.Collection(collection).Indexes().CreateMany(c, indexes)
+		return err
+	}
+}
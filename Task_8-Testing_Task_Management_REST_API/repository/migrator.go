@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration changes the database from the previous version to Version. Up is applied at most once,
+// but may be interrupted before the migration is recorded, so it must be safe to apply again.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(c context.Context, database mongo.Database) error
+}
+
+const (
+	// migrationLockID is the _id of the document of the collection locking the migrations,
+	// while the migrations applied are recorded under their version.
+	migrationLockID = "lock"
+	// migrationLockTTL is how long a lock is held before another instance may take it over,
+	// in case the instance holding it died.
+	migrationLockTTL = 10 * time.Minute
+	// migrationLockRenewal is how often the instance holding the lock renews it while migrating,
+	// so that migrations lasting longer than migrationLockTTL keep it.
+	migrationLockRenewal = migrationLockTTL / 3
+	// migrationLockPoll is how often an instance waiting for the lock tries to take it.
+	migrationLockPoll = time.Second
+)
+
+type migrator struct {
+	database   mongo.Database
+	collection string
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator applying the migrations, which must be sorted by version,
+// and recording those applied in the collection.
+func NewMigrator(database mongo.Database, collection string, migrations []Migration) domain.Migrator {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			panic(fmt.Sprintf("migration %v is listed after migration %v", migrations[i].Version, migrations[i-1].Version))
+		}
+	}
+
+	return &migrator{
+		database:   database,
+		collection: collection,
+		migrations: migrations,
+	}
+}
+
+// Migrate applies the migrations not applied yet while holding the lock, waiting for it until the context is done.
+func (migrator *migrator) Migrate(c context.Context) ([]domain.MigrationStatus, error) {
+	owner := primitive.NewObjectID().Hex()
+	if err := migrator.lock(c, owner); err != nil {
+		return nil, err
+	}
+	// the lock is released even when the migrations are interrupted
+	defer migrator.unlock(context.WithoutCancel(c), owner)
+
+	renewing, stopRenewing := context.WithCancel(context.WithoutCancel(c))
+	defer stopRenewing()
+	go migrator.renew(renewing, owner)
+
+	statuses, err := migrator.Status(c)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := migrator.database.Collection(migrator.collection)
+	var applied []domain.MigrationStatus
+	for i, migration := range migrator.migrations {
+		if statuses[i].AppliedAt != nil {
+			continue
+		}
+
+		slog.InfoContext(c, "Applying migration", "version", migration.Version, "description", migration.Description)
+		if err := migration.Up(c, migrator.database); err != nil {
+			return applied, fmt.Errorf("migration %v, %v, failed: %w", migration.Version, migration.Description, err)
+		}
+
+		now := time.Now().UTC()
+		_, err := collection.InsertOne(c, bson.M{"_id": migration.Version, "description": migration.Description, "applied_at": now})
+		if err != nil {
+			return applied, err
+		}
+
+		statuses[i].AppliedAt = &now
+		applied = append(applied, statuses[i])
+	}
+
+	return applied, nil
+}
+
+// Status lists the migrations, along with when they were applied.
+func (migrator *migrator) Status(c context.Context) ([]domain.MigrationStatus, error) {
+	collection := migrator.database.Collection(migrator.collection)
+
+	cursor, err := collection.Find(c, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, err
+	}
+
+	var records []struct {
+		Version   int       `bson:"_id"`
+		AppliedAt time.Time `bson:"applied_at"`
+	}
+	if err := cursor.All(c, &records); err != nil {
+		return nil, err
+	}
+
+	appliedAt := make(map[int]time.Time, len(records))
+	for _, record := range records {
+		appliedAt[record.Version] = record.AppliedAt
+	}
+
+	statuses := make([]domain.MigrationStatus, len(migrator.migrations))
+	for i, migration := range migrator.migrations {
+		statuses[i] = domain.MigrationStatus{Version: migration.Version, Description: migration.Description}
+		if at, ok := appliedAt[migration.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+
+	return statuses, nil
+}
+
+// lock takes the lock of the migrations, waiting for the instance holding it to release it or for it to expire.
+func (migrator *migrator) lock(c context.Context, owner string) error {
+	collection := migrator.database.Collection(migrator.collection)
+
+	for {
+		// the lock document is only matched once it expired, otherwise the upsert fails on its _id
+		now := time.Now()
+		_, err := collection.UpdateOne(c,
+			bson.M{"_id": migrationLockID, "expires_at": bson.M{"$lte": now}},
+			bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(migrationLockTTL)}},
+			options.Update().SetUpsert(true),
+		)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+
+		select {
+		case <-c.Done():
+			return errors.Join(domain.ErrMigrationLocked, c.Err())
+		case <-time.After(migrationLockPoll):
+		}
+	}
+}
+
+// renew keeps renewing the lock held by the owner until the context is done.
+func (migrator *migrator) renew(c context.Context, owner string) {
+	for {
+		select {
+		case <-c.Done():
+			return
+		case <-time.After(migrationLockRenewal):
+		}
+
+		if err := migrator.extendLock(c, owner); err != nil && c.Err() == nil {
+			slog.WarnContext(c, "Failed to renew the lock of the migrations", "error", err)
+		}
+	}
+}
+
+// extendLock pushes the expiry of the lock back by migrationLockTTL, if the owner still holds it.
+func (migrator *migrator) extendLock(c context.Context, owner string) error {
+	collection := migrator.database.Collection(migrator.collection)
+
+	_, err := collection.UpdateOne(c,
+		bson.M{"_id": migrationLockID, "owner": owner},
+		bson.M{"$set": bson.M{"expires_at": time.Now().Add(migrationLockTTL)}},
+	)
+	return err
+}
+
+func (migrator *migrator) unlock(c context.Context, owner string) {
+	collection := migrator.database.Collection(migrator.collection)
+
+	if _, err := collection.DeleteOne(c, bson.M{"_id": migrationLockID, "owner": owner}); err != nil {
+		slog.WarnContext(c, "Failed to release the lock of the migrations, it expires on its own", "error", err)
+	}
+}
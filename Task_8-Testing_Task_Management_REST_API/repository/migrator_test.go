@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type MigratorTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	collection *mongo.Collection
+	applied    []int
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *MigratorTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_migrations_db")
+	suite.collection = suite.db.Collection("test_migrations")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *MigratorTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *MigratorTestSuite) SetupTest() {
+	suite.Require().NoError(suite.db.Drop(context.Background()))
+	suite.applied = nil
+}
+
+// migration returns a migration recording that it was applied, and failing with err.
+func (suite *MigratorTestSuite) migration(version int, err error) Migration {
+	return Migration{
+		Version:     version,
+		Description: "test migration",
+		Up: func(c context.Context, database mongo.Database) error {
+			suite.applied = append(suite.applied, version)
+			return err
+		},
+	}
+}
+
+func (suite *MigratorTestSuite) newMigrator(migrations ...Migration) domain.Migrator {
+	return NewMigrator(*suite.db, "test_migrations", migrations)
+}
+
+func (suite *MigratorTestSuite) TestMigrate_AppliesEachMigrationOnce() {
+	migrator := suite.newMigrator(suite.migration(1, nil), suite.migration(2, nil))
+
+	applied, err := migrator.Migrate(context.Background())
+	suite.NoError(err)
+	suite.Len(applied, 2)
+	suite.Equal([]int{1, 2}, suite.applied)
+
+	// a later release adds a migration
+	migrator = suite.newMigrator(suite.migration(1, nil), suite.migration(2, nil), suite.migration(3, nil))
+	applied, err = migrator.Migrate(context.Background())
+	suite.NoError(err)
+	suite.Require().Len(applied, 1)
+	suite.Equal(3, applied[0].Version)
+	suite.Equal([]int{1, 2, 3}, suite.applied)
+
+	// the lock is released
+	count, err := suite.collection.CountDocuments(context.Background(), bson.M{"_id": migrationLockID})
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *MigratorTestSuite) TestMigrate_StopsAtTheFailedMigration() {
+	migrator := suite.newMigrator(suite.migration(1, nil), suite.migration(2, errors.New("boom")), suite.migration(3, nil))
+
+	applied, err := migrator.Migrate(context.Background())
+
+	suite.ErrorContains(err, "migration 2, test migration, failed: boom")
+	suite.Len(applied, 1)
+	suite.Equal([]int{1, 2}, suite.applied)
+
+	statuses, err := migrator.Status(context.Background())
+	suite.NoError(err)
+	suite.NotNil(statuses[0].AppliedAt)
+	suite.Nil(statuses[1].AppliedAt)
+	suite.Nil(statuses[2].AppliedAt)
+}
+
+func (suite *MigratorTestSuite) TestMigrate_WaitsForTheLock() {
+	_, err := suite.collection.InsertOne(context.Background(), bson.M{"_id": migrationLockID, "owner": "other", "expires_at": time.Now().Add(time.Minute)})
+	suite.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = suite.newMigrator(suite.migration(1, nil)).Migrate(ctx)
+
+	suite.ErrorIs(err, domain.ErrMigrationLocked)
+	suite.Empty(suite.applied)
+}
+
+func (suite *MigratorTestSuite) TestExtendLock() {
+	expiresAt := time.Now().Add(time.Minute)
+	_, err := suite.collection.InsertOne(context.Background(), bson.M{"_id": migrationLockID, "owner": "me", "expires_at": expiresAt})
+	suite.Require().NoError(err)
+	migrator := suite.newMigrator().(*migrator)
+
+	// only the instance holding the lock renews it
+	suite.NoError(migrator.extendLock(context.Background(), "other"))
+	var lock struct {
+		ExpiresAt time.Time `bson:"expires_at"`
+	}
+	suite.Require().NoError(suite.collection.FindOne(context.Background(), bson.M{"_id": migrationLockID}).Decode(&lock))
+	suite.WithinDuration(expiresAt, lock.ExpiresAt, time.Second)
+
+	suite.NoError(migrator.extendLock(context.Background(), "me"))
+	suite.Require().NoError(suite.collection.FindOne(context.Background(), bson.M{"_id": migrationLockID}).Decode(&lock))
+	suite.WithinDuration(time.Now().Add(migrationLockTTL), lock.ExpiresAt, time.Second)
+}
+
+func (suite *MigratorTestSuite) TestMigrate_TakesOverAnExpiredLock() {
+	_, err := suite.collection.InsertOne(context.Background(), bson.M{"_id": migrationLockID, "owner": "dead", "expires_at": time.Now().Add(-time.Minute)})
+	suite.Require().NoError(err)
+
+	_, err = suite.newMigrator(suite.migration(1, nil)).Migrate(context.Background())
+
+	suite.NoError(err)
+	suite.Equal([]int{1}, suite.applied)
+}
+
+func (suite *MigratorTestSuite) TestMigrations_UniqueEmail() {
+	_, err := NewMigrator(*suite.db, domain.CollectionMigration, Migrations).Migrate(context.Background())
+	suite.Require().NoError(err)
+
+	repo := NewUserRepo(*suite.db, domain.CollectionUser)
+	suite.NoError(repo.Create(context.Background(), &domain.User{Name: "first", Email: "user@example.com"}))
+	err = repo.Create(context.Background(), &domain.User{Name: "second", Email: "user@example.com"})
+
+	suite.ErrorIs(err, domain.ErrUserExists)
+}
+
+func (suite *MigratorTestSuite) TestNewMigrator_UnsortedMigrations() {
+	suite.Panics(func() {
+		suite.newMigrator(suite.migration(2, nil), suite.migration(1, nil))
+	})
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
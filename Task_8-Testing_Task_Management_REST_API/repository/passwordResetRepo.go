@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type passwordResetRepo struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewPasswordResetRepo(database mongo.Database, collection string) domain.PasswordResetRepository {
+	return &passwordResetRepo{
+		database:   database,
+		collection: collection,
+	}
+}
+
+// Create inserts a new password reset into the database.
+// It assigns a fresh ID to the reset before inserting it.
+func (resetRepo *passwordResetRepo) Create(c context.Context, reset *domain.PasswordReset) error {
+	collection := resetRepo.database.Collection(resetRepo.collection)
+
+	reset.ID = primitive.NewObjectID()
+	_, err := collection.InsertOne(c, reset)
+	return err
+}
+
+// GetByTokenHash retrieves the password reset whose token has the given hash.
+// It returns domain.ErrInvalidToken if there is none.
+func (resetRepo *passwordResetRepo) GetByTokenHash(c context.Context, tokenHash string) (*domain.PasswordReset, error) {
+	collection := resetRepo.database.Collection(resetRepo.collection)
+
+	var reset domain.PasswordReset
+	err := collection.FindOne(c, bson.M{"token_hash": tokenHash}).Decode(&reset)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &reset, nil
+}
+
+// MarkUsed records that the password reset has been used.
+// The update only applies to a reset that has not been used yet, so that two concurrent requests
+// cannot both use the same token; the losing one gets domain.ErrInvalidToken.
+func (resetRepo *passwordResetRepo) MarkUsed(c context.Context, resetID primitive.ObjectID, usedAt time.Time) error {
+	collection := resetRepo.database.Collection(resetRepo.collection)
+
+	updateResult, err := collection.UpdateOne(c,
+		bson.M{"_id": resetID, "used_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"used_at": usedAt}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return domain.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes every password reset of the user with the given ID.
+func (resetRepo *passwordResetRepo) DeleteByUserID(c context.Context, userID primitive.ObjectID) error {
+	collection := resetRepo.database.Collection(resetRepo.collection)
+
+	_, err := collection.DeleteMany(c, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type PasswordResetRepoTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	repo       *passwordResetRepo
+	collection *mongo.Collection
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *PasswordResetRepoTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_db")
+	suite.repo = &passwordResetRepo{
+		database:   *suite.db,
+		collection: "test_password_resets",
+	}
+	suite.collection = suite.db.Collection("test_password_resets")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *PasswordResetRepoTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *PasswordResetRepoTestSuite) SetupTest() {
+	// clear the password reset collection before each test
+	suite.collection.Drop(context.Background())
+}
+
+func (suite *PasswordResetRepoTestSuite) newReset(userID primitive.ObjectID, tokenHash string) *domain.PasswordReset {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	reset := &domain.PasswordReset{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	}
+
+	err := suite.repo.Create(context.Background(), reset)
+	suite.NoError(err)
+
+	return reset
+}
+
+func (suite *PasswordResetRepoTestSuite) TestGetByTokenHash() {
+	reset := suite.newReset(primitive.NewObjectID(), "hash")
+
+	found, err := suite.repo.GetByTokenHash(context.Background(), "hash")
+	suite.NoError(err)
+	suite.Equal(reset.ID, found.ID)
+	suite.Equal(reset.ExpiresAt, found.ExpiresAt)
+	suite.Nil(found.UsedAt)
+
+	_, err = suite.repo.GetByTokenHash(context.Background(), "unknown hash")
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func (suite *PasswordResetRepoTestSuite) TestMarkUsed_OnlyOnce() {
+	reset := suite.newReset(primitive.NewObjectID(), "hash")
+
+	err := suite.repo.MarkUsed(context.Background(), reset.ID, time.Now())
+	suite.NoError(err)
+
+	found, err := suite.repo.GetByTokenHash(context.Background(), "hash")
+	suite.NoError(err)
+	suite.NotNil(found.UsedAt)
+
+	// a second use of the same token is rejected
+	err = suite.repo.MarkUsed(context.Background(), reset.ID, time.Now())
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func (suite *PasswordResetRepoTestSuite) TestDeleteByUserID() {
+	userID := primitive.NewObjectID()
+	otherUserID := primitive.NewObjectID()
+
+	suite.newReset(userID, "first hash")
+	suite.newReset(userID, "second hash")
+	suite.newReset(otherUserID, "other hash")
+
+	err := suite.repo.DeleteByUserID(context.Background(), userID)
+	suite.NoError(err)
+
+	// check only the resets of the given user are removed
+	count, err := suite.collection.CountDocuments(context.Background(), bson.M{"user_id": userID})
+	suite.NoError(err)
+	suite.Zero(count)
+
+	count, err = suite.collection.CountDocuments(context.Background(), bson.M{"user_id": otherUserID})
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+func TestPasswordResetRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetRepoTestSuite))
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type personalAccessTokenRepo struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewPersonalAccessTokenRepo(database mongo.Database, collection string) domain.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepo{
+		database:   database,
+		collection: collection,
+	}
+}
+
+// Create inserts a new personal access token into the database.
+// It assigns a fresh ID to the token before inserting it.
+func (tokenRepo *personalAccessTokenRepo) Create(c context.Context, token *domain.PersonalAccessToken) error {
+	collection := tokenRepo.database.Collection(tokenRepo.collection)
+
+	token.ID = primitive.NewObjectID()
+	_, err := collection.InsertOne(c, token)
+	return err
+}
+
+// GetByTokenHash retrieves the personal access token with the given hash.
+// It returns domain.ErrInvalidToken if there is none.
+func (tokenRepo *personalAccessTokenRepo) GetByTokenHash(c context.Context, tokenHash string) (*domain.PersonalAccessToken, error) {
+	collection := tokenRepo.database.Collection(tokenRepo.collection)
+
+	var token domain.PersonalAccessToken
+	err := collection.FindOne(c, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetByUserID retrieves every personal access token of the user, newest first.
+func (tokenRepo *personalAccessTokenRepo) GetByUserID(c context.Context, userID primitive.ObjectID) ([]domain.PersonalAccessToken, error) {
+	collection := tokenRepo.database.Collection(tokenRepo.collection)
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := collection.Find(c, bson.M{"user_id": userID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []domain.PersonalAccessToken{}
+	if err = cursor.All(c, &tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// SetLastUsed records when the personal access token was last used.
+func (tokenRepo *personalAccessTokenRepo) SetLastUsed(c context.Context, tokenID primitive.ObjectID, usedAt time.Time) error {
+	collection := tokenRepo.database.Collection(tokenRepo.collection)
+
+	_, err := collection.UpdateOne(c, bson.M{"_id": tokenID}, bson.M{"$set": bson.M{"last_used_at": usedAt}})
+	return err
+}
+
+// Delete removes the personal access token with the given ID, if it belongs to the user.
+// It returns domain.ErrTokenNotFound if the ID is malformed or the user has no such token.
+func (tokenRepo *personalAccessTokenRepo) Delete(c context.Context, userID primitive.ObjectID, tokenID string) error {
+	collection := tokenRepo.database.Collection(tokenRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(tokenID)
+	if err != nil {
+		return domain.ErrTokenNotFound
+	}
+
+	deleteResult, err := collection.DeleteOne(c, bson.M{"_id": objID, "user_id": userID})
+	if err != nil {
+		return err
+	}
+
+	if deleteResult.DeletedCount == 0 {
+		return domain.ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUserID removes every personal access token of the user with the given ID.
+func (tokenRepo *personalAccessTokenRepo) DeleteByUserID(c context.Context, userID primitive.ObjectID) error {
+	collection := tokenRepo.database.Collection(tokenRepo.collection)
+
+	_, err := collection.DeleteMany(c, bson.M{"user_id": userID})
+	return err
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type PersonalAccessTokenRepoTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	repo       *personalAccessTokenRepo
+	collection *mongo.Collection
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *PersonalAccessTokenRepoTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_db")
+	suite.repo = &personalAccessTokenRepo{
+		database:   *suite.db,
+		collection: "test_personal_access_tokens",
+	}
+	suite.collection = suite.db.Collection("test_personal_access_tokens")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *PersonalAccessTokenRepoTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *PersonalAccessTokenRepoTestSuite) SetupTest() {
+	// clear the token collection before each test
+	suite.collection.Drop(context.Background())
+}
+
+func (suite *PersonalAccessTokenRepoTestSuite) newToken(userID primitive.ObjectID, tokenHash string, createdAt time.Time) *domain.PersonalAccessToken {
+	token := &domain.PersonalAccessToken{
+		UserID:    userID,
+		Name:      "ci",
+		Scopes:    []string{domain.PermissionTasksRead},
+		TokenHash: tokenHash,
+		CreatedAt: createdAt.UTC().Truncate(time.Millisecond),
+	}
+
+	err := suite.repo.Create(context.Background(), token)
+	suite.NoError(err)
+
+	return token
+}
+
+func (suite *PersonalAccessTokenRepoTestSuite) TestGetByTokenHash() {
+	token := suite.newToken(primitive.NewObjectID(), "hash", time.Now())
+
+	found, err := suite.repo.GetByTokenHash(context.Background(), "hash")
+	suite.NoError(err)
+	suite.Equal(token.ID, found.ID)
+	suite.Equal(token.Scopes, found.Scopes)
+	suite.Nil(found.ExpiresAt)
+
+	_, err = suite.repo.GetByTokenHash(context.Background(), "unknown hash")
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+}
+
+func (suite *PersonalAccessTokenRepoTestSuite) TestGetByUserID_NewestFirst() {
+	userID := primitive.NewObjectID()
+	older := suite.newToken(userID, "older hash", time.Now().Add(-time.Hour))
+	newer := suite.newToken(userID, "newer hash", time.Now())
+	suite.newToken(primitive.NewObjectID(), "other hash", time.Now())
+
+	tokens, err := suite.repo.GetByUserID(context.Background(), userID)
+	suite.NoError(err)
+	suite.Len(tokens, 2)
+	suite.Equal(newer.ID, tokens[0].ID)
+	suite.Equal(older.ID, tokens[1].ID)
+}
+
+func (suite *PersonalAccessTokenRepoTestSuite) TestSetLastUsed() {
+	token := suite.newToken(primitive.NewObjectID(), "hash", time.Now())
+	usedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	err := suite.repo.SetLastUsed(context.Background(), token.ID, usedAt)
+	suite.NoError(err)
+
+	found, err := suite.repo.GetByTokenHash(context.Background(), "hash")
+	suite.NoError(err)
+	suite.Equal(usedAt, *found.LastUsedAt)
+}
+
+func (suite *PersonalAccessTokenRepoTestSuite) TestDelete_OnlyOwnTokens() {
+	userID := primitive.NewObjectID()
+	token := suite.newToken(userID, "hash", time.Now())
+
+	// another user cannot revoke the token
+	err := suite.repo.Delete(context.Background(), primitive.NewObjectID(), token.ID.Hex())
+	suite.ErrorIs(err, domain.ErrTokenNotFound)
+
+	err = suite.repo.Delete(context.Background(), userID, token.ID.Hex())
+	suite.NoError(err)
+
+	_, err = suite.repo.GetByTokenHash(context.Background(), "hash")
+	suite.ErrorIs(err, domain.ErrInvalidToken)
+
+	err = suite.repo.Delete(context.Background(), userID, "malformed")
+	suite.ErrorIs(err, domain.ErrTokenNotFound)
+}
+
+func (suite *PersonalAccessTokenRepoTestSuite) TestDeleteByUserID() {
+	userID := primitive.NewObjectID()
+	suite.newToken(userID, "hash", time.Now())
+	suite.newToken(userID, "other hash", time.Now())
+	otherToken := suite.newToken(primitive.NewObjectID(), "another user's hash", time.Now())
+
+	err := suite.repo.DeleteByUserID(context.Background(), userID)
+	suite.NoError(err)
+
+	tokens, err := suite.repo.GetByUserID(context.Background(), userID)
+	suite.NoError(err)
+	suite.Empty(tokens)
+
+	// the tokens of other users are kept
+	token, err := suite.repo.GetByTokenHash(context.Background(), "another user's hash")
+	suite.NoError(err)
+	suite.Equal(otherToken.ID, token.ID)
+}
+
+func TestPersonalAccessTokenRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenRepoTestSuite))
+}
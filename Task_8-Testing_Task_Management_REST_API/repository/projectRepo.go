@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type projectRepo struct {
+	database   mongo.Database
+	collection string
+}
+
+func NewProjectRepo(database mongo.Database, collection string) domain.ProjectRepository {
+	return &projectRepo{
+		database:   database,
+		collection: collection,
+	}
+}
+
+// Create inserts a new project into the database.
+// It assigns a fresh ID to the project before inserting it.
+func (projectRepo *projectRepo) Create(c context.Context, project *domain.Project) error {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	project.ID = primitive.NewObjectID()
+	_, err := collection.InsertOne(c, project)
+	return err
+}
+
+// GetByID retrieves a project by its ID.
+// It returns domain.ErrProjectNotFound if the ID is malformed or no project matches it.
+func (projectRepo *projectRepo) GetByID(c context.Context, projectID string) (*domain.Project, error) {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	var project domain.Project
+	err = collection.FindOne(c, bson.M{"_id": objID}).Decode(&project)
+	if err == mongo.ErrNoDocuments {
+		return nil, domain.ErrProjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// GetByMember retrieves every project the user with the given ID is a member of, whatever their role.
+func (projectRepo *projectRepo) GetByMember(c context.Context, userID string) ([]domain.Project, error) {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return []domain.Project{}, nil
+	}
+
+	cursor, err := collection.Find(c, bson.M{"members.user_id": userObjID})
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []domain.Project{}
+	if err = cursor.All(c, &projects); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Update overwrites the name and description of an existing project.
+func (projectRepo *projectRepo) Update(c context.Context, project *domain.Project) error {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":        project.Name,
+			"description": project.Description,
+		},
+	}
+
+	updateResult, err := collection.UpdateOne(c, bson.M{"_id": project.ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the project with the given ID.
+func (projectRepo *projectRepo) Delete(c context.Context, projectID string) error {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return domain.ErrProjectNotFound
+	}
+
+	deleteResult, err := collection.DeleteOne(c, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+
+	if deleteResult.DeletedCount == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// SetMember adds a user to the project, or changes their role if they already are a member.
+func (projectRepo *projectRepo) SetMember(c context.Context, projectID string, member domain.ProjectMember) error {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return domain.ErrProjectNotFound
+	}
+
+	// first try to change the role of an existing member
+	updateResult, err := collection.UpdateOne(c,
+		bson.M{"_id": objID, "members.user_id": member.UserID},
+		bson.M{"$set": bson.M{"members.$.role": member.Role}},
+	)
+	if err != nil {
+		return err
+	}
+	if updateResult.MatchedCount > 0 {
+		return nil
+	}
+
+	// otherwise add the user, unless a concurrent request already did
+	updateResult, err = collection.UpdateOne(c,
+		bson.M{"_id": objID, "members.user_id": bson.M{"$ne": member.UserID}},
+		bson.M{"$push": bson.M{"members": member}},
+	)
+	if err != nil {
+		return err
+	}
+	if updateResult.MatchedCount == 0 {
+		count, err := collection.CountDocuments(c, bson.M{"_id": objID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrProjectNotFound
+		}
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from the project.
+// It returns domain.ErrNotMember if the user is not a member of the project.
+func (projectRepo *projectRepo) RemoveMember(c context.Context, projectID string, userID string) error {
+	collection := projectRepo.database.Collection(projectRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return domain.ErrProjectNotFound
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrNotMember
+	}
+
+	updateResult, err := collection.UpdateOne(c,
+		bson.M{"_id": objID},
+		bson.M{"$pull": bson.M{"members": bson.M{"user_id": userObjID}}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return domain.ErrProjectNotFound
+	}
+	if updateResult.ModifiedCount == 0 {
+		return domain.ErrNotMember
+	}
+
+	return nil
+}
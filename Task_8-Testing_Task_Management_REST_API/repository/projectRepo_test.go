@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type ProjectRepoTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	repo       *projectRepo
+	collection *mongo.Collection
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *ProjectRepoTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_db")
+	suite.repo = &projectRepo{
+		database:   *suite.db,
+		collection: "test_projects",
+	}
+	suite.collection = suite.db.Collection("test_projects")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *ProjectRepoTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *ProjectRepoTestSuite) SetupTest() {
+	// clear the project collection before each test
+	suite.collection.Drop(context.Background())
+}
+
+func (suite *ProjectRepoTestSuite) newProject(owner primitive.ObjectID) *domain.Project {
+	project := &domain.Project{
+		Name:      "Test Project",
+		Members:   []domain.ProjectMember{{UserID: owner, Role: domain.ProjectRoleOwner}},
+		CreatedBy: owner,
+		CreatedAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+
+	err := suite.repo.Create(context.Background(), project)
+	suite.Require().NoError(err)
+
+	return project
+}
+
+func (suite *ProjectRepoTestSuite) TestCreateAndGetByID() {
+	project := suite.newProject(primitive.NewObjectID())
+
+	fetched, err := suite.repo.GetByID(context.Background(), project.ID.Hex())
+
+	suite.NoError(err)
+	suite.Equal(project, fetched)
+}
+
+func (suite *ProjectRepoTestSuite) TestGetByID_NotFound() {
+	_, err := suite.repo.GetByID(context.Background(), primitive.NewObjectID().Hex())
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+
+	_, err = suite.repo.GetByID(context.Background(), "not-an-id")
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+}
+
+func (suite *ProjectRepoTestSuite) TestMembers() {
+	owner := primitive.NewObjectID()
+	member := primitive.NewObjectID()
+	project := suite.newProject(owner)
+	suite.newProject(primitive.NewObjectID())
+
+	// adding then promoting the same user keeps a single membership
+	err := suite.repo.SetMember(context.Background(), project.ID.Hex(), domain.ProjectMember{UserID: member, Role: domain.ProjectRoleViewer})
+	suite.NoError(err)
+	err = suite.repo.SetMember(context.Background(), project.ID.Hex(), domain.ProjectMember{UserID: member, Role: domain.ProjectRoleMember})
+	suite.NoError(err)
+
+	fetched, err := suite.repo.GetByID(context.Background(), project.ID.Hex())
+	suite.NoError(err)
+	suite.Len(fetched.Members, 2)
+	suite.Equal(domain.ProjectRoleMember, fetched.Members[1].Role)
+
+	projects, err := suite.repo.GetByMember(context.Background(), member.Hex())
+	suite.NoError(err)
+	suite.Len(projects, 1)
+	suite.Equal(project.ID, projects[0].ID)
+
+	err = suite.repo.RemoveMember(context.Background(), project.ID.Hex(), member.Hex())
+	suite.NoError(err)
+	err = suite.repo.RemoveMember(context.Background(), project.ID.Hex(), member.Hex())
+	suite.ErrorIs(err, domain.ErrNotMember)
+
+	err = suite.repo.SetMember(context.Background(), primitive.NewObjectID().Hex(), domain.ProjectMember{UserID: member, Role: domain.ProjectRoleViewer})
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+}
+
+func (suite *ProjectRepoTestSuite) TestUpdateAndDelete() {
+	project := suite.newProject(primitive.NewObjectID())
+
+	project.Name = "Renamed Project"
+	project.Description = "Updated Description"
+	err := suite.repo.Update(context.Background(), project)
+	suite.NoError(err)
+
+	fetched, err := suite.repo.GetByID(context.Background(), project.ID.Hex())
+	suite.NoError(err)
+	suite.Equal("Renamed Project", fetched.Name)
+	suite.Equal("Updated Description", fetched.Description)
+
+	err = suite.repo.Delete(context.Background(), project.ID.Hex())
+	suite.NoError(err)
+	err = suite.repo.Delete(context.Background(), project.ID.Hex())
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+}
+
+func TestProjectRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectRepoTestSuite))
+}
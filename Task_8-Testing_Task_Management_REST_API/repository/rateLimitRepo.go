@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type rateLimitRepo struct {
+	database   mongo.Database
+	collection string
+}
+
+// NewRateLimitRepo creates a rate limit store shared by every replica of the API through the database.
+func NewRateLimitRepo(database mongo.Database, collection string) domain.RateLimitStore {
+	return &rateLimitRepo{
+		database:   database,
+		collection: collection,
+	}
+}
+
+// Take takes a token from the bucket with the given key, which starts full.
+// The refill and the take happen in a single atomic update, so that concurrent requests are all counted.
+func (rateLimitRepo *rateLimitRepo) Take(c context.Context, key string, limit domain.RateLimit, now time.Time) (domain.RateLimitResult, error) {
+	collection := rateLimitRepo.database.Collection(rateLimitRepo.collection)
+
+	capacity := float64(limit.Requests)
+	perMillisecond := capacity / float64(limit.Period.Milliseconds())
+
+	// refill the bucket for the time elapsed since its last update, then take a token if one is left
+	refilled := bson.M{"$min": bson.A{
+		capacity,
+		bson.M{"$add": bson.A{
+			bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+			bson.M{"$multiply": bson.A{
+				bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{now, bson.M{"$ifNull": bson.A{"$updated_at", now}}}}}},
+				perMillisecond,
+			}},
+		}},
+	}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tokens": refilled}}},
+		{{Key: "$set", Value: bson.M{
+			"allowed":    bson.M{"$gte": bson.A{"$tokens", 1}},
+			"tokens":     bson.M{"$cond": bson.A{bson.M{"$gte": bson.A{"$tokens", 1}}, bson.M{"$subtract": bson.A{"$tokens", 1}}, "$tokens"}},
+			"updated_at": now,
+			// the bucket is full again by then, and can be dropped
+			"expires_at": now.Add(limit.Period),
+		}}},
+	}
+	updateOptions := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var bucket struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+	err := collection.FindOneAndUpdate(c, bson.M{"_id": key}, update, updateOptions).Decode(&bucket)
+	if err != nil {
+		return domain.RateLimitResult{}, err
+	}
+
+	return limit.Result(bucket.Tokens, bucket.Allowed), nil
+}
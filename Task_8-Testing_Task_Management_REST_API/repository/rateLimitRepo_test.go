@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+type RateLimitRepoTestSuite struct {
+	suite.Suite
+	db         *mongo.Database
+	repo       *rateLimitRepo
+	collection *mongo.Collection
+}
+
+// SetupSuite runs once before any test in the suite
+func (suite *RateLimitRepoTestSuite) SetupSuite() {
+	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+
+	client, err := mongo.Connect(context.Background(), clientOptions)
+	if err != nil {
+		suite.T().Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	err = client.Ping(context.Background(), readpref.Primary())
+	if err != nil {
+		suite.T().Fatalf("Failed to ping MongoDB: %v", err)
+	}
+
+	suite.db = client.Database("test_db")
+	suite.repo = &rateLimitRepo{
+		database:   *suite.db,
+		collection: "test_rate_limits",
+	}
+	suite.collection = suite.db.Collection("test_rate_limits")
+}
+
+// TearDownSuite runs once after all tests in the suite have finished
+func (suite *RateLimitRepoTestSuite) TearDownSuite() {
+	if err := suite.db.Drop(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to drop test database: %v", err)
+	}
+	if err := suite.db.Client().Disconnect(context.Background()); err != nil {
+		suite.T().Fatalf("Failed to disconnect from MongoDB: %v", err)
+	}
+}
+
+// setup tests before each test
+func (suite *RateLimitRepoTestSuite) SetupTest() {
+	// clear the rate limit collection before each test
+	suite.collection.Drop(context.Background())
+}
+
+func (suite *RateLimitRepoTestSuite) TestTake_AllowsBurstThenRejects() {
+	limit := domain.RateLimit{Requests: 2, Period: time.Minute}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	result, err := suite.repo.Take(context.Background(), "client", limit, now)
+	suite.NoError(err)
+	suite.True(result.Allowed)
+	suite.Equal(1, result.Remaining)
+
+	result, err = suite.repo.Take(context.Background(), "client", limit, now)
+	suite.NoError(err)
+	suite.True(result.Allowed)
+	suite.Equal(0, result.Remaining)
+
+	result, err = suite.repo.Take(context.Background(), "client", limit, now)
+	suite.NoError(err)
+	suite.False(result.Allowed)
+	suite.Equal(time.Second*30, result.RetryAfter)
+
+	// other clients have their own bucket
+	result, err = suite.repo.Take(context.Background(), "another client", limit, now)
+	suite.NoError(err)
+	suite.True(result.Allowed)
+}
+
+func (suite *RateLimitRepoTestSuite) TestTake_Refills() {
+	limit := domain.RateLimit{Requests: 2, Period: time.Minute}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	suite.repo.Take(context.Background(), "client", limit, now)
+	suite.repo.Take(context.Background(), "client", limit, now)
+
+	// one token is back after half of the period
+	result, err := suite.repo.Take(context.Background(), "client", limit, now.Add(time.Second*30))
+	suite.NoError(err)
+	suite.True(result.Allowed)
+	suite.Equal(0, result.Remaining)
+
+	// and the bucket never holds more than the limit
+	result, err = suite.repo.Take(context.Background(), "client", limit, now.Add(time.Hour))
+	suite.NoError(err)
+	suite.True(result.Allowed)
+	suite.Equal(1, result.Remaining)
+}
+
+func TestRateLimitRepoTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitRepoTestSuite))
+}
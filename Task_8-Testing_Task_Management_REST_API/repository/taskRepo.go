@@ -5,11 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type taskRepo struct {
@@ -55,17 +55,20 @@ func (taskRepo *taskRepo) GetTasks(c context.Context) ([]domain.Task, error) {
 }
 
 // GetTaskByID retrieves a task from the database based on the given task ID.
-// It returns the retrieved task and an error, if any.
+// It returns domain.ErrTaskNotFound if no task matches the ID.
 func (taskRepo *taskRepo) GetTaskByID(c context.Context, taskID string) (domain.Task, error) {
 	collection := taskRepo.database.Collection(taskRepo.collection)
 
 	var task domain.Task
 	obj_ID, err := primitive.ObjectIDFromHex(taskID)
 	if err != nil {
-		return task, err
+		return task, domain.ErrTaskNotFound
 	}
 
 	err = collection.FindOne(c, bson.M{"_id": obj_ID}).Decode(&task)
+	if err == mongo.ErrNoDocuments {
+		return task, domain.ErrTaskNotFound
+	}
 	if err != nil {
 		return task, err
 	}
@@ -114,7 +117,7 @@ func (taskRepo *taskRepo) UpdateTask(c context.Context, taskID string, updated_t
 	updateResult, err := collection.UpdateOne(c, filter, update)
 
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	if updateResult.MatchedCount == 0 {
@@ -145,7 +148,7 @@ func (taskRepo *taskRepo) DeleteTask(c context.Context, taskID string) error {
 	deleteResult, err := collection.DeleteOne(c, filter)
 
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	if deleteResult.DeletedCount == 0 {
@@ -155,3 +158,249 @@ func (taskRepo *taskRepo) DeleteTask(c context.Context, taskID string) error {
 
 	return nil
 }
+
+// AddAttachment appends the metadata of an attachment to the task with the given ID.
+// It assigns a fresh ID to the attachment and returns domain.ErrTaskNotFound if no task matches the ID.
+func (taskRepo *taskRepo) AddAttachment(c context.Context, taskID string, attachment *domain.Attachment) error {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	obj_ID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+
+	attachment.ID = primitive.NewObjectID()
+	update := bson.M{
+		"$push": bson.M{"attachments": attachment},
+	}
+
+	updateResult, err := collection.UpdateOne(c, bson.M{"_id": obj_ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// RemoveAttachment removes the metadata of an attachment from the task with the given ID.
+// It returns domain.ErrAttachmentNotFound if the task has no attachment with the given ID.
+func (taskRepo *taskRepo) RemoveAttachment(c context.Context, taskID string, attachmentID string) error {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	obj_ID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+
+	attachmentObjID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return domain.ErrAttachmentNotFound
+	}
+
+	update := bson.M{
+		"$pull": bson.M{"attachments": bson.M{"_id": attachmentObjID}},
+	}
+
+	updateResult, err := collection.UpdateOne(c, bson.M{"_id": obj_ID, "attachments._id": attachmentObjID}, update)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.ModifiedCount == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+// CountAttachmentsByHash counts the attachments, across all tasks, whose content has the given hash.
+// It is used to find out whether a stored blob is still referenced before deleting it.
+func (taskRepo *taskRepo) CountAttachmentsByHash(c context.Context, hash string) (int64, error) {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"attachments.hash": hash}}},
+		{{Key: "$unwind", Value: "$attachments"}},
+		{{Key: "$match", Value: bson.M{"attachments.hash": hash}}},
+		{{Key: "$count", Value: "count"}},
+	}
+
+	cursor, err := collection.Aggregate(c, pipeline)
+	if err != nil {
+		return 0, err
+	}
+
+	var result []struct {
+		Count int64 `bson:"count"`
+	}
+	if err = cursor.All(c, &result); err != nil {
+		return 0, err
+	}
+
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return result[0].Count, nil
+}
+
+// SetAssignees replaces the users assigned to the task with the given ID.
+func (taskRepo *taskRepo) SetAssignees(c context.Context, taskID string, assignees []primitive.ObjectID) error {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	obj_ID, err := primitive.ObjectIDFromHex(taskID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+
+	update := bson.M{
+		"$set": bson.M{"assignees": assignees},
+	}
+
+	updateResult, err := collection.UpdateOne(c, bson.M{"_id": obj_ID}, update)
+	if err != nil {
+		return err
+	}
+
+	if updateResult.MatchedCount == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// GetTasksByAssignee retrieves all tasks the user with the given ID is assigned to.
+func (taskRepo *taskRepo) GetTasksByAssignee(c context.Context, userID string) ([]domain.Task, error) {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return []domain.Task{}, nil
+	}
+
+	cursor, err := collection.Find(c, bson.M{"assignees": userObjID})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []domain.Task{}
+	if err = cursor.All(c, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// UnassignUser removes the user with the given ID from the assignees of every task.
+func (taskRepo *taskRepo) UnassignUser(c context.Context, userID string) error {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = collection.UpdateMany(c,
+		bson.M{"assignees": userObjID},
+		bson.M{"$pull": bson.M{"assignees": userObjID}},
+	)
+	return err
+}
+
+// ReassignUser replaces the user with ID userID by the user with ID replacementID in the assignees of every task.
+// Tasks the replacement is already assigned to only lose the replaced user.
+func (taskRepo *taskRepo) ReassignUser(c context.Context, userID string, replacementID string) error {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	replacementObjID, err := primitive.ObjectIDFromHex(replacementID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	// swap the users in place where the replacement is not assigned yet
+	updateOptions := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"replaced": userObjID}},
+	})
+	_, err = collection.UpdateMany(c,
+		bson.M{"$and": bson.A{
+			bson.M{"assignees": userObjID},
+			bson.M{"assignees": bson.M{"$ne": replacementObjID}},
+		}},
+		bson.M{"$set": bson.M{"assignees.$[replaced]": replacementObjID}},
+		updateOptions,
+	)
+	if err != nil {
+		return err
+	}
+
+	// the tasks left already have the replacement among their assignees
+	return taskRepo.UnassignUser(c, userID)
+}
+
+// GetTasksByProject retrieves all tasks of the project with the given ID.
+func (taskRepo *taskRepo) GetTasksByProject(c context.Context, projectID string) ([]domain.Task, error) {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	projectObjID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	cursor, err := collection.Find(c, bson.M{"project_id": projectObjID})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []domain.Task{}
+	if err = cursor.All(c, &tasks); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// CountTasksByProject counts the tasks of the project with the given ID.
+func (taskRepo *taskRepo) CountTasksByProject(c context.Context, projectID string) (int64, error) {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	projectObjID, err := primitive.ObjectIDFromHex(projectID)
+	if err != nil {
+		return 0, domain.ErrProjectNotFound
+	}
+
+	return collection.CountDocuments(c, bson.M{"project_id": projectObjID})
+}
+
+// CountTasksByStatus counts the tasks of every project, grouped by their status.
+func (taskRepo *taskRepo) CountTasksByStatus(c context.Context) (map[string]int64, error) {
+	collection := taskRepo.database.Collection(taskRepo.collection)
+
+	pipeline := mongo.Pipeline{{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}}}
+	cursor, err := collection.Aggregate(c, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := cursor.All(c, &groups); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(groups))
+	for _, group := range groups {
+		counts[group.Status] = group.Count
+	}
+
+	return counts, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -127,6 +128,14 @@ func (suite *TaskRepoTestSuite) TestGetTaskByID() {
 	suite.Equal(task.Status, retrievedTask.Status)
 }
 
+func (suite *TaskRepoTestSuite) TestGetTaskByID_NotFound() {
+	_, err := suite.repo.GetTaskByID(context.Background(), primitive.NewObjectID().Hex())
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	_, err = suite.repo.GetTaskByID(context.Background(), "invalid")
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
 func (suite *TaskRepoTestSuite) TestUpdateTask() {
 	originalTask := &domain.Task{
 		Title:       "Original Task",
@@ -183,6 +192,100 @@ func (suite *TaskRepoTestSuite) TestDeleteTask() {
 	suite.Equal(mongo.ErrNoDocuments, err)
 }
 
+func (suite *TaskRepoTestSuite) TestAttachments() {
+	task := &domain.Task{Title: "Task with attachments"}
+	otherTask := &domain.Task{Title: "Other task"}
+
+	suite.NoError(suite.repo.Create(context.Background(), task))
+	suite.NoError(suite.repo.Create(context.Background(), otherTask))
+
+	attachment := &domain.Attachment{FileName: "notes.txt", Hash: "shared-hash"}
+	suite.NoError(suite.repo.AddAttachment(context.Background(), task.ID.Hex(), attachment))
+	suite.NoError(suite.repo.AddAttachment(context.Background(), otherTask.ID.Hex(), &domain.Attachment{FileName: "copy.txt", Hash: "shared-hash"}))
+
+	// check the attachment is stored alongside the task
+	retrievedTask, err := suite.repo.GetTaskByID(context.Background(), task.ID.Hex())
+	suite.NoError(err)
+	suite.Len(retrievedTask.Attachments, 1)
+	suite.Equal(attachment.ID, retrievedTask.Attachments[0].ID)
+
+	// check attachments with the same content are counted across tasks
+	count, err := suite.repo.CountAttachmentsByHash(context.Background(), "shared-hash")
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+
+	suite.NoError(suite.repo.RemoveAttachment(context.Background(), task.ID.Hex(), attachment.ID.Hex()))
+	suite.ErrorIs(suite.repo.RemoveAttachment(context.Background(), task.ID.Hex(), attachment.ID.Hex()), domain.ErrAttachmentNotFound)
+
+	count, err = suite.repo.CountAttachmentsByHash(context.Background(), "shared-hash")
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+func (suite *TaskRepoTestSuite) TestAssignees() {
+	assignee := primitive.NewObjectID()
+	assignedTask := &domain.Task{Title: "Assigned Task"}
+	otherTask := &domain.Task{Title: "Other Task"}
+
+	suite.NoError(suite.repo.Create(context.Background(), assignedTask))
+	suite.NoError(suite.repo.Create(context.Background(), otherTask))
+
+	err := suite.repo.SetAssignees(context.Background(), assignedTask.ID.Hex(), []primitive.ObjectID{assignee, primitive.NewObjectID()})
+	suite.NoError(err)
+
+	// check only the tasks the user is assigned to are retrieved
+	tasks, err := suite.repo.GetTasksByAssignee(context.Background(), assignee.Hex())
+	suite.NoError(err)
+	suite.Len(tasks, 1)
+	suite.Equal(assignedTask.ID, tasks[0].ID)
+	suite.Len(tasks[0].Assignees, 2)
+
+	// check assigning to a missing task fails
+	err = suite.repo.SetAssignees(context.Background(), primitive.NewObjectID().Hex(), []primitive.ObjectID{assignee})
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+func (suite *TaskRepoTestSuite) TestReassignAndUnassignUser() {
+	leaver := primitive.NewObjectID()
+	replacement := primitive.NewObjectID()
+	soloTask := &domain.Task{Title: "Solo Task"}
+	sharedTask := &domain.Task{Title: "Shared Task"}
+
+	suite.NoError(suite.repo.Create(context.Background(), soloTask))
+	suite.NoError(suite.repo.Create(context.Background(), sharedTask))
+	suite.NoError(suite.repo.SetAssignees(context.Background(), soloTask.ID.Hex(), []primitive.ObjectID{leaver}))
+	suite.NoError(suite.repo.SetAssignees(context.Background(), sharedTask.ID.Hex(), []primitive.ObjectID{leaver, replacement}))
+
+	err := suite.repo.ReassignUser(context.Background(), leaver.Hex(), replacement.Hex())
+	suite.NoError(err)
+
+	// check the replacement is assigned once to both tasks and the leaver to none
+	tasks, err := suite.repo.GetTasksByAssignee(context.Background(), replacement.Hex())
+	suite.NoError(err)
+	suite.Len(tasks, 2)
+	for _, task := range tasks {
+		suite.Equal([]primitive.ObjectID{replacement}, task.Assignees)
+	}
+
+	err = suite.repo.UnassignUser(context.Background(), replacement.Hex())
+	suite.NoError(err)
+
+	tasks, err = suite.repo.GetTasksByAssignee(context.Background(), replacement.Hex())
+	suite.NoError(err)
+	suite.Empty(tasks)
+}
+
+func (suite *TaskRepoTestSuite) TestCountTasksByStatus() {
+	for _, status := range []string{domain.TaskStatusPending, domain.TaskStatusPending, domain.TaskStatusDone} {
+		suite.NoError(suite.repo.Create(context.Background(), &domain.Task{Title: "Task", Status: status}))
+	}
+
+	counts, err := suite.repo.CountTasksByStatus(context.Background())
+
+	suite.NoError(err)
+	suite.Equal(map[string]int64{domain.TaskStatusPending: 2, domain.TaskStatusDone: 1}, counts)
+}
+
 func TestTaskRepoTestSuite(t *testing.T) {
 	suite.Run(t, new(TaskRepoTestSuite))
 }
@@ -2,12 +2,15 @@ package repository
 
 import (
 	"Task_8-Testing_Task_Management_REST_API/domain"
+	"regexp"
+	"time"
 
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type userRepo struct {
@@ -24,12 +27,16 @@ func NewUserRepo(database mongo.Database, collection string) domain.UserReposito
 
 // Create inserts a new user into the database.
 // It takes a context and a user object as parameters.
-// It returns an error if the insertion fails.
+// It returns domain.ErrUserExists if another user has the same email, and an error if the insertion fails.
 func (userRepo *userRepo) Create(c context.Context, user *domain.User) error {
 	collection := userRepo.database.Collection(userRepo.collection)
 
 	user.UserID = primitive.NewObjectID()
 	_, err := collection.InsertOne(c, user)
+	// the email is the only unique field besides the _id
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrUserExists
+	}
 	return err
 }
 
@@ -55,16 +62,20 @@ func (userRepo *userRepo) GetByEmail(c context.Context, email string) (*domain.U
 
 // GetByID retrieves a user from the database based on the provided userID.
 // It returns the user and any error encountered during the retrieval process.
+// If the ID is malformed or no user matches it, domain.ErrUserNotFound is returned.
 func (userRepo *userRepo) GetByID(c context.Context, userID string) (*domain.User, error) {
 	collection := userRepo.database.Collection(userRepo.collection)
 
 	var user domain.User
 	objID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
-		return &user, err
+		return &user, domain.ErrUserNotFound
 	}
 
 	err = collection.FindOne(c, bson.M{"_id": objID}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return &user, domain.ErrUserNotFound
+	}
 	if err != nil {
 		return &user, err
 	}
@@ -89,6 +100,108 @@ func (userRepo *userRepo) UpdateUser(c context.Context, user *domain.User) error
 	return nil
 }
 
+// SetPassword replaces the password hash of the user with the given ID and increments their token version,
+// so that the tokens issued with the old password are rejected.
+func (userRepo *userRepo) SetPassword(c context.Context, userID string, hashedPassword string) error {
+	return userRepo.updateByID(c, userID, bson.M{
+		"$set": bson.M{"password": hashedPassword},
+		"$inc": bson.M{"token_version": 1},
+	})
+}
+
+// ReplacePasswordHash replaces the password hash of the user with the given ID by another hash of the same password,
+// leaving their sessions as they are. It reports whether the hash was still oldHash, as a password changed meanwhile
+// must not be overwritten.
+func (userRepo *userRepo) ReplacePasswordHash(c context.Context, userID string, oldHash string, newHash string) (bool, error) {
+	return userRepo.updateIf(c, userID, bson.M{"password": oldHash}, bson.M{"$set": bson.M{"password": newHash}})
+}
+
+// SetRole gives the user with the given ID the role.
+func (userRepo *userRepo) SetRole(c context.Context, userID string, role string) error {
+	return userRepo.updateByID(c, userID, bson.M{"$set": bson.M{"role": role}})
+}
+
+// SetDisabled disables or re-enables the account of the user with the given ID.
+func (userRepo *userRepo) SetDisabled(c context.Context, userID string, disabled bool) error {
+	return userRepo.updateByID(c, userID, bson.M{"$set": bson.M{"disabled": disabled}})
+}
+
+// SetEmailVerified marks the email of the user with the given ID as verified.
+func (userRepo *userRepo) SetEmailVerified(c context.Context, userID string) error {
+	return userRepo.updateByID(c, userID, bson.M{"$set": bson.M{"email_verified": true}})
+}
+
+// ClaimVerificationSend records that a verification link is sent to the user with the given ID at sentAt,
+// unless the last one was sent at sentBefore or later. It reports whether the link can be sent; as the check
+// and the update are a single operation, only one of several concurrent claims succeeds.
+func (userRepo *userRepo) ClaimVerificationSend(c context.Context, userID string, sentBefore time.Time, sentAt time.Time) (bool, error) {
+	// users who were never sent a link may not have the field at all
+	condition := bson.M{"verification_sent_at": bson.M{"$not": bson.M{"$gte": sentBefore}}}
+	return userRepo.updateIf(c, userID, condition, bson.M{"$set": bson.M{"verification_sent_at": sentAt}})
+}
+
+// UpdateTwoFactor saves the two-factor authentication settings of the user, and only those.
+func (userRepo *userRepo) UpdateTwoFactor(c context.Context, user *domain.User) error {
+	return userRepo.updateByID(c, user.UserID.Hex(), bson.M{"$set": bson.M{
+		"totp_secret":        user.TOTPSecret,
+		"two_factor_enabled": user.TwoFactorEnabled,
+		"recovery_codes":     user.RecoveryCodes,
+		"totp_last_step":     user.TOTPLastStep,
+	}})
+}
+
+// UseTOTPStep records that a code of the given time step was accepted for the user with the given ID,
+// unless a code of that step or a later one already was. It reports whether the code can be accepted;
+// as the check and the update are a single operation, a code cannot be accepted twice, even concurrently.
+func (userRepo *userRepo) UseTOTPStep(c context.Context, userID string, step int64) (bool, error) {
+	condition := bson.M{"totp_last_step": bson.M{"$not": bson.M{"$gte": step}}}
+	return userRepo.updateIf(c, userID, condition, bson.M{"$set": bson.M{"totp_last_step": step}})
+}
+
+// UseRecoveryCode removes the recovery code with the given hash from the user with the given ID.
+// It reports whether the user still had it, so that, like UseTOTPStep, a code is only accepted once.
+func (userRepo *userRepo) UseRecoveryCode(c context.Context, userID string, hash string) (bool, error) {
+	condition := bson.M{"recovery_codes": hash}
+	return userRepo.updateIf(c, userID, condition, bson.M{"$pull": bson.M{"recovery_codes": hash}})
+}
+
+// updateByID applies the update to the user with the given ID, leaving the fields it doesn't mention as they are.
+// It returns domain.ErrUserNotFound if no user matches the ID.
+func (userRepo *userRepo) updateByID(c context.Context, userID string, update bson.M) error {
+	updated, err := userRepo.updateIf(c, userID, bson.M{}, update)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// updateIf applies the update to the user with the given ID if they also match the condition,
+// reporting whether they did.
+func (userRepo *userRepo) updateIf(c context.Context, userID string, condition bson.M, update bson.M) (bool, error) {
+	collection := userRepo.database.Collection(userRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, nil
+	}
+
+	filter := bson.M{"_id": objID}
+	for field, value := range condition {
+		filter[field] = value
+	}
+
+	result, err := collection.UpdateOne(c, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
 // AreThereAnyUsers checks if there are any users in the database.
 // It returns true if there are users, false otherwise.
 // An error is returned if there was a problem counting the documents.
@@ -102,3 +215,72 @@ func (userRepo *userRepo) AreThereAnyUsers(c context.Context) (bool, error) {
 
 	return count > 0, nil
 }
+
+// GetUsers retrieves one page of the users matching the filter, ordered by name,
+// together with the total number of matching users.
+func (userRepo *userRepo) GetUsers(c context.Context, filter domain.UserFilter, pagination domain.Pagination) ([]domain.User, int64, error) {
+	collection := userRepo.database.Collection(userRepo.collection)
+
+	query := bson.M{}
+	if filter.Query != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Query), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"email": pattern},
+		}
+	}
+	if filter.Role != "" {
+		query["role"] = filter.Role
+	}
+
+	total, err := collection.CountDocuments(c, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit)
+
+	cursor, err := collection.Find(c, query, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := []domain.User{}
+	if err = cursor.All(c, &users); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// CountActiveByRole counts the users with the given role whose account is not disabled.
+func (userRepo *userRepo) CountActiveByRole(c context.Context, role string) (int64, error) {
+	collection := userRepo.database.Collection(userRepo.collection)
+
+	return collection.CountDocuments(c, bson.M{"role": role, "disabled": bson.M{"$ne": true}})
+}
+
+// DeleteUser removes the user with the given ID.
+// It returns domain.ErrUserNotFound if no user matches the ID.
+func (userRepo *userRepo) DeleteUser(c context.Context, userID string) error {
+	collection := userRepo.database.Collection(userRepo.collection)
+
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	deleteResult, err := collection.DeleteOne(c, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+
+	if deleteResult.DeletedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
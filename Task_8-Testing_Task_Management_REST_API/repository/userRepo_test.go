@@ -4,9 +4,11 @@ import (
 	"Task_8-Testing_Task_Management_REST_API/domain"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -163,6 +165,117 @@ func (suite *UserRepoTestSuite) TestUpdateUser() {
 	suite.Equal("newRole", updatedUser.Role)
 }
 
+func (suite *UserRepoTestSuite) TestSetPassword() {
+	user := &domain.User{Name: "Test Name", Email: "test@example.com", Password: "old hash", Role: "USER", TokenVersion: 2}
+	suite.NoError(suite.repo.Create(context.Background(), user))
+
+	// each update only changes its own fields
+	suite.NoError(suite.repo.SetPassword(context.Background(), user.UserID.Hex(), "new hash"))
+	suite.NoError(suite.repo.SetDisabled(context.Background(), user.UserID.Hex(), true))
+
+	var updatedUser domain.User
+	err := suite.collection.FindOne(context.Background(), bson.M{"_id": user.UserID}).Decode(&updatedUser)
+	suite.NoError(err)
+	suite.Equal("new hash", updatedUser.Password)
+	suite.Equal(3, updatedUser.TokenVersion)
+	suite.True(updatedUser.Disabled)
+	suite.Equal("Test Name", updatedUser.Name)
+}
+
+func (suite *UserRepoTestSuite) TestReplacePasswordHash() {
+	user := &domain.User{Name: "Test Name", Email: "test@example.com", Password: "old hash", Role: "USER", TokenVersion: 2}
+	suite.NoError(suite.repo.Create(context.Background(), user))
+
+	replaced, err := suite.repo.ReplacePasswordHash(context.Background(), user.UserID.Hex(), "old hash", "new hash")
+	suite.NoError(err)
+	suite.True(replaced)
+
+	// a rehash based on a stale hash doesn't overwrite the password
+	replaced, err = suite.repo.ReplacePasswordHash(context.Background(), user.UserID.Hex(), "old hash", "stale hash")
+	suite.NoError(err)
+	suite.False(replaced)
+
+	updatedUser, err := suite.repo.GetByID(context.Background(), user.UserID.Hex())
+	suite.NoError(err)
+	suite.Equal("new hash", updatedUser.Password)
+	suite.Equal(2, updatedUser.TokenVersion)
+}
+
+func (suite *UserRepoTestSuite) TestSetRole() {
+	user := &domain.User{Name: "Test Name", Email: "test@example.com", Password: "hash", Role: "USER"}
+	suite.NoError(suite.repo.Create(context.Background(), user))
+
+	suite.NoError(suite.repo.SetRole(context.Background(), user.UserID.Hex(), "ADMIN"))
+	suite.NoError(suite.repo.SetEmailVerified(context.Background(), user.UserID.Hex()))
+
+	updatedUser, err := suite.repo.GetByID(context.Background(), user.UserID.Hex())
+	suite.NoError(err)
+	suite.Equal("ADMIN", updatedUser.Role)
+	suite.True(updatedUser.EmailVerified)
+	suite.Equal("hash", updatedUser.Password)
+}
+
+func (suite *UserRepoTestSuite) TestSetRole_UserNotFound() {
+	err := suite.repo.SetRole(context.Background(), primitive.NewObjectID().Hex(), "ADMIN")
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+
+	err = suite.repo.SetRole(context.Background(), "invalid", "ADMIN")
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+}
+
+func (suite *UserRepoTestSuite) TestClaimVerificationSend() {
+	user := &domain.User{Name: "Test Name", Email: "test@example.com", Password: "hash", Role: "USER"}
+	suite.NoError(suite.repo.Create(context.Background(), user))
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	// only the first of two claims within the interval succeeds
+	claimed, err := suite.repo.ClaimVerificationSend(context.Background(), user.UserID.Hex(), now.Add(-time.Minute), now)
+	suite.NoError(err)
+	suite.True(claimed)
+
+	claimed, err = suite.repo.ClaimVerificationSend(context.Background(), user.UserID.Hex(), now.Add(-time.Minute), now)
+	suite.NoError(err)
+	suite.False(claimed)
+
+	claimed, err = suite.repo.ClaimVerificationSend(context.Background(), user.UserID.Hex(), now.Add(time.Second), now.Add(time.Minute))
+	suite.NoError(err)
+	suite.True(claimed)
+}
+
+func (suite *UserRepoTestSuite) TestUseTwoFactorCodes() {
+	user := &domain.User{Name: "Test Name", Email: "test@example.com", Password: "hash", Role: "USER"}
+	suite.NoError(suite.repo.Create(context.Background(), user))
+
+	user.TwoFactorEnabled = true
+	user.TOTPSecret = "secret"
+	user.TOTPLastStep = 10
+	user.RecoveryCodes = []string{"first", "second"}
+	suite.NoError(suite.repo.UpdateTwoFactor(context.Background(), user))
+
+	// a step or a recovery code is only accepted once
+	used, err := suite.repo.UseTOTPStep(context.Background(), user.UserID.Hex(), 11)
+	suite.NoError(err)
+	suite.True(used)
+
+	used, err = suite.repo.UseTOTPStep(context.Background(), user.UserID.Hex(), 11)
+	suite.NoError(err)
+	suite.False(used)
+
+	used, err = suite.repo.UseRecoveryCode(context.Background(), user.UserID.Hex(), "first")
+	suite.NoError(err)
+	suite.True(used)
+
+	used, err = suite.repo.UseRecoveryCode(context.Background(), user.UserID.Hex(), "first")
+	suite.NoError(err)
+	suite.False(used)
+
+	updatedUser, err := suite.repo.GetByID(context.Background(), user.UserID.Hex())
+	suite.NoError(err)
+	suite.Equal(int64(11), updatedUser.TOTPLastStep)
+	suite.Equal([]string{"second"}, updatedUser.RecoveryCodes)
+	suite.Equal("hash", updatedUser.Password)
+}
+
 func (suite *UserRepoTestSuite) TestAreThereAnyUsers() {
 	// first check with no users
 	checkUsers, err := suite.repo.AreThereAnyUsers(context.Background())
@@ -187,6 +300,50 @@ func (suite *UserRepoTestSuite) TestAreThereAnyUsers() {
 	suite.True(checkUsers)
 }
 
+func (suite *UserRepoTestSuite) TestGetUsers() {
+	users := []*domain.User{
+		{Name: "Alice", Email: "alice@example.com", Role: "ADMIN"},
+		{Name: "Bob", Email: "bob@example.com", Role: "USER"},
+		{Name: "Carol", Email: "carol@test.org", Role: "USER"},
+	}
+	for _, user := range users {
+		suite.NoError(suite.repo.Create(context.Background(), user))
+	}
+
+	// check the query matches names and emails, ignoring case
+	found, total, err := suite.repo.GetUsers(context.Background(), domain.UserFilter{Query: "EXAMPLE.com"}, domain.NewPagination(1, 1))
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Len(found, 1)
+	suite.Equal("Alice", found[0].Name)
+
+	found, total, err = suite.repo.GetUsers(context.Background(), domain.UserFilter{Role: "USER"}, domain.NewPagination(1, 10))
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Equal("Bob", found[0].Name)
+	suite.Equal("Carol", found[1].Name)
+}
+
+func (suite *UserRepoTestSuite) TestCountActiveByRoleAndDelete() {
+	admin := &domain.User{Name: "Admin", Email: "admin@example.com", Role: "ADMIN"}
+	disabledAdmin := &domain.User{Name: "Disabled Admin", Email: "disabled@example.com", Role: "ADMIN", Disabled: true}
+	suite.NoError(suite.repo.Create(context.Background(), admin))
+	suite.NoError(suite.repo.Create(context.Background(), disabledAdmin))
+
+	count, err := suite.repo.CountActiveByRole(context.Background(), "ADMIN")
+	suite.NoError(err)
+	suite.Equal(int64(1), count)
+
+	err = suite.repo.DeleteUser(context.Background(), admin.UserID.Hex())
+	suite.NoError(err)
+
+	_, err = suite.repo.GetByID(context.Background(), admin.UserID.Hex())
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+
+	err = suite.repo.DeleteUser(context.Background(), admin.UserID.Hex())
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+}
+
 func TestUserRepoTestSuite(t *testing.T) {
 	suite.Run(t, new(UserRepoTestSuite))
 }
@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// blobLocks is held for the hash of a blob while an attachment referring to it is added, and while the blob
+// is deleted once no attachment refers to it, so that a blob is never deleted under a new attachment.
+// The blobs are stored on the disk of the instance, so a lock of the instance is enough.
+var blobLocks = newKeyedMutex()
+
+type attachmentUsecase struct {
+	taskRepository domain.TaskRepository
+	blobStorage    domain.BlobStorage
+	maxSize        int64
+	allowedTypes   map[string]bool
+	roles          domain.Roles
+	contextTimeout time.Duration
+}
+
+func NewAttachmentUsecase(taskRepository domain.TaskRepository, blobStorage domain.BlobStorage, maxSize int64, allowedTypes []string, roles domain.Roles, timeout time.Duration) domain.AttachmentUsecase {
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, contentType := range allowedTypes {
+		if contentType = strings.TrimSpace(contentType); contentType != "" {
+			allowed[strings.ToLower(contentType)] = true
+		}
+	}
+
+	return &attachmentUsecase{
+		taskRepository: taskRepository,
+		blobStorage:    blobStorage,
+		maxSize:        maxSize,
+		allowedTypes:   allowed,
+		roles:          roles,
+		contextTimeout: timeout,
+	}
+}
+
+// Upload stores the content of a new attachment of the task with ID taskID.
+// The MIME type is sniffed from the content rather than trusted from the client,
+// and identical contents are stored only once since blobs are keyed by their SHA-256 hash.
+func (attachmentUC *attachmentUsecase) Upload(c context.Context, taskID string, uploaderID string, fileName string, content io.Reader) (*domain.Attachment, error) {
+	ctx, end := startSpan(c, attachmentUC.contextTimeout, "AttachmentUsecase.Upload")
+	defer end()
+
+	fileName = filepath.Base(strings.TrimSpace(fileName))
+	if fileName == "." || fileName == string(filepath.Separator) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	uploaderObjID, err := primitive.ObjectIDFromHex(uploaderID)
+	if err != nil {
+		return nil, domain.ErrForbidden
+	}
+
+	if _, err = attachmentUC.taskRepository.GetTaskByID(ctx, taskID); err != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	// read one byte past the limit to tell a file of exactly the maximum size from a larger one
+	data, err := io.ReadAll(io.LimitReader(content, attachmentUC.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > attachmentUC.maxSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil || !attachmentUC.allowedTypes[contentType] {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	unlock := blobLocks.Lock(hash)
+	defer unlock()
+
+	exists, err := attachmentUC.blobStorage.Exists(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err = attachmentUC.blobStorage.Put(ctx, hash, bytes.NewReader(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	attachment := &domain.Attachment{
+		UploaderID:  uploaderObjID,
+		FileName:    fileName,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Hash:        hash,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	err = attachmentUC.taskRepository.AddAttachment(ctx, taskID, attachment)
+	if err != nil {
+		if !exists {
+			deleteUnreferencedBlob(ctx, attachmentUC.taskRepository, attachmentUC.blobStorage, hash)
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// Open returns the metadata of an attachment of the task together with a reader of its content.
+// The caller is responsible for closing the reader.
+func (attachmentUC *attachmentUsecase) Open(c context.Context, taskID string, attachmentID string) (*domain.Attachment, io.ReadCloser, error) {
+	ctx, end := startSpan(c, attachmentUC.contextTimeout, "AttachmentUsecase.Open")
+	defer end()
+
+	attachment, err := attachmentUC.find(ctx, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := attachmentUC.blobStorage.Get(ctx, attachment.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// Delete removes an attachment from the task on behalf of its uploader or a user allowed to moderate attachments.
+// The stored content is deleted once no other attachment refers to it.
+func (attachmentUC *attachmentUsecase) Delete(c context.Context, taskID string, attachmentID string, actorID string, actorRole string) error {
+	ctx, end := startSpan(c, attachmentUC.contextTimeout, "AttachmentUsecase.Delete")
+	defer end()
+
+	attachment, err := attachmentUC.find(ctx, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploaderID.Hex() != actorID && !attachmentUC.roles.Has(actorRole, domain.PermissionAttachmentsModerate) {
+		return domain.ErrForbidden
+	}
+
+	err = attachmentUC.taskRepository.RemoveAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return releaseBlob(ctx, attachmentUC.taskRepository, attachmentUC.blobStorage, attachment.Hash)
+}
+
+// find looks up the metadata of an attachment among the attachments of the task.
+func (attachmentUC *attachmentUsecase) find(c context.Context, taskID string, attachmentID string) (*domain.Attachment, error) {
+	task, err := attachmentUC.taskRepository.GetTaskByID(c, taskID)
+	if err != nil {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	for _, attachment := range task.Attachments {
+		if attachment.ID.Hex() == attachmentID {
+			return &attachment, nil
+		}
+	}
+
+	return nil, domain.ErrAttachmentNotFound
+}
+
+// releaseBlob deletes the blob with the given hash if no attachment refers to it anymore.
+func releaseBlob(c context.Context, taskRepository domain.TaskRepository, blobStorage domain.BlobStorage, hash string) error {
+	unlock := blobLocks.Lock(hash)
+	defer unlock()
+
+	return deleteUnreferencedBlob(c, taskRepository, blobStorage, hash)
+}
+
+// deleteUnreferencedBlob is releaseBlob for a caller already holding the lock of the hash.
+func deleteUnreferencedBlob(c context.Context, taskRepository domain.TaskRepository, blobStorage domain.BlobStorage, hash string) error {
+	count, err := taskRepository.CountAttachmentsByHash(c, hash)
+	if err != nil || count > 0 {
+		return err
+	}
+
+	return blobStorage.Delete(c, hash)
+}
+
+// keyedMutex is a set of mutexes identified by keys, each of them kept only while it is held or waited for.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	holders int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+// Lock waits for the mutex of the key and returns the function unlocking it.
+func (keyed *keyedMutex) Lock(key string) func() {
+	keyed.mu.Lock()
+	lock, ok := keyed.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		keyed.locks[key] = lock
+	}
+	lock.holders++
+	keyed.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+
+		keyed.mu.Lock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(keyed.locks, key)
+		}
+		keyed.mu.Unlock()
+	}
+}
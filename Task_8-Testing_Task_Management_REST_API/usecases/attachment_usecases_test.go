@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AttachmentUsecaseTestSuite struct {
+	suite.Suite
+	attachmentUsecase *attachmentUsecase
+	taskMockRepo      *mocks.TaskRepository
+	mockBlobStorage   *mocks.BlobStorage
+	task              domain.Task
+}
+
+// SetupTest runs before each test in the suite
+func (suite *AttachmentUsecaseTestSuite) SetupTest() {
+	suite.taskMockRepo = new(mocks.TaskRepository)
+	suite.mockBlobStorage = new(mocks.BlobStorage)
+	suite.attachmentUsecase = NewAttachmentUsecase(suite.taskMockRepo, suite.mockBlobStorage, 16, []string{"text/plain"}, domain.DefaultRoles(), time.Second*2).(*attachmentUsecase)
+	suite.task = domain.Task{ID: primitive.NewObjectID()}
+}
+
+func (suite *AttachmentUsecaseTestSuite) TearDownTest() {
+	suite.taskMockRepo.AssertExpectations(suite.T())
+	suite.mockBlobStorage.AssertExpectations(suite.T())
+}
+
+func hashOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUpload_NewContent() {
+	content := "hello world"
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+	suite.mockBlobStorage.On("Exists", mock.Anything, hashOf(content)).Return(false, nil).Once()
+	suite.mockBlobStorage.On("Put", mock.Anything, hashOf(content), mock.Anything).Return(nil).Once()
+	suite.taskMockRepo.On("AddAttachment", mock.Anything, suite.task.ID.Hex(), mock.AnythingOfType("*domain.Attachment")).Return(nil).Once()
+
+	attachment, err := suite.attachmentUsecase.Upload(context.Background(), suite.task.ID.Hex(), primitive.NewObjectID().Hex(), "../notes.txt", strings.NewReader(content))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "notes.txt", attachment.FileName)
+	assert.Equal(suite.T(), "text/plain", attachment.ContentType)
+	assert.Equal(suite.T(), int64(len(content)), attachment.Size)
+	assert.Equal(suite.T(), hashOf(content), attachment.Hash)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUpload_DeduplicatesContent() {
+	content := "hello world"
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+	suite.mockBlobStorage.On("Exists", mock.Anything, hashOf(content)).Return(true, nil).Once()
+	suite.taskMockRepo.On("AddAttachment", mock.Anything, suite.task.ID.Hex(), mock.AnythingOfType("*domain.Attachment")).Return(nil).Once()
+
+	_, err := suite.attachmentUsecase.Upload(context.Background(), suite.task.ID.Hex(), primitive.NewObjectID().Hex(), "notes.txt", strings.NewReader(content))
+
+	// assert the existing blob is reused instead of being written again
+	assert.NoError(suite.T(), err)
+	suite.mockBlobStorage.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUpload_WaitsForTheBlobToBeReleased() {
+	content := "hello world"
+
+	// the last attachment of the content is being deleted, along with the blob
+	unlock := blobLocks.Lock(hashOf(content))
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+	suite.mockBlobStorage.On("Exists", mock.Anything, hashOf(content)).Return(false, nil).Once()
+	suite.mockBlobStorage.On("Put", mock.Anything, hashOf(content), mock.Anything).Return(nil).Once()
+	suite.taskMockRepo.On("AddAttachment", mock.Anything, suite.task.ID.Hex(), mock.AnythingOfType("*domain.Attachment")).Return(nil).Once()
+
+	done := make(chan error)
+	go func() {
+		_, err := suite.attachmentUsecase.Upload(context.Background(), suite.task.ID.Hex(), primitive.NewObjectID().Hex(), "notes.txt", strings.NewReader(content))
+		done <- err
+	}()
+
+	// the upload only checks for the blob once it is deleted, and then stores it again
+	time.Sleep(50 * time.Millisecond)
+	suite.mockBlobStorage.AssertNotCalled(suite.T(), "Exists", mock.Anything, mock.Anything)
+
+	unlock()
+	assert.NoError(suite.T(), <-done)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUpload_TooLarge() {
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+
+	_, err := suite.attachmentUsecase.Upload(context.Background(), suite.task.ID.Hex(), primitive.NewObjectID().Hex(), "notes.txt", strings.NewReader(strings.Repeat("a", 17)))
+
+	assert.ErrorIs(suite.T(), err, domain.ErrAttachmentTooLarge)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestUpload_UnsupportedType() {
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+
+	_, err := suite.attachmentUsecase.Upload(context.Background(), suite.task.ID.Hex(), primitive.NewObjectID().Hex(), "image.png", strings.NewReader("\x89PNG\r\n\x1a\n"))
+
+	assert.ErrorIs(suite.T(), err, domain.ErrUnsupportedMediaType)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestOpen() {
+	attachment := domain.Attachment{ID: primitive.NewObjectID(), Hash: "some-hash"}
+	suite.task.Attachments = []domain.Attachment{attachment}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+	suite.mockBlobStorage.On("Get", mock.Anything, "some-hash").Return(io.NopCloser(strings.NewReader("content")), nil).Once()
+
+	found, content, err := suite.attachmentUsecase.Open(context.Background(), suite.task.ID.Hex(), attachment.ID.Hex())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), attachment.ID, found.ID)
+	assert.NotNil(suite.T(), content)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestOpen_NotFound() {
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+
+	_, _, err := suite.attachmentUsecase.Open(context.Background(), suite.task.ID.Hex(), primitive.NewObjectID().Hex())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrAttachmentNotFound)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestDelete_ByUploaderReleasesBlob() {
+	attachment := domain.Attachment{ID: primitive.NewObjectID(), UploaderID: primitive.NewObjectID(), Hash: "some-hash"}
+	suite.task.Attachments = []domain.Attachment{attachment}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+	suite.taskMockRepo.On("RemoveAttachment", mock.Anything, suite.task.ID.Hex(), attachment.ID.Hex()).Return(nil).Once()
+	suite.taskMockRepo.On("CountAttachmentsByHash", mock.Anything, "some-hash").Return(int64(0), nil).Once()
+	suite.mockBlobStorage.On("Delete", mock.Anything, "some-hash").Return(nil).Once()
+
+	err := suite.attachmentUsecase.Delete(context.Background(), suite.task.ID.Hex(), attachment.ID.Hex(), attachment.UploaderID.Hex(), "USER")
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *AttachmentUsecaseTestSuite) TestDelete_ByOtherUser() {
+	attachment := domain.Attachment{ID: primitive.NewObjectID(), UploaderID: primitive.NewObjectID(), Hash: "some-hash"}
+	suite.task.Attachments = []domain.Attachment{attachment}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, suite.task.ID.Hex()).Return(suite.task, nil).Once()
+
+	err := suite.attachmentUsecase.Delete(context.Background(), suite.task.ID.Hex(), attachment.ID.Hex(), primitive.NewObjectID().Hex(), "USER")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+}
+
+// TestAttachmentUsecaseTestSuite runs the test suite
+func TestAttachmentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUsecaseTestSuite))
+}
@@ -0,0 +1,200 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const MaxCommentLength = 5000
+
+type commentUsecase struct {
+	commentRepository domain.CommentRepository
+	taskRepository    domain.TaskRepository
+	roles             domain.Roles
+	contextTimeout    time.Duration
+}
+
+func NewCommentUsecase(commentRepository domain.CommentRepository, taskRepository domain.TaskRepository, roles domain.Roles, timeout time.Duration) domain.CommentUsecase {
+	return &commentUsecase{
+		commentRepository: commentRepository,
+		taskRepository:    taskRepository,
+		roles:             roles,
+		contextTimeout:    timeout,
+	}
+}
+
+// Create adds a comment written by authorID to the task with ID taskID.
+// If parentID is not empty the comment is stored as a reply to that comment,
+// which must belong to the same task.
+func (commentUC *commentUsecase) Create(c context.Context, taskID string, authorID string, parentID string, content string) (*domain.Comment, error) {
+	ctx, end := startSpan(c, commentUC.contextTimeout, "CommentUsecase.Create")
+	defer end()
+
+	content = strings.TrimSpace(content)
+	if content == "" || len(content) > MaxCommentLength {
+		return nil, domain.ErrInvalidInput
+	}
+
+	authorObjID, err := primitive.ObjectIDFromHex(authorID)
+	if err != nil {
+		return nil, domain.ErrForbidden
+	}
+
+	// the repository reports a missing task as domain.ErrTaskNotFound, other errors are unexpected
+	task, err := commentUC.taskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	comment := &domain.Comment{
+		TaskID:    task.ID,
+		AuthorID:  authorObjID,
+		Content:   content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if parentID != "" {
+		parent, err := commentUC.commentRepository.GetByID(ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.TaskID != task.ID {
+			return nil, domain.ErrCommentNotFound
+		}
+
+		// every reply remembers the top-level comment of its thread so that
+		// whole threads can be loaded with a single query
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+	}
+
+	err = commentUC.commentRepository.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetTaskComments returns one page of the top-level comments of a task with
+// their replies nested underneath them.
+func (commentUC *commentUsecase) GetTaskComments(c context.Context, taskID string, pagination domain.Pagination) (domain.CommentPage, error) {
+	ctx, end := startSpan(c, commentUC.contextTimeout, "CommentUsecase.GetTaskComments")
+	defer end()
+
+	if _, err := commentUC.taskRepository.GetTaskByID(ctx, taskID); err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	threads, total, err := commentUC.commentRepository.GetThreads(ctx, taskID, pagination)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	rootIDs := make([]primitive.ObjectID, 0, len(threads))
+	for _, thread := range threads {
+		rootIDs = append(rootIDs, thread.ID)
+	}
+
+	replies, err := commentUC.commentRepository.GetReplies(ctx, rootIDs)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	return domain.CommentPage{
+		Comments:   nestReplies(threads, replies),
+		Pagination: pagination,
+		Total:      total,
+	}, nil
+}
+
+// Edit replaces the content of a comment. Only the author of the comment may edit it.
+func (commentUC *commentUsecase) Edit(c context.Context, commentID string, actorID string, content string) (*domain.Comment, error) {
+	ctx, end := startSpan(c, commentUC.contextTimeout, "CommentUsecase.Edit")
+	defer end()
+
+	content = strings.TrimSpace(content)
+	if content == "" || len(content) > MaxCommentLength {
+		return nil, domain.ErrInvalidInput
+	}
+
+	comment, err := commentUC.commentRepository.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, domain.ErrCommentNotFound
+	}
+	if comment.AuthorID.Hex() != actorID {
+		return nil, domain.ErrForbidden
+	}
+
+	comment.Content = content
+	comment.UpdatedAt = time.Now().UTC()
+
+	err = commentUC.commentRepository.Update(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Delete removes a comment on behalf of its author or a user allowed to moderate comments.
+// The comment is kept as a tombstone so that the replies underneath it stay in place.
+func (commentUC *commentUsecase) Delete(c context.Context, commentID string, actorID string, actorRole string) error {
+	ctx, end := startSpan(c, commentUC.contextTimeout, "CommentUsecase.Delete")
+	defer end()
+
+	comment, err := commentUC.commentRepository.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted {
+		return domain.ErrCommentNotFound
+	}
+	if comment.AuthorID.Hex() != actorID && !commentUC.roles.Has(actorRole, domain.PermissionCommentsModerate) {
+		return domain.ErrForbidden
+	}
+
+	comment.Content = ""
+	comment.Deleted = true
+	comment.UpdatedAt = time.Now().UTC()
+
+	return commentUC.commentRepository.Update(ctx, comment)
+}
+
+// nestReplies attaches every reply to its parent comment, keeping the order in which they were given.
+func nestReplies(threads []domain.Comment, replies []domain.Comment) []domain.Comment {
+	children := make(map[primitive.ObjectID][]domain.Comment)
+	for _, reply := range replies {
+		if reply.ParentID != nil {
+			children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+		}
+	}
+
+	var attach func(comment domain.Comment) domain.Comment
+	attach = func(comment domain.Comment) domain.Comment {
+		for _, child := range children[comment.ID] {
+			comment.Replies = append(comment.Replies, attach(child))
+		}
+		return comment
+	}
+
+	nested := make([]domain.Comment, 0, len(threads))
+	for _, thread := range threads {
+		nested = append(nested, attach(thread))
+	}
+
+	return nested
+}
@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommentUsecaseTestSuite struct {
+	suite.Suite
+	commentUsecase  *commentUsecase
+	commentMockRepo *mocks.CommentRepository
+	taskMockRepo    *mocks.TaskRepository
+}
+
+// SetupTest runs before each test in the suite
+func (suite *CommentUsecaseTestSuite) SetupTest() {
+	suite.commentMockRepo = new(mocks.CommentRepository)
+	suite.taskMockRepo = new(mocks.TaskRepository)
+	suite.commentUsecase = &commentUsecase{
+		commentRepository: suite.commentMockRepo,
+		taskRepository:    suite.taskMockRepo,
+		roles:             domain.DefaultRoles(),
+		contextTimeout:    time.Second * 2,
+	}
+}
+
+func (suite *CommentUsecaseTestSuite) TearDownTest() {
+	suite.commentMockRepo.AssertExpectations(suite.T())
+	suite.taskMockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *CommentUsecaseTestSuite) TestCreate_TopLevel() {
+	task := domain.Task{ID: primitive.NewObjectID()}
+	authorID := primitive.NewObjectID()
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, task.ID.Hex()).Return(task, nil).Once()
+	suite.commentMockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+	comment, err := suite.commentUsecase.Create(context.Background(), task.ID.Hex(), authorID.Hex(), "", "  first!  ")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "first!", comment.Content)
+	assert.Equal(suite.T(), task.ID, comment.TaskID)
+	assert.Equal(suite.T(), authorID, comment.AuthorID)
+	assert.Nil(suite.T(), comment.ParentID)
+	assert.Nil(suite.T(), comment.RootID)
+}
+
+func (suite *CommentUsecaseTestSuite) TestCreate_ReplyInheritsThreadRoot() {
+	task := domain.Task{ID: primitive.NewObjectID()}
+	rootID := primitive.NewObjectID()
+	parent := &domain.Comment{ID: primitive.NewObjectID(), TaskID: task.ID, ParentID: &rootID, RootID: &rootID}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, task.ID.Hex()).Return(task, nil).Once()
+	suite.commentMockRepo.On("GetByID", mock.Anything, parent.ID.Hex()).Return(parent, nil).Once()
+	suite.commentMockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+
+	comment, err := suite.commentUsecase.Create(context.Background(), task.ID.Hex(), primitive.NewObjectID().Hex(), parent.ID.Hex(), "reply")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), parent.ID, *comment.ParentID)
+	assert.Equal(suite.T(), rootID, *comment.RootID)
+}
+
+func (suite *CommentUsecaseTestSuite) TestCreate_ParentOnAnotherTask() {
+	task := domain.Task{ID: primitive.NewObjectID()}
+	parent := &domain.Comment{ID: primitive.NewObjectID(), TaskID: primitive.NewObjectID()}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, task.ID.Hex()).Return(task, nil).Once()
+	suite.commentMockRepo.On("GetByID", mock.Anything, parent.ID.Hex()).Return(parent, nil).Once()
+
+	_, err := suite.commentUsecase.Create(context.Background(), task.ID.Hex(), primitive.NewObjectID().Hex(), parent.ID.Hex(), "reply")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrCommentNotFound)
+}
+
+func (suite *CommentUsecaseTestSuite) TestCreate_EmptyContent() {
+	_, err := suite.commentUsecase.Create(context.Background(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), "", "   ")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *CommentUsecaseTestSuite) TestCreate_TaskNotFound() {
+	taskID := primitive.NewObjectID().Hex()
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, taskID).Return(domain.Task{}, domain.ErrTaskNotFound).Once()
+
+	_, err := suite.commentUsecase.Create(context.Background(), taskID, primitive.NewObjectID().Hex(), "", "hello")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrTaskNotFound)
+}
+
+func (suite *CommentUsecaseTestSuite) TestCreate_DatabaseError() {
+	taskID := primitive.NewObjectID().Hex()
+	dbErr := errors.New("connection lost")
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, taskID).Return(domain.Task{}, dbErr).Once()
+
+	// only a missing task is reported as such, the other errors are left for a 500 response
+	_, err := suite.commentUsecase.Create(context.Background(), taskID, primitive.NewObjectID().Hex(), "", "hello")
+
+	assert.ErrorIs(suite.T(), err, dbErr)
+	assert.NotErrorIs(suite.T(), err, domain.ErrTaskNotFound)
+}
+
+func (suite *CommentUsecaseTestSuite) TestGetTaskComments_DatabaseError() {
+	taskID := primitive.NewObjectID().Hex()
+	dbErr := errors.New("connection lost")
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, taskID).Return(domain.Task{}, dbErr).Once()
+
+	_, err := suite.commentUsecase.GetTaskComments(context.Background(), taskID, domain.NewPagination(1, 10))
+
+	assert.ErrorIs(suite.T(), err, dbErr)
+}
+
+func (suite *CommentUsecaseTestSuite) TestGetTaskComments_NestsReplies() {
+	task := domain.Task{ID: primitive.NewObjectID()}
+	thread := domain.Comment{ID: primitive.NewObjectID(), TaskID: task.ID}
+	reply := domain.Comment{ID: primitive.NewObjectID(), TaskID: task.ID, ParentID: &thread.ID, RootID: &thread.ID}
+	nestedReply := domain.Comment{ID: primitive.NewObjectID(), TaskID: task.ID, ParentID: &reply.ID, RootID: &thread.ID}
+	pagination := domain.NewPagination(1, 10)
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, task.ID.Hex()).Return(task, nil).Once()
+	suite.commentMockRepo.On("GetThreads", mock.Anything, task.ID.Hex(), pagination).Return([]domain.Comment{thread}, int64(1), nil).Once()
+	suite.commentMockRepo.On("GetReplies", mock.Anything, []primitive.ObjectID{thread.ID}).Return([]domain.Comment{reply, nestedReply}, nil).Once()
+
+	page, err := suite.commentUsecase.GetTaskComments(context.Background(), task.ID.Hex(), pagination)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), page.Total)
+	assert.Len(suite.T(), page.Comments, 1)
+	assert.Len(suite.T(), page.Comments[0].Replies, 1)
+	assert.Equal(suite.T(), reply.ID, page.Comments[0].Replies[0].ID)
+	assert.Len(suite.T(), page.Comments[0].Replies[0].Replies, 1)
+	assert.Equal(suite.T(), nestedReply.ID, page.Comments[0].Replies[0].Replies[0].ID)
+}
+
+func (suite *CommentUsecaseTestSuite) TestEdit_ByAuthor() {
+	comment := &domain.Comment{ID: primitive.NewObjectID(), AuthorID: primitive.NewObjectID(), Content: "old"}
+
+	suite.commentMockRepo.On("GetByID", mock.Anything, comment.ID.Hex()).Return(comment, nil).Once()
+	suite.commentMockRepo.On("Update", mock.Anything, comment).Return(nil).Once()
+
+	updated, err := suite.commentUsecase.Edit(context.Background(), comment.ID.Hex(), comment.AuthorID.Hex(), "new")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "new", updated.Content)
+}
+
+func (suite *CommentUsecaseTestSuite) TestEdit_ByOtherUser() {
+	comment := &domain.Comment{ID: primitive.NewObjectID(), AuthorID: primitive.NewObjectID(), Content: "old"}
+
+	suite.commentMockRepo.On("GetByID", mock.Anything, comment.ID.Hex()).Return(comment, nil).Once()
+
+	_, err := suite.commentUsecase.Edit(context.Background(), comment.ID.Hex(), primitive.NewObjectID().Hex(), "new")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+}
+
+func (suite *CommentUsecaseTestSuite) TestDelete_ByAdmin() {
+	comment := &domain.Comment{ID: primitive.NewObjectID(), AuthorID: primitive.NewObjectID(), Content: "spam"}
+
+	suite.commentMockRepo.On("GetByID", mock.Anything, comment.ID.Hex()).Return(comment, nil).Once()
+	suite.commentMockRepo.On("Update", mock.Anything, comment).Return(nil).Once()
+
+	err := suite.commentUsecase.Delete(context.Background(), comment.ID.Hex(), primitive.NewObjectID().Hex(), "ADMIN")
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), comment.Deleted)
+	assert.Empty(suite.T(), comment.Content)
+}
+
+func (suite *CommentUsecaseTestSuite) TestDelete_ByOtherUser() {
+	comment := &domain.Comment{ID: primitive.NewObjectID(), AuthorID: primitive.NewObjectID(), Content: "mine"}
+
+	suite.commentMockRepo.On("GetByID", mock.Anything, comment.ID.Hex()).Return(comment, nil).Once()
+
+	err := suite.commentUsecase.Delete(context.Background(), comment.ID.Hex(), primitive.NewObjectID().Hex(), "USER")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+	assert.False(suite.T(), comment.Deleted)
+}
+
+// TestCommentUsecaseTestSuite runs the test suite
+func TestCommentUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentUsecaseTestSuite))
+}
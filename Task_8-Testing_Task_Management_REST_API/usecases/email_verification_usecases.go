@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type emailVerificationUsecase struct {
+	userRepository domain.UserRepository
+	mailer         domain.Mailer
+	secret         string
+	baseURL        string
+	tokenTTL       time.Duration
+	resendInterval time.Duration
+	contextTimeout time.Duration
+}
+
+// NewEmailVerificationUsecase creates the usecase sending verification links that point to baseURL.
+// The links are signed with secret, stay valid for tokenTTL and can only be resent once per resendInterval.
+func NewEmailVerificationUsecase(userRepository domain.UserRepository, mailer domain.Mailer, secret string, baseURL string, tokenTTL time.Duration, resendInterval time.Duration, timeout time.Duration) domain.EmailVerificationUsecase {
+	return &emailVerificationUsecase{
+		userRepository: userRepository,
+		mailer:         mailer,
+		secret:         secret,
+		baseURL:        baseURL,
+		tokenTTL:       tokenTTL,
+		resendInterval: resendInterval,
+		contextTimeout: timeout,
+	}
+}
+
+// SendVerification mails a verification link to the user, unless their email is already verified
+// or a link was sent to them less than the resend interval ago.
+func (verificationUC *emailVerificationUsecase) SendVerification(c context.Context, user *domain.User) error {
+	ctx, end := startSpan(c, verificationUC.contextTimeout, "EmailVerificationUsecase.SendVerification")
+	defer end()
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	// the send is claimed before mailing, so that concurrent requests send a single link;
+	// a link that could not be sent still counts, and a new one can be requested after the interval
+	now := time.Now().UTC()
+	claimed, err := verificationUC.userRepository.ClaimVerificationSend(ctx, user.UserID.Hex(), now.Add(-verificationUC.resendInterval), now)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+	user.VerificationSentAt = now
+
+	token := infrastructure.CreateEmailVerificationToken(user.UserID.Hex(), user.Email, now.Add(verificationUC.tokenTTL), verificationUC.secret)
+	link := verificationUC.baseURL + "/verify-email?token=" + url.QueryEscape(token)
+
+	return verificationUC.mailer.Send(ctx, domain.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hello %v,\n\nOpen the following link to verify your email address: %v\n"+
+			"It expires in %v. If you did not create an account, you can ignore this message.",
+			user.Name, link, verificationUC.tokenTTL),
+	})
+}
+
+// Resend mails a new verification link to the user with the given email.
+// Unknown emails, disabled or verified users and requests made too soon after the last link
+// are silently ignored, so that callers cannot find out which emails are registered.
+func (verificationUC *emailVerificationUsecase) Resend(c context.Context, email string) error {
+	ctx, end := startSpan(c, verificationUC.contextTimeout, "EmailVerificationUsecase.Resend")
+	defer end()
+
+	email, err := domain.NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, err := verificationUC.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Disabled || user.EmailVerified {
+		return nil
+	}
+
+	return verificationUC.SendVerification(ctx, user)
+}
+
+// Verify marks the email of the user the token was issued for as verified.
+// Verifying an email twice is not an error.
+func (verificationUC *emailVerificationUsecase) Verify(c context.Context, token string) error {
+	ctx, end := startSpan(c, verificationUC.contextTimeout, "EmailVerificationUsecase.Verify")
+	defer end()
+
+	userID, email, err := infrastructure.ParseEmailVerificationToken(token, verificationUC.secret, time.Now())
+	if err != nil {
+		return err
+	}
+
+	user, err := verificationUC.userRepository.GetByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+
+	// the token only proves ownership of the email it was sent to
+	if user.Email != email {
+		return domain.ErrInvalidToken
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	return verificationUC.userRepository.SetEmailVerified(ctx, userID)
+}
@@ -0,0 +1,150 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EmailVerificationUsecaseTestSuite struct {
+	suite.Suite
+	verificationUsecase *emailVerificationUsecase
+	userMockRepo        *mocks.UserRepository
+	mockMailer          *mocks.Mailer
+}
+
+// SetupTest runs before each test in the suite
+func (suite *EmailVerificationUsecaseTestSuite) SetupTest() {
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.mockMailer = new(mocks.Mailer)
+	suite.verificationUsecase = &emailVerificationUsecase{
+		userRepository: suite.userMockRepo,
+		mailer:         suite.mockMailer,
+		secret:         "test secret",
+		baseURL:        "http://localhost:8080",
+		tokenTTL:       time.Hour,
+		resendInterval: time.Minute,
+		contextTimeout: time.Second * 2,
+	}
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TearDownTest() {
+	suite.userMockRepo.AssertExpectations(suite.T())
+	suite.mockMailer.AssertExpectations(suite.T())
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestSendVerification_MailsSignedLink() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Name: "test name", Email: "test@example.com"}
+
+	var sent domain.Message
+	suite.mockMailer.On("Send", mock.Anything, mock.AnythingOfType("domain.Message")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(domain.Message) }).
+		Return(nil).Once()
+	suite.userMockRepo.On("ClaimVerificationSend", mock.Anything, user.UserID.Hex(), mock.MatchedBy(func(sentBefore time.Time) bool {
+		return time.Since(sentBefore) >= suite.verificationUsecase.resendInterval
+	}), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+
+	err := suite.verificationUsecase.SendVerification(context.Background(), user)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), user.Email, sent.To)
+	assert.WithinDuration(suite.T(), time.Now(), user.VerificationSentAt, time.Minute)
+
+	// the link carries a token for the user and their email
+	link := strings.Fields(sent.Body[strings.Index(sent.Body, "http://localhost:8080/verify-email?token="):])[0]
+	parsedLink, err := url.Parse(link)
+	assert.NoError(suite.T(), err)
+
+	userID, email, err := infrastructure.ParseEmailVerificationToken(parsedLink.Query().Get("token"), "test secret", time.Now())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), user.UserID.Hex(), userID)
+	assert.Equal(suite.T(), user.Email, email)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestResend_Throttled() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com"}
+
+	// the copy of the user is stale, another request claimed the send in the meantime
+	suite.userMockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+	suite.userMockRepo.On("ClaimVerificationSend", mock.Anything, user.UserID.Hex(), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(false, nil).Once()
+
+	err := suite.verificationUsecase.Resend(context.Background(), "Test@Example.com")
+
+	assert.NoError(suite.T(), err)
+	suite.mockMailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestResend_AfterInterval() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", VerificationSentAt: time.Now().Add(-time.Hour)}
+
+	suite.userMockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+	suite.userMockRepo.On("ClaimVerificationSend", mock.Anything, user.UserID.Hex(), mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(true, nil).Once()
+	suite.mockMailer.On("Send", mock.Anything, mock.AnythingOfType("domain.Message")).Return(nil).Once()
+
+	err := suite.verificationUsecase.Resend(context.Background(), user.Email)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestResend_UnknownOrVerifiedEmail() {
+	verifiedUser := &domain.User{UserID: primitive.NewObjectID(), Email: "verified@example.com", EmailVerified: true}
+
+	suite.userMockRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, nil).Once()
+	suite.userMockRepo.On("GetByEmail", mock.Anything, verifiedUser.Email).Return(verifiedUser, nil).Once()
+
+	assert.NoError(suite.T(), suite.verificationUsecase.Resend(context.Background(), "unknown@example.com"))
+	assert.NoError(suite.T(), suite.verificationUsecase.Resend(context.Background(), verifiedUser.Email))
+	suite.mockMailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestResend_InvalidEmail() {
+	err := suite.verificationUsecase.Resend(context.Background(), "not an email")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestVerify_Success() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com"}
+	token := infrastructure.CreateEmailVerificationToken(user.UserID.Hex(), user.Email, time.Now().Add(time.Hour), "test secret")
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.userMockRepo.On("SetEmailVerified", mock.Anything, user.UserID.Hex()).Return(nil).Once()
+
+	err := suite.verificationUsecase.Verify(context.Background(), token)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestVerify_EmailChanged() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "new@example.com"}
+	token := infrastructure.CreateEmailVerificationToken(user.UserID.Hex(), "old@example.com", time.Now().Add(time.Hour), "test secret")
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+
+	err := suite.verificationUsecase.Verify(context.Background(), token)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+	assert.False(suite.T(), user.EmailVerified)
+}
+
+func (suite *EmailVerificationUsecaseTestSuite) TestVerify_ExpiredToken() {
+	token := infrastructure.CreateEmailVerificationToken(primitive.NewObjectID().Hex(), "test@example.com", time.Now().Add(-time.Minute), "test secret")
+
+	err := suite.verificationUsecase.Verify(context.Background(), token)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+}
+
+func TestEmailVerificationUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(EmailVerificationUsecaseTestSuite))
+}
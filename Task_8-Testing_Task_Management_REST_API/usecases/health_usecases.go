@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type healthUsecase struct {
+	mu             sync.RWMutex
+	checkers       map[string]domain.HealthChecker
+	draining       atomic.Bool
+	contextTimeout time.Duration
+}
+
+// NewHealthUsecase creates the usecase answering the health probes. Every dependency is given
+// the timeout to answer, so that a hanging dependency can't hang the probe.
+func NewHealthUsecase(timeout time.Duration) domain.HealthUsecase {
+	return &healthUsecase{
+		checkers:       map[string]domain.HealthChecker{},
+		contextTimeout: timeout,
+	}
+}
+
+func (healthUC *healthUsecase) Register(name string, checker domain.HealthChecker) {
+	healthUC.mu.Lock()
+	defer healthUC.mu.Unlock()
+
+	healthUC.checkers[name] = checker
+}
+
+func (healthUC *healthUsecase) Drain() {
+	healthUC.draining.Store(true)
+}
+
+// Liveness reports that the process is up, whatever the state of its dependencies.
+func (healthUC *healthUsecase) Liveness() domain.HealthReport {
+	return domain.HealthReport{Status: domain.HealthStatusUp}
+}
+
+// Readiness checks every dependency at the same time. The API is ready when all of them are up,
+// and never once it has started shutting down.
+func (healthUC *healthUsecase) Readiness(c context.Context) domain.HealthReport {
+	ctx, cancel := context.WithTimeout(c, healthUC.contextTimeout)
+	defer cancel()
+
+	healthUC.mu.RLock()
+	checkers := make(map[string]domain.HealthChecker, len(healthUC.checkers))
+	for name, checker := range healthUC.checkers {
+		checkers[name] = checker
+	}
+	healthUC.mu.RUnlock()
+
+	report := domain.HealthReport{Status: domain.HealthStatusUp, Checks: map[string]domain.DependencyHealth{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker domain.HealthChecker) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := checker.Check(ctx)
+			health := domain.DependencyHealth{
+				Status:    domain.HealthStatusUp,
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				health.Status = domain.HealthStatusDown
+				health.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = health
+			if err != nil {
+				report.Status = domain.HealthStatusDown
+			}
+		}(name, checker)
+	}
+	wg.Wait()
+
+	if healthUC.draining.Load() {
+		report.Status = domain.HealthStatusDown
+		report.Checks["shutdown"] = domain.DependencyHealth{Status: domain.HealthStatusDown, Error: "the server is shutting down"}
+	}
+
+	return report
+}
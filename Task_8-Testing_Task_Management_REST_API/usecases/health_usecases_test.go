@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthUsecaseTestSuite struct {
+	suite.Suite
+	healthUsecase domain.HealthUsecase
+}
+
+// SetupTest runs before each test in the suite
+func (suite *HealthUsecaseTestSuite) SetupTest() {
+	suite.healthUsecase = NewHealthUsecase(time.Millisecond * 50)
+}
+
+func (suite *HealthUsecaseTestSuite) TestLiveness() {
+	suite.healthUsecase.Register("mongodb", domain.HealthCheckerFunc(func(c context.Context) error {
+		return errors.New("connection refused")
+	}))
+
+	// the process is up even if a dependency is down
+	report := suite.healthUsecase.Liveness()
+
+	suite.True(report.Up())
+	suite.Empty(report.Checks)
+}
+
+func (suite *HealthUsecaseTestSuite) TestReadiness_Up() {
+	suite.healthUsecase.Register("mongodb", domain.HealthCheckerFunc(func(c context.Context) error { return nil }))
+	suite.healthUsecase.Register("mailer", domain.HealthCheckerFunc(func(c context.Context) error { return nil }))
+
+	report := suite.healthUsecase.Readiness(context.Background())
+
+	suite.True(report.Up())
+	suite.Equal(domain.HealthStatusUp, report.Checks["mongodb"].Status)
+	suite.Equal(domain.HealthStatusUp, report.Checks["mailer"].Status)
+	suite.Empty(report.Checks["mongodb"].Error)
+}
+
+func (suite *HealthUsecaseTestSuite) TestReadiness_DependencyDown() {
+	suite.healthUsecase.Register("mongodb", domain.HealthCheckerFunc(func(c context.Context) error {
+		return errors.New("connection refused")
+	}))
+	suite.healthUsecase.Register("mailer", domain.HealthCheckerFunc(func(c context.Context) error { return nil }))
+
+	report := suite.healthUsecase.Readiness(context.Background())
+
+	suite.False(report.Up())
+	suite.Equal(domain.DependencyHealth{Status: domain.HealthStatusDown, LatencyMS: report.Checks["mongodb"].LatencyMS, Error: "connection refused"}, report.Checks["mongodb"])
+	suite.Equal(domain.HealthStatusUp, report.Checks["mailer"].Status)
+}
+
+func (suite *HealthUsecaseTestSuite) TestReadiness_HangingDependencyTimesOut() {
+	suite.healthUsecase.Register("mongodb", domain.HealthCheckerFunc(func(c context.Context) error {
+		<-c.Done()
+		return c.Err()
+	}))
+
+	report := suite.healthUsecase.Readiness(context.Background())
+
+	suite.False(report.Up())
+	suite.Equal(context.DeadlineExceeded.Error(), report.Checks["mongodb"].Error)
+	suite.GreaterOrEqual(report.Checks["mongodb"].LatencyMS, float64(50))
+}
+
+func (suite *HealthUsecaseTestSuite) TestReadiness_Draining() {
+	suite.healthUsecase.Register("mongodb", domain.HealthCheckerFunc(func(c context.Context) error { return nil }))
+	suite.True(suite.healthUsecase.Readiness(context.Background()).Up())
+
+	suite.healthUsecase.Drain()
+	report := suite.healthUsecase.Readiness(context.Background())
+
+	suite.False(report.Up())
+	suite.Equal(domain.HealthStatusUp, report.Checks["mongodb"].Status)
+	suite.Equal(domain.HealthStatusDown, report.Checks["shutdown"].Status)
+}
+
+func TestHealthUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthUsecaseTestSuite))
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"strings"
+	"time"
+)
+
+type loginAttemptUsecase struct {
+	attemptRepository domain.LoginAttemptRepository
+	userRepository    domain.UserRepository
+	policy            domain.LoginPolicy
+	contextTimeout    time.Duration
+}
+
+func NewLoginAttemptUsecase(attemptRepository domain.LoginAttemptRepository, userRepository domain.UserRepository, policy domain.LoginPolicy, timeout time.Duration) domain.LoginAttemptUsecase {
+	return &loginAttemptUsecase{
+		attemptRepository: attemptRepository,
+		userRepository:    userRepository,
+		policy:            policy,
+		contextTimeout:    timeout,
+	}
+}
+
+// Attempt counts a login attempt with the given email from the given IP, before its password is checked,
+// and returns how long the client has to wait before trying again if the attempt is refused.
+// A zero duration means the login can go on. Counting first throttles concurrent attempts too,
+// as each sees the ones counted before it; refused attempts count as well. Attempts are counted
+// for unknown emails too, so that they behave like existing accounts.
+func (attemptUC *loginAttemptUsecase) Attempt(c context.Context, email string, ip string) (time.Duration, error) {
+	ctx, end := startSpan(c, attemptUC.contextTimeout, "LoginAttemptUsecase.Attempt")
+	defer end()
+
+	now := time.Now().UTC()
+
+	limits := map[string]int{
+		accountKey(email): attemptUC.policy.MaxAccountFailures,
+		ipKey(ip):         attemptUC.policy.MaxIPFailures,
+	}
+
+	var wait time.Duration
+	for key, maxFailures := range limits {
+		if key == "" {
+			continue
+		}
+
+		previous, err := attemptUC.attemptRepository.RecordAttempt(ctx, key, now, now.Add(-attemptUC.policy.Lockout))
+		if err != nil {
+			return 0, err
+		}
+		if previous == nil {
+			continue
+		}
+
+		if keyWait := attemptUC.waitFor(previous, maxFailures, now); keyWait > wait {
+			wait = keyWait
+		}
+	}
+
+	return wait, nil
+}
+
+// Forgive takes back the attempt with the given email from the given IP, whose password was right,
+// keeping the failures before it. It is used while the login waits for the second factor.
+func (attemptUC *loginAttemptUsecase) Forgive(c context.Context, email string, ip string) error {
+	ctx, end := startSpan(c, attemptUC.contextTimeout, "LoginAttemptUsecase.Forgive")
+	defer end()
+
+	for _, key := range []string{accountKey(email), ipKey(ip)} {
+		if key == "" {
+			continue
+		}
+
+		if err := attemptUC.attemptRepository.ForgiveAttempt(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordSuccess forgets the failed logins of the account with the given email.
+// Only the successful attempt is taken back from the IP, so that logging into one's own account
+// doesn't allow guessing more passwords.
+func (attemptUC *loginAttemptUsecase) RecordSuccess(c context.Context, email string, ip string) error {
+	ctx, end := startSpan(c, attemptUC.contextTimeout, "LoginAttemptUsecase.RecordSuccess")
+	defer end()
+
+	if err := attemptUC.attemptRepository.Reset(ctx, accountKey(email)); err != nil {
+		return err
+	}
+	if key := ipKey(ip); key != "" {
+		return attemptUC.attemptRepository.ForgiveAttempt(ctx, key)
+	}
+
+	return nil
+}
+
+// Unlock lifts the lockout of the user with the given ID and forgets their failed logins.
+func (attemptUC *loginAttemptUsecase) Unlock(c context.Context, userID string) error {
+	ctx, end := startSpan(c, attemptUC.contextTimeout, "LoginAttemptUsecase.Unlock")
+	defer end()
+
+	user, err := attemptUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return attemptUC.attemptRepository.Reset(ctx, accountKey(user.Email))
+}
+
+// waitFor returns how long the lockout or the progressive delay of the attempts, counted before one made at now,
+// still lasts. Reaching maxFailures locks out until the last failure is forgotten.
+func (attemptUC *loginAttemptUsecase) waitFor(attempt *domain.LoginAttempt, maxFailures int, now time.Time) time.Duration {
+	if attempt.LastFailure.Before(now.Add(-attemptUC.policy.Lockout)) {
+		return 0
+	}
+
+	if attempt.Failures >= maxFailures {
+		return attempt.LastFailure.Add(attemptUC.policy.Lockout).Sub(now)
+	}
+
+	retryAt := attempt.LastFailure.Add(progressiveDelay(attempt.Failures, attemptUC.policy.MaxDelay))
+	if now.Before(retryAt) {
+		return retryAt.Sub(now)
+	}
+
+	return 0
+}
+
+// progressiveDelay returns the delay imposed after the given number of consecutive failures:
+// nothing after the first one, then one second doubling with every failure, up to maxDelay.
+func progressiveDelay(failures int, maxDelay time.Duration) time.Duration {
+	if failures < 2 {
+		return 0
+	}
+
+	delay := time.Second
+	for i := 2; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+func accountKey(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return ""
+	}
+	return "account:" + email
+}
+
+func ipKey(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	return "ip:" + ip
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LoginAttemptUsecaseTestSuite struct {
+	suite.Suite
+	attemptUsecase  *loginAttemptUsecase
+	attemptMockRepo *mocks.LoginAttemptRepository
+	userMockRepo    *mocks.UserRepository
+}
+
+// SetupTest runs before each test in the suite
+func (suite *LoginAttemptUsecaseTestSuite) SetupTest() {
+	suite.attemptMockRepo = new(mocks.LoginAttemptRepository)
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.attemptUsecase = &loginAttemptUsecase{
+		attemptRepository: suite.attemptMockRepo,
+		userRepository:    suite.userMockRepo,
+		policy: domain.LoginPolicy{
+			MaxAccountFailures: 5,
+			MaxIPFailures:      20,
+			Lockout:            time.Minute * 15,
+			MaxDelay:           time.Second * 30,
+		},
+		contextTimeout: time.Second * 2,
+	}
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TearDownTest() {
+	suite.attemptMockRepo.AssertExpectations(suite.T())
+	suite.userMockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestAttempt_NoFailures() {
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "account:test@example.com", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "ip:10.0.0.1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+
+	wait, err := suite.attemptUsecase.Attempt(context.Background(), " Test@Example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), wait)
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestAttempt_ProgressiveDelay() {
+	// the fourth failure delays the next attempt by four seconds
+	attempt := &domain.LoginAttempt{Key: "account:test@example.com", Failures: 4, LastFailure: time.Now().UTC()}
+
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "account:test@example.com", mock.AnythingOfType("time.Time"), mock.MatchedBy(func(forgetBefore time.Time) bool {
+		return time.Since(forgetBefore) > time.Minute*14
+	})).Return(attempt, nil).Once()
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "ip:10.0.0.1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+
+	wait, err := suite.attemptUsecase.Attempt(context.Background(), "test@example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), float64(time.Second*4), float64(wait), float64(time.Second))
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestAttempt_ConcurrentAttempts() {
+	// the attempts made at the same time each see the ones counted before them
+	lastFailure := time.Now().UTC()
+	for failures := 0; failures < 3; failures++ {
+		var previous *domain.LoginAttempt
+		if failures > 0 {
+			previous = &domain.LoginAttempt{Key: "account:test@example.com", Failures: failures, LastFailure: lastFailure}
+		}
+		suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "account:test@example.com", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(previous, nil).Once()
+	}
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "ip:10.0.0.1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, nil).Times(3)
+
+	var refused int
+	for i := 0; i < 3; i++ {
+		wait, err := suite.attemptUsecase.Attempt(context.Background(), "test@example.com", "10.0.0.1")
+		assert.NoError(suite.T(), err)
+		if wait > 0 {
+			refused++
+		}
+	}
+
+	// only the second failure delays the attempts after it
+	assert.Equal(suite.T(), 1, refused)
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestAttempt_LockedIP() {
+	attempt := &domain.LoginAttempt{Key: "ip:10.0.0.1", Failures: 20, LastFailure: time.Now().UTC().Add(-time.Minute * 5)}
+
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "account:test@example.com", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "ip:10.0.0.1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(attempt, nil).Once()
+
+	wait, err := suite.attemptUsecase.Attempt(context.Background(), "test@example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), float64(time.Minute*10), float64(wait), float64(time.Second))
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestAttempt_LocksAccountAtMaxFailures() {
+	attempt := &domain.LoginAttempt{Key: "account:test@example.com", Failures: 5, LastFailure: time.Now().UTC().Add(-time.Minute)}
+
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "account:test@example.com", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(attempt, nil).Once()
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "ip:10.0.0.1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(&domain.LoginAttempt{Key: "ip:10.0.0.1", Failures: 5, LastFailure: time.Now().UTC().Add(-time.Minute)}, nil).Once()
+
+	wait, err := suite.attemptUsecase.Attempt(context.Background(), "test@example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), float64(time.Minute*14), float64(wait), float64(time.Second))
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestAttempt_OldFailuresForgotten() {
+	attempt := &domain.LoginAttempt{Key: "account:test@example.com", Failures: 4, LastFailure: time.Now().UTC().Add(-time.Hour)}
+
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "account:test@example.com", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(attempt, nil).Once()
+	suite.attemptMockRepo.On("RecordAttempt", mock.Anything, "ip:10.0.0.1", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil, nil).Once()
+
+	wait, err := suite.attemptUsecase.Attempt(context.Background(), "test@example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), wait)
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestForgive() {
+	suite.attemptMockRepo.On("ForgiveAttempt", mock.Anything, "account:test@example.com").Return(nil).Once()
+	suite.attemptMockRepo.On("ForgiveAttempt", mock.Anything, "ip:10.0.0.1").Return(nil).Once()
+
+	err := suite.attemptUsecase.Forgive(context.Background(), "test@example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestRecordSuccess_KeepsIPFailures() {
+	suite.attemptMockRepo.On("Reset", mock.Anything, "account:test@example.com").Return(nil).Once()
+	suite.attemptMockRepo.On("ForgiveAttempt", mock.Anything, "ip:10.0.0.1").Return(nil).Once()
+
+	err := suite.attemptUsecase.RecordSuccess(context.Background(), "test@example.com", "10.0.0.1")
+
+	assert.NoError(suite.T(), err)
+	suite.attemptMockRepo.AssertNotCalled(suite.T(), "Reset", mock.Anything, "ip:10.0.0.1")
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestUnlock() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com"}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.attemptMockRepo.On("Reset", mock.Anything, "account:test@example.com").Return(nil).Once()
+
+	err := suite.attemptUsecase.Unlock(context.Background(), user.UserID.Hex())
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestUnlock_UserNotFound() {
+	suite.userMockRepo.On("GetByID", mock.Anything, "missing").Return(&domain.User{}, domain.ErrUserNotFound).Once()
+
+	err := suite.attemptUsecase.Unlock(context.Background(), "missing")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+}
+
+func (suite *LoginAttemptUsecaseTestSuite) TestProgressiveDelay() {
+	assert.Zero(suite.T(), progressiveDelay(1, time.Second*30))
+	assert.Equal(suite.T(), time.Second, progressiveDelay(2, time.Second*30))
+	assert.Equal(suite.T(), time.Second*2, progressiveDelay(3, time.Second*30))
+	assert.Equal(suite.T(), time.Second*16, progressiveDelay(6, time.Second*30))
+	assert.Equal(suite.T(), time.Second*30, progressiveDelay(100, time.Second*30))
+}
+
+func TestLoginAttemptUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptUsecaseTestSuite))
+}
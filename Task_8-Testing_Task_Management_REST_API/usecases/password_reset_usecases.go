@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type passwordResetUsecase struct {
+	userRepository  domain.UserRepository
+	resetRepository domain.PasswordResetRepository
+	mailer          domain.Mailer
+	passwordHasher  domain.PasswordHasher
+	passwordPolicy  domain.PasswordPolicy
+	tokenTTL        time.Duration
+	contextTimeout  time.Duration
+}
+
+func NewPasswordResetUsecase(userRepository domain.UserRepository, resetRepository domain.PasswordResetRepository, mailer domain.Mailer, passwordHasher domain.PasswordHasher, passwordPolicy domain.PasswordPolicy, tokenTTL time.Duration, timeout time.Duration) domain.PasswordResetUsecase {
+	return &passwordResetUsecase{
+		userRepository:  userRepository,
+		resetRepository: resetRepository,
+		mailer:          mailer,
+		passwordHasher:  passwordHasher,
+		passwordPolicy:  passwordPolicy,
+		tokenTTL:        tokenTTL,
+		contextTimeout:  timeout,
+	}
+}
+
+// RequestReset mails a password reset token to the user with the given email, replacing any token sent before.
+// Unknown emails and disabled users are silently ignored, so that callers cannot find out which emails are registered.
+func (resetUC *passwordResetUsecase) RequestReset(c context.Context, email string) error {
+	ctx, end := startSpan(c, resetUC.contextTimeout, "PasswordResetUsecase.RequestReset")
+	defer end()
+
+	email, err := domain.NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+
+	user, err := resetUC.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Disabled {
+		return nil
+	}
+
+	token, err := newResetToken()
+	if err != nil {
+		return err
+	}
+
+	err = resetUC.resetRepository.DeleteByUserID(ctx, user.UserID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	err = resetUC.resetRepository.Create(ctx, &domain.PasswordReset{
+		UserID:    user.UserID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: now.Add(resetUC.tokenTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return resetUC.mailer.Send(ctx, domain.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %v,\n\nUse the following token to reset your password: %v\n"+
+			"It expires in %v and can only be used once. If you did not ask for a reset, you can ignore this message.",
+			user.Name, token, resetUC.tokenTTL),
+	})
+}
+
+// ResetPassword sets a new password for the user the token was sent to.
+// The token can only be used once, and every session of the user is invalidated.
+func (resetUC *passwordResetUsecase) ResetPassword(c context.Context, token string, password string) error {
+	ctx, end := startSpan(c, resetUC.contextTimeout, "PasswordResetUsecase.ResetPassword")
+	defer end()
+
+	if err := resetUC.passwordPolicy.Validate(password); err != nil {
+		return err
+	}
+
+	reset, err := resetUC.resetRepository.GetByTokenHash(ctx, hashResetToken(token))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if reset.UsedAt != nil || now.After(reset.ExpiresAt) {
+		return domain.ErrInvalidToken
+	}
+
+	user, err := resetUC.userRepository.GetByID(ctx, reset.UserID.Hex())
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrInvalidToken
+	}
+	if err != nil {
+		return err
+	}
+
+	// claim the token before changing anything, so that it cannot be used twice
+	err = resetUC.resetRepository.MarkUsed(ctx, reset.ID, now)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := resetUC.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	// the token version is incremented along with the password, ending the sessions of the user
+	err = resetUC.userRepository.SetPassword(ctx, user.UserID.Hex(), hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	return resetUC.resetRepository.DeleteByUserID(ctx, user.UserID)
+}
+
+// newResetToken generates a random token that is only ever sent to the user.
+func newResetToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// hashResetToken returns the form of the token that is stored in the database.
+func hashResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,189 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PasswordResetUsecaseTestSuite struct {
+	suite.Suite
+	resetUsecase  *passwordResetUsecase
+	userMockRepo  *mocks.UserRepository
+	resetMockRepo *mocks.PasswordResetRepository
+	mockMailer    *mocks.Mailer
+}
+
+// SetupTest runs before each test in the suite
+func (suite *PasswordResetUsecaseTestSuite) SetupTest() {
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.resetMockRepo = new(mocks.PasswordResetRepository)
+	suite.mockMailer = new(mocks.Mailer)
+	suite.resetUsecase = &passwordResetUsecase{
+		userRepository:  suite.userMockRepo,
+		resetRepository: suite.resetMockRepo,
+		mailer:          suite.mockMailer,
+		passwordHasher:  infrastructure.NewBcryptHasher(4),
+		passwordPolicy:  domain.PasswordPolicy{MinLength: 8, MaxLength: 128},
+		tokenTTL:        time.Minute * 30,
+		contextTimeout:  time.Second * 2,
+	}
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TearDownTest() {
+	suite.userMockRepo.AssertExpectations(suite.T())
+	suite.resetMockRepo.AssertExpectations(suite.T())
+	suite.mockMailer.AssertExpectations(suite.T())
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestRequestReset_SendsToken() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Name: "test name", Email: "test@example.com"}
+
+	var stored *domain.PasswordReset
+	var sent domain.Message
+
+	suite.userMockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+	suite.resetMockRepo.On("DeleteByUserID", mock.Anything, user.UserID).Return(nil).Once()
+	suite.resetMockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PasswordReset")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.PasswordReset) }).
+		Return(nil).Once()
+	suite.mockMailer.On("Send", mock.Anything, mock.AnythingOfType("domain.Message")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(domain.Message) }).
+		Return(nil).Once()
+
+	err := suite.resetUsecase.RequestReset(context.Background(), " test@example.com ")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), user.UserID, stored.UserID)
+	assert.WithinDuration(suite.T(), time.Now().Add(time.Minute*30), stored.ExpiresAt, time.Minute)
+	assert.Equal(suite.T(), user.Email, sent.To)
+
+	// only the hash of the mailed token is stored
+	token := sent.Body[strings.Index(sent.Body, "password: ")+len("password: "):]
+	token = strings.Fields(token)[0]
+	assert.NotContains(suite.T(), stored.TokenHash, token)
+	assert.Equal(suite.T(), hashResetToken(token), stored.TokenHash)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestRequestReset_UnknownEmail() {
+	suite.userMockRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, nil).Once()
+
+	err := suite.resetUsecase.RequestReset(context.Background(), "unknown@example.com")
+
+	assert.NoError(suite.T(), err)
+	suite.resetMockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	suite.mockMailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestRequestReset_DisabledUser() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", Disabled: true}
+
+	suite.userMockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+
+	err := suite.resetUsecase.RequestReset(context.Background(), user.Email)
+
+	assert.NoError(suite.T(), err)
+	suite.mockMailer.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestResetPassword_Success() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", Password: "old hash", TokenVersion: 2}
+	reset := &domain.PasswordReset{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.UserID,
+		TokenHash: hashResetToken("token"),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	suite.resetMockRepo.On("GetByTokenHash", mock.Anything, reset.TokenHash).Return(reset, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.resetMockRepo.On("MarkUsed", mock.Anything, reset.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.userMockRepo.On("SetPassword", mock.Anything, user.UserID.Hex(), mock.MatchedBy(func(hashedPassword string) bool {
+		valid, err := suite.resetUsecase.passwordHasher.Verify("new password", hashedPassword)
+		return valid && err == nil
+	})).Return(nil).Once()
+	suite.resetMockRepo.On("DeleteByUserID", mock.Anything, user.UserID).Return(nil).Once()
+
+	err := suite.resetUsecase.ResetPassword(context.Background(), "token", "new password")
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestResetPassword_ShortPassword() {
+	err := suite.resetUsecase.ResetPassword(context.Background(), "token", "short")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestResetPassword_UnknownToken() {
+	suite.resetMockRepo.On("GetByTokenHash", mock.Anything, hashResetToken("token")).Return(nil, domain.ErrInvalidToken).Once()
+
+	err := suite.resetUsecase.ResetPassword(context.Background(), "token", "new password")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestResetPassword_ExpiredToken() {
+	reset := &domain.PasswordReset{
+		ID:        primitive.NewObjectID(),
+		UserID:    primitive.NewObjectID(),
+		TokenHash: hashResetToken("token"),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+
+	suite.resetMockRepo.On("GetByTokenHash", mock.Anything, reset.TokenHash).Return(reset, nil).Once()
+
+	err := suite.resetUsecase.ResetPassword(context.Background(), "token", "new password")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+	suite.userMockRepo.AssertNotCalled(suite.T(), "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestResetPassword_UsedToken() {
+	usedAt := time.Now().Add(-time.Minute)
+	reset := &domain.PasswordReset{
+		ID:        primitive.NewObjectID(),
+		UserID:    primitive.NewObjectID(),
+		TokenHash: hashResetToken("token"),
+		ExpiresAt: time.Now().Add(time.Minute),
+		UsedAt:    &usedAt,
+	}
+
+	suite.resetMockRepo.On("GetByTokenHash", mock.Anything, reset.TokenHash).Return(reset, nil).Once()
+
+	err := suite.resetUsecase.ResetPassword(context.Background(), "token", "new password")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+}
+
+func (suite *PasswordResetUsecaseTestSuite) TestResetPassword_TokenClaimedConcurrently() {
+	user := &domain.User{UserID: primitive.NewObjectID()}
+	reset := &domain.PasswordReset{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.UserID,
+		TokenHash: hashResetToken("token"),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	suite.resetMockRepo.On("GetByTokenHash", mock.Anything, reset.TokenHash).Return(reset, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.resetMockRepo.On("MarkUsed", mock.Anything, reset.ID, mock.AnythingOfType("time.Time")).Return(domain.ErrInvalidToken).Once()
+
+	err := suite.resetUsecase.ResetPassword(context.Background(), "token", "new password")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+	suite.userMockRepo.AssertNotCalled(suite.T(), "SetPassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPasswordResetUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetUsecaseTestSuite))
+}
@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// lastUsedPrecision is how often the last use of a token is written, so that busy tokens don't cost a write per request.
+const lastUsedPrecision = time.Minute
+
+type personalAccessTokenUsecase struct {
+	tokenRepository domain.PersonalAccessTokenRepository
+	userRepository  domain.UserRepository
+	roles           domain.Roles
+	contextTimeout  time.Duration
+}
+
+// NewPersonalAccessTokenUsecase creates the usecase managing personal access tokens.
+// A token can only be created with scopes that the roles grant to its user.
+func NewPersonalAccessTokenUsecase(tokenRepository domain.PersonalAccessTokenRepository, userRepository domain.UserRepository, roles domain.Roles, timeout time.Duration) domain.PersonalAccessTokenUsecase {
+	return &personalAccessTokenUsecase{
+		tokenRepository: tokenRepository,
+		userRepository:  userRepository,
+		roles:           roles,
+		contextTimeout:  timeout,
+	}
+}
+
+// Create creates a new personal access token for the user. The token is only returned this once.
+func (tokenUC *personalAccessTokenUsecase) Create(c context.Context, userID string, request domain.PersonalAccessTokenRequest) (*domain.CreatedPersonalAccessToken, error) {
+	ctx, end := startSpan(c, tokenUC.contextTimeout, "PersonalAccessTokenUsecase.Create")
+	defer end()
+
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return nil, fmt.Errorf("%w: the token needs a name", domain.ErrInvalidInput)
+	}
+	if len(request.Scopes) == 0 {
+		return nil, fmt.Errorf("%w: the token needs at least one scope", domain.ErrInvalidInput)
+	}
+	if request.ExpiresInDays < 0 {
+		return nil, fmt.Errorf("%w: expires_in_days can't be negative", domain.ErrInvalidInput)
+	}
+
+	user, err := tokenUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a token never grants more than its user has
+	for _, scope := range request.Scopes {
+		if !tokenUC.roles.Has(user.Role, scope) {
+			return nil, fmt.Errorf("%w: the '%v' role does not grant the '%v' scope", domain.ErrInvalidInput, user.Role, scope)
+		}
+	}
+
+	secret, err := newPersonalAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	token := domain.PersonalAccessToken{
+		UserID:    user.UserID,
+		Name:      request.Name,
+		Scopes:    request.Scopes,
+		TokenHash: hashPersonalAccessToken(secret),
+		Hint:      secret[len(secret)-4:],
+		CreatedAt: now,
+	}
+	if request.ExpiresInDays > 0 {
+		expiresAt := now.AddDate(0, 0, request.ExpiresInDays)
+		token.ExpiresAt = &expiresAt
+	}
+
+	if err = tokenUC.tokenRepository.Create(ctx, &token); err != nil {
+		return nil, err
+	}
+
+	return &domain.CreatedPersonalAccessToken{Token: secret, PersonalAccessToken: token}, nil
+}
+
+// GetUserTokens returns the personal access tokens of the user, without the tokens themselves.
+func (tokenUC *personalAccessTokenUsecase) GetUserTokens(c context.Context, userID string) ([]domain.PersonalAccessToken, error) {
+	ctx, end := startSpan(c, tokenUC.contextTimeout, "PersonalAccessTokenUsecase.GetUserTokens")
+	defer end()
+
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	return tokenUC.tokenRepository.GetByUserID(ctx, objID)
+}
+
+// Revoke deletes a personal access token of the user, which stops working immediately.
+func (tokenUC *personalAccessTokenUsecase) Revoke(c context.Context, userID string, tokenID string) error {
+	ctx, end := startSpan(c, tokenUC.contextTimeout, "PersonalAccessTokenUsecase.Revoke")
+	defer end()
+
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	return tokenUC.tokenRepository.Delete(ctx, objID, tokenID)
+}
+
+// Authenticate returns the personal access token a request was made with, and records that it has been used.
+// It returns domain.ErrInvalidToken if the token is unknown, revoked or expired.
+func (tokenUC *personalAccessTokenUsecase) Authenticate(c context.Context, secret string) (*domain.PersonalAccessToken, error) {
+	ctx, end := startSpan(c, tokenUC.contextTimeout, "PersonalAccessTokenUsecase.Authenticate")
+	defer end()
+
+	if !strings.HasPrefix(secret, domain.PersonalAccessTokenPrefix) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	token, err := tokenUC.tokenRepository.GetByTokenHash(ctx, hashPersonalAccessToken(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedPrecision {
+		if err = tokenUC.tokenRepository.SetLastUsed(ctx, token.ID, now); err != nil {
+			return nil, err
+		}
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
+
+// newPersonalAccessToken generates a random token, recognisable by its prefix.
+func newPersonalAccessToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return domain.PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// hashPersonalAccessToken returns the form of the token that is stored in the database.
+func hashPersonalAccessToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
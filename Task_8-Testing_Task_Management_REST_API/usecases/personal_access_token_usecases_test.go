@@ -0,0 +1,135 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PersonalAccessTokenUsecaseTestSuite struct {
+	suite.Suite
+	tokenUsecase  *personalAccessTokenUsecase
+	tokenMockRepo *mocks.PersonalAccessTokenRepository
+	userMockRepo  *mocks.UserRepository
+	user          *domain.User
+}
+
+// SetupTest runs before each test in the suite
+func (suite *PersonalAccessTokenUsecaseTestSuite) SetupTest() {
+	suite.tokenMockRepo = new(mocks.PersonalAccessTokenRepository)
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.tokenUsecase = &personalAccessTokenUsecase{
+		tokenRepository: suite.tokenMockRepo,
+		userRepository:  suite.userMockRepo,
+		roles:           domain.DefaultRoles(),
+		contextTimeout:  time.Second * 2,
+	}
+	suite.user = &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleUser}
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TearDownTest() {
+	suite.tokenMockRepo.AssertExpectations(suite.T())
+	suite.userMockRepo.AssertExpectations(suite.T())
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestCreate_StoresHashOnly() {
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	var stored *domain.PersonalAccessToken
+	suite.tokenMockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PersonalAccessToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.PersonalAccessToken) }).
+		Return(nil).Once()
+
+	created, err := suite.tokenUsecase.Create(context.Background(), suite.user.UserID.Hex(), domain.PersonalAccessTokenRequest{
+		Name:          " ci ",
+		Scopes:        []string{domain.PermissionProjectsRead},
+		ExpiresInDays: 30,
+	})
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), strings.HasPrefix(created.Token, domain.PersonalAccessTokenPrefix))
+	assert.Equal(suite.T(), "ci", stored.Name)
+	assert.Equal(suite.T(), suite.user.UserID, stored.UserID)
+	assert.Equal(suite.T(), hashPersonalAccessToken(created.Token), stored.TokenHash)
+	assert.NotContains(suite.T(), stored.TokenHash, created.Token)
+	assert.True(suite.T(), strings.HasSuffix(created.Token, stored.Hint))
+	assert.WithinDuration(suite.T(), time.Now().AddDate(0, 0, 30), *stored.ExpiresAt, time.Minute)
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestCreate_ScopeNotGrantedByRole() {
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	_, err := suite.tokenUsecase.Create(context.Background(), suite.user.UserID.Hex(), domain.PersonalAccessTokenRequest{
+		Name:   "ci",
+		Scopes: []string{domain.PermissionUsersDelete},
+	})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestCreate_InvalidRequest() {
+	requests := []domain.PersonalAccessTokenRequest{
+		{Name: " ", Scopes: []string{domain.PermissionProjectsRead}},
+		{Name: "ci"},
+		{Name: "ci", Scopes: []string{domain.PermissionProjectsRead}, ExpiresInDays: -1},
+	}
+
+	for _, request := range requests {
+		_, err := suite.tokenUsecase.Create(context.Background(), suite.user.UserID.Hex(), request)
+		assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+	}
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestAuthenticate_RecordsLastUse() {
+	token := &domain.PersonalAccessToken{ID: primitive.NewObjectID(), UserID: suite.user.UserID}
+	suite.tokenMockRepo.On("GetByTokenHash", mock.Anything, hashPersonalAccessToken("tmpat_secret")).Return(token, nil).Once()
+	suite.tokenMockRepo.On("SetLastUsed", mock.Anything, token.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+	found, err := suite.tokenUsecase.Authenticate(context.Background(), "tmpat_secret")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), token, found)
+	assert.NotNil(suite.T(), found.LastUsedAt)
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestAuthenticate_RecentlyUsed() {
+	lastUsed := time.Now().Add(-time.Second)
+	token := &domain.PersonalAccessToken{ID: primitive.NewObjectID(), LastUsedAt: &lastUsed}
+	suite.tokenMockRepo.On("GetByTokenHash", mock.Anything, hashPersonalAccessToken("tmpat_secret")).Return(token, nil).Once()
+
+	// the last use is not written again so soon
+	_, err := suite.tokenUsecase.Authenticate(context.Background(), "tmpat_secret")
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestAuthenticate_Expired() {
+	expiresAt := time.Now().Add(-time.Minute)
+	token := &domain.PersonalAccessToken{ID: primitive.NewObjectID(), ExpiresAt: &expiresAt}
+	suite.tokenMockRepo.On("GetByTokenHash", mock.Anything, hashPersonalAccessToken("tmpat_secret")).Return(token, nil).Once()
+
+	_, err := suite.tokenUsecase.Authenticate(context.Background(), "tmpat_secret")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+}
+
+func (suite *PersonalAccessTokenUsecaseTestSuite) TestRevoke() {
+	tokenID := primitive.NewObjectID().Hex()
+	suite.tokenMockRepo.On("Delete", mock.Anything, suite.user.UserID, tokenID).Return(domain.ErrTokenNotFound).Once()
+
+	err := suite.tokenUsecase.Revoke(context.Background(), suite.user.UserID.Hex(), tokenID)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrTokenNotFound)
+}
+
+func TestPersonalAccessTokenUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenUsecaseTestSuite))
+}
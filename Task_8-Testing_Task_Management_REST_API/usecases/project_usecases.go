@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type projectUsecase struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	userRepository    domain.UserRepository
+	contextTimeout    time.Duration
+}
+
+func NewProjectUsecase(projectRepository domain.ProjectRepository, taskRepository domain.TaskRepository, userRepository domain.UserRepository, timeout time.Duration) domain.ProjectUsecase {
+	return &projectUsecase{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		userRepository:    userRepository,
+		contextTimeout:    timeout,
+	}
+}
+
+// Create stores a new project with the user with ID ownerID as its only member and owner.
+func (projectUC *projectUsecase) Create(c context.Context, project *domain.Project, ownerID string) error {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.Create")
+	defer end()
+
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return domain.ErrInvalidInput
+	}
+
+	ownerObjID, err := primitive.ObjectIDFromHex(ownerID)
+	if err != nil {
+		return domain.ErrForbidden
+	}
+
+	project.CreatedBy = ownerObjID
+	project.CreatedAt = time.Now().UTC()
+	project.Members = []domain.ProjectMember{
+		{UserID: ownerObjID, Role: domain.ProjectRoleOwner},
+	}
+
+	return projectUC.projectRepository.Create(ctx, project)
+}
+
+func (projectUC *projectUsecase) GetByID(c context.Context, projectID string) (*domain.Project, error) {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.GetByID")
+	defer end()
+	return projectUC.projectRepository.GetByID(ctx, projectID)
+}
+
+func (projectUC *projectUsecase) GetUserProjects(c context.Context, userID string) ([]domain.Project, error) {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.GetUserProjects")
+	defer end()
+	return projectUC.projectRepository.GetByMember(ctx, userID)
+}
+
+// Update changes the name and the description of a project.
+// An empty description clears it, while the name cannot be empty.
+func (projectUC *projectUsecase) Update(c context.Context, projectID string, name string, description string) error {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.Update")
+	defer end()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.ErrInvalidInput
+	}
+
+	project, err := projectUC.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	project.Name = name
+	project.Description = description
+
+	return projectUC.projectRepository.Update(ctx, project)
+}
+
+// Delete removes a project. Projects that still have tasks cannot be deleted,
+// their tasks have to be deleted first.
+func (projectUC *projectUsecase) Delete(c context.Context, projectID string) error {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.Delete")
+	defer end()
+
+	count, err := projectUC.taskRepository.CountTasksByProject(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrProjectNotEmpty
+	}
+
+	return projectUC.projectRepository.Delete(ctx, projectID)
+}
+
+// AddMember adds an existing user to the project with the given role,
+// or changes their role if they already are a member.
+func (projectUC *projectUsecase) AddMember(c context.Context, projectID string, userID string, role string) error {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.AddMember")
+	defer end()
+
+	if domain.ProjectRoleRank(role) == 0 {
+		return domain.ErrInvalidInput
+	}
+
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+	if _, err = projectUC.userRepository.GetByID(ctx, userID); err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	project, err := projectUC.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if role != domain.ProjectRoleOwner && isLastOwner(project, userID) {
+		return domain.ErrLastOwner
+	}
+
+	return projectUC.projectRepository.SetMember(ctx, projectID, domain.ProjectMember{UserID: userObjID, Role: role})
+}
+
+// RemoveMember removes a user from the project. The last owner of a project cannot be removed.
+func (projectUC *projectUsecase) RemoveMember(c context.Context, projectID string, userID string) error {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.RemoveMember")
+	defer end()
+
+	project, err := projectUC.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	if isLastOwner(project, userID) {
+		return domain.ErrLastOwner
+	}
+
+	return projectUC.projectRepository.RemoveMember(ctx, projectID, userID)
+}
+
+// GetMemberRole returns the role of the user in the project.
+// It returns domain.ErrNotMember if the user is not a member of the project.
+func (projectUC *projectUsecase) GetMemberRole(c context.Context, projectID string, userID string) (string, error) {
+	ctx, end := startSpan(c, projectUC.contextTimeout, "ProjectUsecase.GetMemberRole")
+	defer end()
+
+	project, err := projectUC.projectRepository.GetByID(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	return memberRole(project, userID)
+}
+
+// memberRole looks up the role of the user among the members of the project.
+func memberRole(project *domain.Project, userID string) (string, error) {
+	for _, member := range project.Members {
+		if member.UserID.Hex() == userID {
+			return member.Role, nil
+		}
+	}
+
+	return "", domain.ErrNotMember
+}
+
+// isLastOwner reports whether the user is the only owner of the project.
+func isLastOwner(project *domain.Project, userID string) bool {
+	owners := 0
+	isOwner := false
+	for _, member := range project.Members {
+		if member.Role == domain.ProjectRoleOwner {
+			owners++
+			isOwner = isOwner || member.UserID.Hex() == userID
+		}
+	}
+
+	return isOwner && owners == 1
+}
@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProjectUsecaseTestSuite struct {
+	suite.Suite
+	projectUsecase  *projectUsecase
+	projectMockRepo *mocks.ProjectRepository
+	taskMockRepo    *mocks.TaskRepository
+	userMockRepo    *mocks.UserRepository
+}
+
+// SetupTest runs before each test in the suite
+func (suite *ProjectUsecaseTestSuite) SetupTest() {
+	suite.projectMockRepo = new(mocks.ProjectRepository)
+	suite.taskMockRepo = new(mocks.TaskRepository)
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.projectUsecase = &projectUsecase{
+		projectRepository: suite.projectMockRepo,
+		taskRepository:    suite.taskMockRepo,
+		userRepository:    suite.userMockRepo,
+		contextTimeout:    time.Second * 2,
+	}
+}
+
+func (suite *ProjectUsecaseTestSuite) TearDownTest() {
+	suite.projectMockRepo.AssertExpectations(suite.T())
+	suite.taskMockRepo.AssertExpectations(suite.T())
+	suite.userMockRepo.AssertExpectations(suite.T())
+}
+
+// ownedProject returns a project whose only member is the given owner
+func ownedProject(owner primitive.ObjectID) *domain.Project {
+	return &domain.Project{
+		ID:      primitive.NewObjectID(),
+		Name:    "test project",
+		Members: []domain.ProjectMember{{UserID: owner, Role: domain.ProjectRoleOwner}},
+	}
+}
+
+func (suite *ProjectUsecaseTestSuite) TestCreate_CreatorBecomesOwner() {
+	ownerID := primitive.NewObjectID()
+	project := &domain.Project{Name: "  test project  "}
+
+	suite.projectMockRepo.On("Create", mock.Anything, project).Return(nil).Once()
+
+	err := suite.projectUsecase.Create(context.Background(), project, ownerID.Hex())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "test project", project.Name)
+	assert.Equal(suite.T(), ownerID, project.CreatedBy)
+	assert.Equal(suite.T(), []domain.ProjectMember{{UserID: ownerID, Role: domain.ProjectRoleOwner}}, project.Members)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestCreate_EmptyName() {
+	err := suite.projectUsecase.Create(context.Background(), &domain.Project{Name: "   "}, primitive.NewObjectID().Hex())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestDelete_NotEmpty() {
+	projectID := primitive.NewObjectID().Hex()
+
+	suite.taskMockRepo.On("CountTasksByProject", mock.Anything, projectID).Return(int64(2), nil).Once()
+
+	err := suite.projectUsecase.Delete(context.Background(), projectID)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrProjectNotEmpty)
+	suite.projectMockRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, projectID)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestDelete_Empty() {
+	projectID := primitive.NewObjectID().Hex()
+
+	suite.taskMockRepo.On("CountTasksByProject", mock.Anything, projectID).Return(int64(0), nil).Once()
+	suite.projectMockRepo.On("Delete", mock.Anything, projectID).Return(nil).Once()
+
+	err := suite.projectUsecase.Delete(context.Background(), projectID)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestAddMember() {
+	project := ownedProject(primitive.NewObjectID())
+	user := &domain.User{UserID: primitive.NewObjectID()}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.projectMockRepo.On("GetByID", mock.Anything, project.ID.Hex()).Return(project, nil).Once()
+	suite.projectMockRepo.On("SetMember", mock.Anything, project.ID.Hex(), domain.ProjectMember{UserID: user.UserID, Role: domain.ProjectRoleViewer}).Return(nil).Once()
+
+	err := suite.projectUsecase.AddMember(context.Background(), project.ID.Hex(), user.UserID.Hex(), domain.ProjectRoleViewer)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestAddMember_InvalidRole() {
+	err := suite.projectUsecase.AddMember(context.Background(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), "superuser")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestAddMember_UnknownUser() {
+	userID := primitive.NewObjectID().Hex()
+
+	suite.userMockRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{}, errors.New("mongo: no documents in result")).Once()
+
+	err := suite.projectUsecase.AddMember(context.Background(), primitive.NewObjectID().Hex(), userID, domain.ProjectRoleMember)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrUserNotFound)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestAddMember_DemoteLastOwner() {
+	owner := &domain.User{UserID: primitive.NewObjectID()}
+	project := ownedProject(owner.UserID)
+
+	suite.userMockRepo.On("GetByID", mock.Anything, owner.UserID.Hex()).Return(owner, nil).Once()
+	suite.projectMockRepo.On("GetByID", mock.Anything, project.ID.Hex()).Return(project, nil).Once()
+
+	err := suite.projectUsecase.AddMember(context.Background(), project.ID.Hex(), owner.UserID.Hex(), domain.ProjectRoleMember)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrLastOwner)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestRemoveMember_LastOwner() {
+	owner := primitive.NewObjectID()
+	project := ownedProject(owner)
+
+	suite.projectMockRepo.On("GetByID", mock.Anything, project.ID.Hex()).Return(project, nil).Once()
+
+	err := suite.projectUsecase.RemoveMember(context.Background(), project.ID.Hex(), owner.Hex())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrLastOwner)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestRemoveMember_OneOfTwoOwners() {
+	owner := primitive.NewObjectID()
+	coOwner := primitive.NewObjectID()
+	project := ownedProject(owner)
+	project.Members = append(project.Members, domain.ProjectMember{UserID: coOwner, Role: domain.ProjectRoleOwner})
+
+	suite.projectMockRepo.On("GetByID", mock.Anything, project.ID.Hex()).Return(project, nil).Once()
+	suite.projectMockRepo.On("RemoveMember", mock.Anything, project.ID.Hex(), owner.Hex()).Return(nil).Once()
+
+	err := suite.projectUsecase.RemoveMember(context.Background(), project.ID.Hex(), owner.Hex())
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ProjectUsecaseTestSuite) TestGetMemberRole() {
+	owner := primitive.NewObjectID()
+	project := ownedProject(owner)
+
+	suite.projectMockRepo.On("GetByID", mock.Anything, project.ID.Hex()).Return(project, nil).Twice()
+
+	role, err := suite.projectUsecase.GetMemberRole(context.Background(), project.ID.Hex(), owner.Hex())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.ProjectRoleOwner, role)
+
+	_, err = suite.projectUsecase.GetMemberRole(context.Background(), project.ID.Hex(), primitive.NewObjectID().Hex())
+	assert.ErrorIs(suite.T(), err, domain.ErrNotMember)
+}
+
+func TestProjectUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectUsecaseTestSuite))
+}
@@ -3,47 +3,242 @@ package usecases
 import (
 	"Task_8-Testing_Task_Management_REST_API/domain"
 	"context"
+	"fmt"
+	"strings"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type taskUsecase struct {
-	taskRepository domain.TaskRepository
-	contextTimeout time.Duration
+	taskRepository    domain.TaskRepository
+	commentRepository domain.CommentRepository
+	userRepository    domain.UserRepository
+	projectRepository domain.ProjectRepository
+	blobStorage       domain.BlobStorage
+	roles             domain.Roles
+	contextTimeout    time.Duration
 }
 
-func NewTaskUsecase(taskRepository domain.TaskRepository, timeout time.Duration) domain.TaskUsecase {
+func NewTaskUsecase(taskRepository domain.TaskRepository, commentRepository domain.CommentRepository, userRepository domain.UserRepository, projectRepository domain.ProjectRepository, blobStorage domain.BlobStorage, roles domain.Roles, timeout time.Duration) domain.TaskUsecase {
 	return &taskUsecase{
-		taskRepository: taskRepository,
-		contextTimeout: timeout,
+		taskRepository:    taskRepository,
+		commentRepository: commentRepository,
+		userRepository:    userRepository,
+		projectRepository: projectRepository,
+		blobStorage:       blobStorage,
+		roles:             roles,
+		contextTimeout:    timeout,
 	}
 }
 
 func (taskUC *taskUsecase) Create(c context.Context, task *domain.Task) error {
-	ctx, cancel := context.WithTimeout(c, taskUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.Create")
+	defer end()
+
+	// attachments can only be added through the attachment endpoints
+	task.Attachments = nil
+	if task.Status == "" {
+		task.Status = domain.TaskStatusPending
+	}
+
+	// a task created without a project is not scoped to any, like the tasks created before projects existed
+	var project *domain.Project
+	if !task.ProjectID.IsZero() {
+		var err error
+		project, err = taskUC.projectRepository.GetByID(ctx, task.ProjectID.Hex())
+		if err != nil {
+			return fmt.Errorf("%w: project '%v' does not exist", domain.ErrInvalidInput, task.ProjectID.Hex())
+		}
+	}
+
+	assignees, err := taskUC.validateAssignees(ctx, project, task.Assignees)
+	if err != nil {
+		return err
+	}
+	task.Assignees = assignees
+
 	return taskUC.taskRepository.Create(ctx, task)
 }
 
 func (taskUC *taskUsecase) GetTasks(c context.Context) ([]domain.Task, error) {
-	ctx, cancel := context.WithTimeout(c, taskUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.GetTasks")
+	defer end()
 	return taskUC.taskRepository.GetTasks(ctx)
 }
 
 func (taskUC *taskUsecase) GetTaskByID(c context.Context, taskID string) (domain.Task, error) {
-	ctx, cancel := context.WithTimeout(c, taskUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.GetTaskByID")
+	defer end()
 	return taskUC.taskRepository.GetTaskByID(ctx, taskID)
 }
 
 func (taskUC *taskUsecase) UpdateTask(c context.Context, taskID string, updated_task *domain.Task) error {
-	ctx, cancel := context.WithTimeout(c, taskUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.UpdateTask")
+	defer end()
 	return taskUC.taskRepository.UpdateTask(ctx, taskID, updated_task)
 }
 
+// DeleteTask deletes the task and cascades the deletion to its comments
+// and to the stored content of attachments no other task refers to.
 func (taskUC *taskUsecase) DeleteTask(c context.Context, taskID string) error {
-	ctx, cancel := context.WithTimeout(c, taskUC.contextTimeout)
-	defer cancel()
-	return taskUC.taskRepository.DeleteTask(ctx, taskID)
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.DeleteTask")
+	defer end()
+
+	task, err := taskUC.taskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	err = taskUC.taskRepository.DeleteTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	err = taskUC.commentRepository.DeleteByTaskID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range task.Attachments {
+		err = releaseBlob(ctx, taskUC.taskRepository, taskUC.blobStorage, attachment.Hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AssignUsers replaces the users assigned to the task with the users with the given IDs.
+// Every ID must belong to an existing user who is a member of the project of the task;
+// an empty list unassigns everyone.
+func (taskUC *taskUsecase) AssignUsers(c context.Context, taskID string, userIDs []string) error {
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.AssignUsers")
+	defer end()
+
+	task, err := taskUC.taskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+
+	// tasks created before projects existed are not scoped to any project
+	var project *domain.Project
+	if !task.ProjectID.IsZero() {
+		project, err = taskUC.projectRepository.GetByID(ctx, task.ProjectID.Hex())
+		if err != nil {
+			return err
+		}
+	}
+
+	assignees := make([]primitive.ObjectID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		objID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return fmt.Errorf("%w: user '%v' does not exist", domain.ErrInvalidInput, userID)
+		}
+		assignees = append(assignees, objID)
+	}
+
+	assignees, err = taskUC.validateAssignees(ctx, project, assignees)
+	if err != nil {
+		return err
+	}
+
+	return taskUC.taskRepository.SetAssignees(ctx, taskID, assignees)
+}
+
+// UpdateStatus changes the status of a task on behalf of one of its assignees or a user allowed to update any task.
+func (taskUC *taskUsecase) UpdateStatus(c context.Context, taskID string, actorID string, actorRole string, status string) error {
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.UpdateStatus")
+	defer end()
+
+	status = strings.TrimSpace(status)
+	if !domain.IsTaskStatus(status) {
+		return fmt.Errorf("%w: status must be one of %v", domain.ErrInvalidInput, strings.Join(domain.TaskStatuses, ", "))
+	}
+
+	task, err := taskUC.taskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return domain.ErrTaskNotFound
+	}
+
+	if !taskUC.roles.Has(actorRole, domain.PermissionTasksUpdate) && !isAssignee(task, actorID) {
+		return domain.ErrForbidden
+	}
+
+	return taskUC.taskRepository.UpdateTask(ctx, taskID, &domain.Task{Status: status})
+}
+
+// GetAssignedTasks returns the tasks the user with the given ID is assigned to.
+func (taskUC *taskUsecase) GetAssignedTasks(c context.Context, userID string) ([]domain.Task, error) {
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.GetAssignedTasks")
+	defer end()
+	return taskUC.taskRepository.GetTasksByAssignee(ctx, userID)
+}
+
+// GetProjectTasks returns the tasks of the project with the given ID.
+func (taskUC *taskUsecase) GetProjectTasks(c context.Context, projectID string) ([]domain.Task, error) {
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.GetProjectTasks")
+	defer end()
+	return taskUC.taskRepository.GetTasksByProject(ctx, projectID)
+}
+
+// GetTaskWithoutProject returns the task with the given ID if it doesn't belong to a project, as the tasks of
+// a project are only reached through it, and the actor may read it: with the 'tasks:read' permission or as
+// one of its assignees.
+func (taskUC *taskUsecase) GetTaskWithoutProject(c context.Context, taskID string, actorID string, actorRole string) (domain.Task, error) {
+	ctx, end := startSpan(c, taskUC.contextTimeout, "TaskUsecase.GetTaskWithoutProject")
+	defer end()
+
+	task, err := taskUC.taskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !task.ProjectID.IsZero() {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+
+	if !taskUC.roles.Has(actorRole, domain.PermissionTasksRead) && !isAssignee(task, actorID) {
+		return domain.Task{}, domain.ErrForbidden
+	}
+
+	return task, nil
+}
+
+// validateAssignees checks that every assignee is an existing user and, when a project is given,
+// a member of that project. Duplicated assignees are dropped.
+func (taskUC *taskUsecase) validateAssignees(c context.Context, project *domain.Project, assignees []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	seen := make(map[primitive.ObjectID]bool, len(assignees))
+	unique := make([]primitive.ObjectID, 0, len(assignees))
+
+	for _, assignee := range assignees {
+		if seen[assignee] {
+			continue
+		}
+		seen[assignee] = true
+
+		if _, err := taskUC.userRepository.GetByID(c, assignee.Hex()); err != nil {
+			return nil, fmt.Errorf("%w: user '%v' does not exist", domain.ErrInvalidInput, assignee.Hex())
+		}
+		if project != nil {
+			if _, err := memberRole(project, assignee.Hex()); err != nil {
+				return nil, fmt.Errorf("%w: user '%v' is not a member of the project", domain.ErrInvalidInput, assignee.Hex())
+			}
+		}
+		unique = append(unique, assignee)
+	}
+
+	return unique, nil
+}
+
+// isAssignee reports whether the user with the given ID is assigned to the task.
+func isAssignee(task domain.Task, userID string) bool {
+	for _, assignee := range task.Assignees {
+		if assignee.Hex() == userID {
+			return true
+		}
+	}
+
+	return false
 }
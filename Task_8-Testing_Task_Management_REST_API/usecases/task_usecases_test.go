@@ -4,6 +4,7 @@ import (
 	"Task_8-Testing_Task_Management_REST_API/domain"
 	"Task_8-Testing_Task_Management_REST_API/mocks"
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -15,31 +16,51 @@ import (
 
 type TaskUsecaseTestSuite struct {
 	suite.Suite
-	taskUsecase  *taskUsecase
-	taskMockRepo *mocks.TaskRepository
+	taskUsecase     *taskUsecase
+	taskMockRepo    *mocks.TaskRepository
+	commentMockRepo *mocks.CommentRepository
+	userMockRepo    *mocks.UserRepository
+	projectMockRepo *mocks.ProjectRepository
+	mockBlobStorage *mocks.BlobStorage
 }
 
 // setupSuite runs once before all tests in the suite
 func (suite *TaskUsecaseTestSuite) SetupSuite() {
 	suite.taskMockRepo = new(mocks.TaskRepository)
+	suite.commentMockRepo = new(mocks.CommentRepository)
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.projectMockRepo = new(mocks.ProjectRepository)
+	suite.mockBlobStorage = new(mocks.BlobStorage)
 	suite.taskUsecase = &taskUsecase{
-		taskRepository: suite.taskMockRepo,
-		contextTimeout: time.Second * 2,
+		taskRepository:    suite.taskMockRepo,
+		commentRepository: suite.commentMockRepo,
+		userRepository:    suite.userMockRepo,
+		projectRepository: suite.projectMockRepo,
+		blobStorage:       suite.mockBlobStorage,
+		roles:             domain.DefaultRoles(),
+		contextTimeout:    time.Second * 2,
 	}
 }
 
 func (suite *TaskUsecaseTestSuite) TearDownSuite() {
 	suite.taskMockRepo.AssertExpectations(suite.T())
+	suite.commentMockRepo.AssertExpectations(suite.T())
+	suite.userMockRepo.AssertExpectations(suite.T())
+	suite.projectMockRepo.AssertExpectations(suite.T())
+	suite.mockBlobStorage.AssertExpectations(suite.T())
 }
 
 func (suite *TaskUsecaseTestSuite) TestCreate() {
+	mockProject := &domain.Project{ID: primitive.NewObjectID()}
 	mockTask := &domain.Task{
+		ProjectID:   mockProject.ID,
 		Title:       "test title",
 		Description: "test description",
 		DueDate:     time.Now().UTC().Truncate(time.Second),
 		Status:      "test status",
 	}
 
+	suite.projectMockRepo.On("GetByID", mock.Anything, mockProject.ID.Hex()).Return(mockProject, nil).Once()
 	suite.taskMockRepo.On("Create", mock.Anything, mockTask).Return(nil)
 
 	err := suite.taskUsecase.Create(context.Background(), mockTask)
@@ -47,6 +68,48 @@ func (suite *TaskUsecaseTestSuite) TestCreate() {
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *TaskUsecaseTestSuite) TestCreate_WithoutProject() {
+	assignee := primitive.NewObjectID()
+	mockTask := &domain.Task{Title: "unscoped task", Assignees: []primitive.ObjectID{assignee}}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, assignee.Hex()).Return(&domain.User{UserID: assignee}, nil).Once()
+	suite.taskMockRepo.On("Create", mock.Anything, mockTask).Return(nil).Once()
+
+	err := suite.taskUsecase.Create(context.Background(), mockTask)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.TaskStatusPending, mockTask.Status)
+	suite.projectMockRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything, primitive.NilObjectID.Hex())
+}
+
+func (suite *TaskUsecaseTestSuite) TestCreate_UnknownProject() {
+	mockTask := &domain.Task{ProjectID: primitive.NewObjectID(), Title: "orphan task"}
+
+	suite.projectMockRepo.On("GetByID", mock.Anything, mockTask.ProjectID.Hex()).Return(nil, domain.ErrProjectNotFound).Once()
+
+	err := suite.taskUsecase.Create(context.Background(), mockTask)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+	suite.taskMockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mockTask)
+}
+
+func (suite *TaskUsecaseTestSuite) TestCreate_AssigneeOutsideProject() {
+	outsider := primitive.NewObjectID()
+	mockProject := &domain.Project{
+		ID:      primitive.NewObjectID(),
+		Members: []domain.ProjectMember{{UserID: primitive.NewObjectID(), Role: domain.ProjectRoleOwner}},
+	}
+	mockTask := &domain.Task{ProjectID: mockProject.ID, Title: "scoped task", Assignees: []primitive.ObjectID{outsider}}
+
+	suite.projectMockRepo.On("GetByID", mock.Anything, mockProject.ID.Hex()).Return(mockProject, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, outsider.Hex()).Return(&domain.User{UserID: outsider}, nil).Once()
+
+	err := suite.taskUsecase.Create(context.Background(), mockTask)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+	suite.taskMockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mockTask)
+}
+
 func (suite *TaskUsecaseTestSuite) TestGetTasks() {
 	mockTasks := []domain.Task{
 		{
@@ -109,18 +172,168 @@ func (suite *TaskUsecaseTestSuite) TestUpdateTask() {
 }
 
 func (suite *TaskUsecaseTestSuite) TestDeleteTask() {
-	mockTask := &domain.Task{
+	mockTask := domain.Task{
 		ID: primitive.NewObjectID(),
+		Attachments: []domain.Attachment{
+			{ID: primitive.NewObjectID(), Hash: "unshared-hash"},
+			{ID: primitive.NewObjectID(), Hash: "shared-hash"},
+		},
 	}
 
-	suite.taskMockRepo.On("DeleteTask", mock.Anything, mockTask.ID.Hex()).Return(nil)
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Once()
+	suite.taskMockRepo.On("DeleteTask", mock.Anything, mockTask.ID.Hex()).Return(nil).Once()
+	suite.commentMockRepo.On("DeleteByTaskID", mock.Anything, mockTask.ID.Hex()).Return(nil).Once()
+	suite.taskMockRepo.On("CountAttachmentsByHash", mock.Anything, "unshared-hash").Return(int64(0), nil).Once()
+	suite.taskMockRepo.On("CountAttachmentsByHash", mock.Anything, "shared-hash").Return(int64(1), nil).Once()
+	suite.mockBlobStorage.On("Delete", mock.Anything, "unshared-hash").Return(nil).Once()
 
 	err := suite.taskUsecase.DeleteTask(context.Background(), mockTask.ID.Hex())
 
-	// assert no error occured
+	// assert no error occured and only the unshared blob is removed
+	assert.NoError(suite.T(), err)
+	suite.mockBlobStorage.AssertNotCalled(suite.T(), "Delete", mock.Anything, "shared-hash")
+}
+
+func (suite *TaskUsecaseTestSuite) TestDeleteTask_NotFoundSkipsComments() {
+	taskID := primitive.NewObjectID().Hex()
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, taskID).Return(domain.Task{}, errors.New("task not found")).Once()
+
+	err := suite.taskUsecase.DeleteTask(context.Background(), taskID)
+
+	// assert the error is propagated and the comments are left untouched
+	assert.Error(suite.T(), err)
+	suite.commentMockRepo.AssertNotCalled(suite.T(), "DeleteByTaskID", mock.Anything, taskID)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAssignUsers() {
+	taskID := primitive.NewObjectID().Hex()
+	user := &domain.User{UserID: primitive.NewObjectID()}
+
+	// a task created before projects existed skips the membership check
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, taskID).Return(domain.Task{}, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.taskMockRepo.On("SetAssignees", mock.Anything, taskID, []primitive.ObjectID{user.UserID}).Return(nil).Once()
+
+	// assigning the same user twice only stores it once
+	err := suite.taskUsecase.AssignUsers(context.Background(), taskID, []string{user.UserID.Hex(), user.UserID.Hex()})
+
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *TaskUsecaseTestSuite) TestAssignUsers_UnknownUser() {
+	taskID := primitive.NewObjectID().Hex()
+	userID := primitive.NewObjectID().Hex()
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, taskID).Return(domain.Task{}, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{}, errors.New("mongo: no documents in result")).Once()
+
+	err := suite.taskUsecase.AssignUsers(context.Background(), taskID, []string{userID})
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+	suite.taskMockRepo.AssertNotCalled(suite.T(), "SetAssignees", mock.Anything, taskID, mock.Anything)
+}
+
+func (suite *TaskUsecaseTestSuite) TestAssignUsers_ProjectMember() {
+	member := primitive.NewObjectID()
+	mockProject := &domain.Project{
+		ID:      primitive.NewObjectID(),
+		Members: []domain.ProjectMember{{UserID: member, Role: domain.ProjectRoleMember}},
+	}
+	mockTask := domain.Task{ID: primitive.NewObjectID(), ProjectID: mockProject.ID}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Once()
+	suite.projectMockRepo.On("GetByID", mock.Anything, mockProject.ID.Hex()).Return(mockProject, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, member.Hex()).Return(&domain.User{UserID: member}, nil).Once()
+	suite.taskMockRepo.On("SetAssignees", mock.Anything, mockTask.ID.Hex(), []primitive.ObjectID{member}).Return(nil).Once()
+
+	err := suite.taskUsecase.AssignUsers(context.Background(), mockTask.ID.Hex(), []string{member.Hex()})
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *TaskUsecaseTestSuite) TestGetProjectTasks() {
+	projectID := primitive.NewObjectID()
+	mockTasks := []domain.Task{{ID: primitive.NewObjectID(), ProjectID: projectID, Title: "project task"}}
+
+	suite.taskMockRepo.On("GetTasksByProject", mock.Anything, projectID.Hex()).Return(mockTasks, nil).Once()
+
+	tasks, err := suite.taskUsecase.GetProjectTasks(context.Background(), projectID.Hex())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockTasks, tasks)
+}
+
+func (suite *TaskUsecaseTestSuite) TestUpdateStatus_ByAssignee() {
+	assignee := primitive.NewObjectID()
+	mockTask := domain.Task{ID: primitive.NewObjectID(), Assignees: []primitive.ObjectID{assignee}}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Once()
+	suite.taskMockRepo.On("UpdateTask", mock.Anything, mockTask.ID.Hex(), &domain.Task{Status: "done"}).Return(nil).Once()
+
+	err := suite.taskUsecase.UpdateStatus(context.Background(), mockTask.ID.Hex(), assignee.Hex(), "USER", "done")
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *TaskUsecaseTestSuite) TestUpdateStatus_ByNonAssignee() {
+	mockTask := domain.Task{ID: primitive.NewObjectID(), Assignees: []primitive.ObjectID{primitive.NewObjectID()}}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Once()
+
+	err := suite.taskUsecase.UpdateStatus(context.Background(), mockTask.ID.Hex(), primitive.NewObjectID().Hex(), "USER", "done")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+}
+
+func (suite *TaskUsecaseTestSuite) TestUpdateStatus_UnknownStatus() {
+	err := suite.taskUsecase.UpdateStatus(context.Background(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), "USER", "someday")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *TaskUsecaseTestSuite) TestGetTaskWithoutProject() {
+	assignee := primitive.NewObjectID()
+	mockTask := domain.Task{ID: primitive.NewObjectID(), Assignees: []primitive.ObjectID{assignee}}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Times(3)
+
+	// assignees and users allowed to read any task reach it, other users don't
+	task, err := suite.taskUsecase.GetTaskWithoutProject(context.Background(), mockTask.ID.Hex(), assignee.Hex(), "USER")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockTask, task)
+
+	_, err = suite.taskUsecase.GetTaskWithoutProject(context.Background(), mockTask.ID.Hex(), primitive.NewObjectID().Hex(), "ADMIN")
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.taskUsecase.GetTaskWithoutProject(context.Background(), mockTask.ID.Hex(), primitive.NewObjectID().Hex(), "USER")
+	assert.ErrorIs(suite.T(), err, domain.ErrForbidden)
+}
+
+func (suite *TaskUsecaseTestSuite) TestGetTaskWithoutProject_InProject() {
+	assignee := primitive.NewObjectID()
+	mockTask := domain.Task{ID: primitive.NewObjectID(), ProjectID: primitive.NewObjectID(), Assignees: []primitive.ObjectID{assignee}}
+
+	suite.taskMockRepo.On("GetTaskByID", mock.Anything, mockTask.ID.Hex()).Return(mockTask, nil).Once()
+
+	// the tasks of a project are only reached through the project
+	_, err := suite.taskUsecase.GetTaskWithoutProject(context.Background(), mockTask.ID.Hex(), assignee.Hex(), "USER")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrTaskNotFound)
+}
+
+func (suite *TaskUsecaseTestSuite) TestGetAssignedTasks() {
+	userID := primitive.NewObjectID().Hex()
+	mockTasks := []domain.Task{{ID: primitive.NewObjectID(), Title: "assigned task"}}
+
+	suite.taskMockRepo.On("GetTasksByAssignee", mock.Anything, userID).Return(mockTasks, nil).Once()
+
+	tasks, err := suite.taskUsecase.GetAssignedTasks(context.Background(), userID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), mockTasks, tasks)
+}
+
 // TestUserUsecaseTestSuite runs the test suite
 func TestTaskUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(TaskUsecaseTestSuite))
@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const tracerName = "Task_8-Testing_Task_Management_REST_API/usecases"
+
+// startSpan starts the span of a usecase method, named after the usecase and the method, and applies
+// the timeout of the usecase under it, so that the calls to the repositories are traced as its children.
+// The returned function ends both, marking the span as failed when the deadline was exceeded.
+func startSpan(c context.Context, timeout time.Duration, name string) (context.Context, func()) {
+	// the tracer is looked up every time, so that it follows the provider set up by bootstrap
+	ctx, span := otel.Tracer(tracerName).Start(c, name)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, func() {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			span.SetStatus(codes.Error, "deadline exceeded")
+		}
+		cancel()
+		span.End()
+	}
+}
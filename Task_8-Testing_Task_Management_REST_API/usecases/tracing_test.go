@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	recorder *tracetest.SpanRecorder
+	provider *sdktrace.TracerProvider
+}
+
+func (suite *TracingTestSuite) SetupTest() {
+	suite.recorder = tracetest.NewSpanRecorder()
+	suite.provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(suite.recorder))
+	otel.SetTracerProvider(suite.provider)
+}
+
+func (suite *TracingTestSuite) TestStartSpan_ChildOfTheRequest() {
+	parent, requestSpan := suite.provider.Tracer("test").Start(context.Background(), "GET /v1/tasks")
+
+	ctx, end := startSpan(parent, time.Second, "TaskUsecase.GetTasks")
+	_, hasDeadline := ctx.Deadline()
+	end()
+	requestSpan.End()
+
+	suite.True(hasDeadline)
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 2)
+	suite.Equal("TaskUsecase.GetTasks", spans[0].Name())
+	suite.Equal(requestSpan.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	suite.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (suite *TracingTestSuite) TestStartSpan_DeadlineExceeded() {
+	ctx, end := startSpan(context.Background(), time.Millisecond, "TaskUsecase.GetTasks")
+	<-ctx.Done()
+	end()
+
+	spans := suite.recorder.Ended()
+	suite.Require().Len(spans, 1)
+	suite.Equal(codes.Error, spans[0].Status().Code)
+	suite.Equal("deadline exceeded", spans[0].Status().Description)
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
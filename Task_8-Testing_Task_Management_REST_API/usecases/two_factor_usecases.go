@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RecoveryCodeCount is the number of recovery codes handed out when two-factor authentication is enabled.
+const RecoveryCodeCount = 10
+
+type twoFactorUsecase struct {
+	userRepository domain.UserRepository
+	issuer         string
+	secret         string
+	challengeTTL   time.Duration
+	requiredRoles  []string
+	contextTimeout time.Duration
+}
+
+// NewTwoFactorUsecase creates the usecase managing the second factor of users.
+// Authenticator apps show the accounts under the name of the issuer. The login challenge tokens are signed with secret
+// and stay valid for challengeTTL. Users with one of the requiredRoles cannot disable two-factor authentication.
+func NewTwoFactorUsecase(userRepository domain.UserRepository, issuer string, secret string, challengeTTL time.Duration, requiredRoles []string, timeout time.Duration) domain.TwoFactorUsecase {
+	return &twoFactorUsecase{
+		userRepository: userRepository,
+		issuer:         issuer,
+		secret:         secret,
+		challengeTTL:   challengeTTL,
+		requiredRoles:  requiredRoles,
+		contextTimeout: timeout,
+	}
+}
+
+// Enroll generates a new TOTP secret for the user. It is only used for logins once confirmed with Confirm,
+// and enrolling again before confirming replaces it.
+func (twoFactorUC *twoFactorUsecase) Enroll(c context.Context, userID string) (*domain.TwoFactorEnrolment, error) {
+	ctx, end := startSpan(c, twoFactorUC.contextTimeout, "TwoFactorUsecase.Enroll")
+	defer end()
+
+	user, err := twoFactorUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := infrastructure.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err = twoFactorUC.userRepository.UpdateTwoFactor(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorEnrolment{
+		Secret: secret,
+		URI:    infrastructure.TOTPURI(twoFactorUC.issuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables two-factor authentication once the user proves, with a code of their authenticator app,
+// that they enrolled the secret. It returns the recovery codes, which are only stored hashed and never shown again.
+func (twoFactorUC *twoFactorUsecase) Confirm(c context.Context, userID string, code string) ([]string, error) {
+	ctx, end := startSpan(c, twoFactorUC.contextTimeout, "TwoFactorUsecase.Confirm")
+	defer end()
+
+	user, err := twoFactorUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, fmt.Errorf("%w: two-factor authentication has to be enrolled first", domain.ErrInvalidInput)
+	}
+
+	step, valid := infrastructure.ValidateTOTP(user.TOTPSecret, strings.TrimSpace(code), time.Now(), user.TOTPLastStep)
+	if !valid {
+		return nil, domain.ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		recoveryCode, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, recoveryCode)
+		hashes = append(hashes, hashRecoveryCode(recoveryCode))
+	}
+
+	user.TwoFactorEnabled = true
+	user.TOTPLastStep = step
+	user.RecoveryCodes = hashes
+	if err = twoFactorUC.userRepository.UpdateTwoFactor(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off, given a valid code. Users whose role requires it cannot disable it.
+func (twoFactorUC *twoFactorUsecase) Disable(c context.Context, userID string, code string) error {
+	ctx, end := startSpan(c, twoFactorUC.contextTimeout, "TwoFactorUsecase.Disable")
+	defer end()
+
+	user, err := twoFactorUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+	if slices.Contains(twoFactorUC.requiredRoles, user.Role) {
+		return domain.ErrTwoFactorRequired
+	}
+
+	if err = twoFactorUC.useCode(ctx, user, code); err != nil {
+		return err
+	}
+
+	user.TwoFactorEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.RecoveryCodes = nil
+	return twoFactorUC.userRepository.UpdateTwoFactor(ctx, user)
+}
+
+// CreateChallenge signs the token handed out to a user who entered their password, to be sent back with a code.
+func (twoFactorUC *twoFactorUsecase) CreateChallenge(user *domain.User) string {
+	return infrastructure.CreateLoginChallengeToken(user.UserID.Hex(), time.Now().Add(twoFactorUC.challengeTTL), twoFactorUC.secret)
+}
+
+// GetChallengeUser returns the user a challenge token was issued for.
+// It returns domain.ErrInvalidToken if the token has expired, or if the user is gone, disabled
+// or has turned two-factor authentication off since.
+func (twoFactorUC *twoFactorUsecase) GetChallengeUser(c context.Context, challengeToken string) (*domain.User, error) {
+	ctx, end := startSpan(c, twoFactorUC.contextTimeout, "TwoFactorUsecase.GetChallengeUser")
+	defer end()
+
+	userID, err := infrastructure.ParseLoginChallengeToken(challengeToken, twoFactorUC.secret, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := twoFactorUC.userRepository.GetByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled || !user.TwoFactorEnabled {
+		return nil, domain.ErrInvalidToken
+	}
+
+	return user, nil
+}
+
+// VerifyCode checks a code of the user, either from their authenticator app or one of their recovery codes,
+// and makes sure it cannot be used again.
+func (twoFactorUC *twoFactorUsecase) VerifyCode(c context.Context, user *domain.User, code string) error {
+	ctx, end := startSpan(c, twoFactorUC.contextTimeout, "TwoFactorUsecase.VerifyCode")
+	defer end()
+
+	return twoFactorUC.useCode(ctx, user, code)
+}
+
+// useCode checks the code against the TOTP secret, then against the recovery codes of the user,
+// and records it as used, on the user and in the repository. It returns domain.ErrInvalidTwoFactorCode
+// if the code is wrong, or was used since the user was read, such as by a concurrent login.
+func (twoFactorUC *twoFactorUsecase) useCode(c context.Context, user *domain.User, code string) error {
+	code = strings.TrimSpace(code)
+	userID := user.UserID.Hex()
+
+	step, valid := infrastructure.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+	if valid {
+		used, err := twoFactorUC.userRepository.UseTOTPStep(c, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return domain.ErrInvalidTwoFactorCode
+		}
+
+		user.TOTPLastStep = step
+		return nil
+	}
+
+	hash := hashRecoveryCode(code)
+	index := slices.Index(user.RecoveryCodes, hash)
+	if index < 0 {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	used, err := twoFactorUC.userRepository.UseRecoveryCode(c, userID, hash)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	user.RecoveryCodes = slices.Delete(user.RecoveryCodes, index, index+1)
+	return nil
+}
+
+// newRecoveryCode generates a random recovery code, such as "abcd-efgh", that is easy to type.
+func newRecoveryCode() (string, error) {
+	random := make([]byte, 5)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(random))
+	return code[:4] + "-" + code[4:], nil
+}
+
+// hashRecoveryCode returns the form of the recovery code that is stored in the database,
+// ignoring the case and the separator of the code as typed by the user.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(code, "-", ""))
+
+	hash := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hash[:])
+}
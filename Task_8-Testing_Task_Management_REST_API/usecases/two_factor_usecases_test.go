@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"Task_8-Testing_Task_Management_REST_API/domain"
+	"Task_8-Testing_Task_Management_REST_API/infrastructure"
+	"Task_8-Testing_Task_Management_REST_API/mocks"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TwoFactorUsecaseTestSuite struct {
+	suite.Suite
+	twoFactorUsecase *twoFactorUsecase
+	userMockRepo     *mocks.UserRepository
+	user             *domain.User
+}
+
+// SetupTest runs before each test in the suite
+func (suite *TwoFactorUsecaseTestSuite) SetupTest() {
+	suite.userMockRepo = new(mocks.UserRepository)
+	suite.twoFactorUsecase = &twoFactorUsecase{
+		userRepository: suite.userMockRepo,
+		issuer:         "TaskManager",
+		secret:         "test secret",
+		challengeTTL:   time.Minute,
+		requiredRoles:  []string{domain.RoleAdmin},
+		contextTimeout: time.Second * 2,
+	}
+
+	secret, err := infrastructure.GenerateTOTPSecret()
+	suite.Require().NoError(err)
+	suite.user = &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", Role: domain.RoleUser, TOTPSecret: secret}
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TearDownTest() {
+	suite.userMockRepo.AssertExpectations(suite.T())
+}
+
+// currentCode returns the code the authenticator app of the test user shows now
+func (suite *TwoFactorUsecaseTestSuite) currentCode() string {
+	code, err := infrastructure.TOTPCode(suite.user.TOTPSecret, time.Now())
+	suite.Require().NoError(err)
+	return code
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestEnroll_StoresSecret() {
+	suite.user.TOTPSecret = ""
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+	suite.userMockRepo.On("UpdateTwoFactor", mock.Anything, suite.user).Return(nil).Once()
+
+	enrolment, err := suite.twoFactorUsecase.Enroll(context.Background(), suite.user.UserID.Hex())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.user.TOTPSecret, enrolment.Secret)
+	assert.True(suite.T(), strings.HasPrefix(enrolment.URI, "otpauth://totp/TaskManager:test@example.com?"))
+	assert.False(suite.T(), suite.user.TwoFactorEnabled)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestEnroll_AlreadyEnabled() {
+	suite.user.TwoFactorEnabled = true
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	_, err := suite.twoFactorUsecase.Enroll(context.Background(), suite.user.UserID.Hex())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrTwoFactorAlreadyEnabled)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestConfirm_EnablesAndReturnsRecoveryCodes() {
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+	suite.userMockRepo.On("UpdateTwoFactor", mock.Anything, suite.user).Return(nil).Once()
+
+	codes, err := suite.twoFactorUsecase.Confirm(context.Background(), suite.user.UserID.Hex(), suite.currentCode())
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), suite.user.TwoFactorEnabled)
+	assert.Len(suite.T(), codes, RecoveryCodeCount)
+	assert.Len(suite.T(), suite.user.RecoveryCodes, RecoveryCodeCount)
+	// only the hashes of the codes are stored
+	assert.NotContains(suite.T(), suite.user.RecoveryCodes, codes[0])
+	assert.Contains(suite.T(), suite.user.RecoveryCodes, hashRecoveryCode(codes[0]))
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestConfirm_InvalidCode() {
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	_, err := suite.twoFactorUsecase.Confirm(context.Background(), suite.user.UserID.Hex(), "abcdef")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidTwoFactorCode)
+	assert.False(suite.T(), suite.user.TwoFactorEnabled)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestConfirm_NotEnrolled() {
+	suite.user.TOTPSecret = ""
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	_, err := suite.twoFactorUsecase.Confirm(context.Background(), suite.user.UserID.Hex(), "123456")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestDisable_Success() {
+	suite.user.TwoFactorEnabled = true
+	suite.user.RecoveryCodes = []string{hashRecoveryCode("abcd-efgh")}
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+	suite.userMockRepo.On("UseTOTPStep", mock.Anything, suite.user.UserID.Hex(), mock.AnythingOfType("int64")).Return(true, nil).Once()
+	suite.userMockRepo.On("UpdateTwoFactor", mock.Anything, suite.user).Return(nil).Once()
+
+	err := suite.twoFactorUsecase.Disable(context.Background(), suite.user.UserID.Hex(), suite.currentCode())
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), suite.user.TwoFactorEnabled)
+	assert.Empty(suite.T(), suite.user.TOTPSecret)
+	assert.Empty(suite.T(), suite.user.RecoveryCodes)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestDisable_RequiredForRole() {
+	suite.user.TwoFactorEnabled = true
+	suite.user.Role = domain.RoleAdmin
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	err := suite.twoFactorUsecase.Disable(context.Background(), suite.user.UserID.Hex(), suite.currentCode())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrTwoFactorRequired)
+	assert.True(suite.T(), suite.user.TwoFactorEnabled)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestChallenge_RoundTrip() {
+	suite.user.TwoFactorEnabled = true
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	token := suite.twoFactorUsecase.CreateChallenge(suite.user)
+	user, err := suite.twoFactorUsecase.GetChallengeUser(context.Background(), token)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.user, user)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestChallenge_TwoFactorDisabledSince() {
+	suite.userMockRepo.On("GetByID", mock.Anything, suite.user.UserID.Hex()).Return(suite.user, nil).Once()
+
+	token := suite.twoFactorUsecase.CreateChallenge(suite.user)
+	_, err := suite.twoFactorUsecase.GetChallengeUser(context.Background(), token)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestChallenge_Tampered() {
+	_, err := suite.twoFactorUsecase.GetChallengeUser(context.Background(), "not a token")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidToken)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestVerifyCode_CannotBeReplayed() {
+	suite.user.TwoFactorEnabled = true
+	suite.userMockRepo.On("UseTOTPStep", mock.Anything, suite.user.UserID.Hex(), mock.AnythingOfType("int64")).Return(true, nil).Once()
+
+	code := suite.currentCode()
+	err := suite.twoFactorUsecase.VerifyCode(context.Background(), suite.user, code)
+	assert.NoError(suite.T(), err)
+
+	err = suite.twoFactorUsecase.VerifyCode(context.Background(), suite.user, code)
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidTwoFactorCode)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestVerifyCode_RecoveryCodeIsSingleUse() {
+	suite.user.TwoFactorEnabled = true
+	suite.user.RecoveryCodes = []string{hashRecoveryCode("abcd-efgh"), hashRecoveryCode("ijkl-mnop")}
+	suite.userMockRepo.On("UseRecoveryCode", mock.Anything, suite.user.UserID.Hex(), hashRecoveryCode("abcd-efgh")).Return(true, nil).Once()
+
+	// recovery codes can be typed without the separator and in any case
+	err := suite.twoFactorUsecase.VerifyCode(context.Background(), suite.user, "ABCDEFGH")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{hashRecoveryCode("ijkl-mnop")}, suite.user.RecoveryCodes)
+
+	err = suite.twoFactorUsecase.VerifyCode(context.Background(), suite.user, "abcd-efgh")
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidTwoFactorCode)
+}
+
+func (suite *TwoFactorUsecaseTestSuite) TestVerifyCode_UsedConcurrently() {
+	suite.user.TwoFactorEnabled = true
+	suite.user.RecoveryCodes = []string{hashRecoveryCode("abcd-efgh")}
+
+	// another login used the codes since the user was read, so the repository no longer accepts them
+	suite.userMockRepo.On("UseTOTPStep", mock.Anything, suite.user.UserID.Hex(), mock.AnythingOfType("int64")).Return(false, nil).Once()
+	suite.userMockRepo.On("UseRecoveryCode", mock.Anything, suite.user.UserID.Hex(), hashRecoveryCode("abcd-efgh")).Return(false, nil).Once()
+
+	err := suite.twoFactorUsecase.VerifyCode(context.Background(), suite.user, suite.currentCode())
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidTwoFactorCode)
+
+	err = suite.twoFactorUsecase.VerifyCode(context.Background(), suite.user, "abcd-efgh")
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidTwoFactorCode)
+	assert.Len(suite.T(), suite.user.RecoveryCodes, 1)
+}
+
+func TestTwoFactorUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorUsecaseTestSuite))
+}
@@ -4,51 +4,313 @@ import (
 	"Task_8-Testing_Task_Management_REST_API/domain"
 	"Task_8-Testing_Task_Management_REST_API/infrastructure"
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
 type userUsecase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	taskRepository         domain.TaskRepository
+	projectRepository      domain.ProjectRepository
+	commentRepository      domain.CommentRepository
+	tokenRepository        domain.PersonalAccessTokenRepository
+	loginAttemptRepository domain.LoginAttemptRepository
+	contextTimeout         time.Duration
 }
 
-func NewUserUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.UserUsecase {
+func NewUserUsecase(userRepository domain.UserRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, commentRepository domain.CommentRepository, tokenRepository domain.PersonalAccessTokenRepository, loginAttemptRepository domain.LoginAttemptRepository, timeout time.Duration) domain.UserUsecase {
 	return &userUsecase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		taskRepository:         taskRepository,
+		projectRepository:      projectRepository,
+		commentRepository:      commentRepository,
+		tokenRepository:        tokenRepository,
+		loginAttemptRepository: loginAttemptRepository,
+		contextTimeout:         timeout,
 	}
 }
 
 func (userUC *userUsecase) Create(c context.Context, user *domain.User) error {
-	ctx, cancel := context.WithTimeout(c, userUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.Create")
+	defer end()
 	return userUC.userRepository.Create(ctx, user)
 }
 
 func (userUC *userUsecase) GetByEmail(c context.Context, email string) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(c, userUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.GetByEmail")
+	defer end()
 	return userUC.userRepository.GetByEmail(ctx, email)
 }
 
 func (userUC *userUsecase) GetByID(c context.Context, userID string) (*domain.User, error) {
-	ctx, cancel := context.WithTimeout(c, userUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.GetByID")
+	defer end()
 	return userUC.userRepository.GetByID(ctx, userID)
 }
 
 func (userUC *userUsecase) UpdateUser(c context.Context, updated_user *domain.User) error {
-	ctx, cancel := context.WithTimeout(c, userUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.UpdateUser")
+	defer end()
 	return userUC.userRepository.UpdateUser(ctx, updated_user)
 }
 
+// SetPassword replaces the password hash of the user and ends their sessions,
+// the tokens issued before being rejected from now on.
+func (userUC *userUsecase) SetPassword(c context.Context, userID string, hashedPassword string) error {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.SetPassword")
+	defer end()
+	return userUC.userRepository.SetPassword(ctx, userID, hashedPassword)
+}
+
+// ReplacePasswordHash replaces the password hash of the user by another hash of the same password,
+// unless the password was changed since oldHash was read.
+func (userUC *userUsecase) ReplacePasswordHash(c context.Context, userID string, oldHash string, newHash string) (bool, error) {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.ReplacePasswordHash")
+	defer end()
+	return userUC.userRepository.ReplacePasswordHash(ctx, userID, oldHash, newHash)
+}
+
 func (userUC *userUsecase) AreThereAnyUsers(c context.Context) (bool, error) {
-	ctx, cancel := context.WithTimeout(c, userUC.contextTimeout)
-	defer cancel()
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.AreThereAnyUsers")
+	defer end()
 	return userUC.userRepository.AreThereAnyUsers(ctx)
 }
 
 func (loginUsecase *userUsecase) CreateAccessToken(user *domain.User, secret string, expiry int) (string, error) {
 	return infrastructure.CreateAccessToken(user, secret, expiry)
 }
+
+// GetUsers returns one page of the users matching the filter.
+func (userUC *userUsecase) GetUsers(c context.Context, filter domain.UserFilter, pagination domain.Pagination) (*domain.UserPage, error) {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.GetUsers")
+	defer end()
+
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	users, total, err := userUC.userRepository.GetUsers(ctx, filter, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]domain.UserProfile, 0, len(users))
+	for i := range users {
+		profiles = append(profiles, users[i].Profile())
+	}
+
+	return &domain.UserPage{Users: profiles, Pagination: pagination, Total: total}, nil
+}
+
+// Promote gives the user the 'ADMIN' role.
+func (userUC *userUsecase) Promote(c context.Context, userID string) error {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.Promote")
+	defer end()
+	return userUC.userRepository.SetRole(ctx, userID, domain.RoleAdmin)
+}
+
+// Demote gives the user the 'USER' role back. The last active admin cannot be demoted.
+func (userUC *userUsecase) Demote(c context.Context, userID string) error {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.Demote")
+	defer end()
+
+	user, err := userUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role == domain.RoleUser {
+		return nil
+	}
+
+	if err = userUC.checkNotLastAdmin(ctx, user); err != nil {
+		return err
+	}
+
+	if err = userUC.userRepository.SetRole(ctx, userID, domain.RoleUser); err != nil {
+		return err
+	}
+
+	return userUC.keepAnAdmin(ctx, user, func() error {
+		return userUC.userRepository.SetRole(ctx, userID, domain.RoleAdmin)
+	})
+}
+
+// SetDisabled disables or re-enables the account of the user.
+// Disabled users are rejected by JWTAuthMiddleware even if they hold a valid token.
+// The last active admin cannot be disabled.
+func (userUC *userUsecase) SetDisabled(c context.Context, userID string, disabled bool) error {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.SetDisabled")
+	defer end()
+
+	user, err := userUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Disabled == disabled {
+		return nil
+	}
+
+	if disabled {
+		if err = userUC.checkNotLastAdmin(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	if err = userUC.userRepository.SetDisabled(ctx, userID, disabled); err != nil {
+		return err
+	}
+	if !disabled {
+		return nil
+	}
+
+	return userUC.keepAnAdmin(ctx, user, func() error {
+		return userUC.userRepository.SetDisabled(ctx, userID, false)
+	})
+}
+
+// Delete removes the user. Depending on taskPolicy, the tasks assigned to the user are either
+// reassigned to the user with ID replacementID, who also takes over their project memberships,
+// or simply lose the user as an assignee. Their personal access tokens and failed logins are
+// removed, and their comments deleted like they would delete them. The last active admin cannot
+// be deleted, and with the orphan policy neither can the last owner of a project.
+func (userUC *userUsecase) Delete(c context.Context, userID string, taskPolicy string, replacementID string) error {
+	ctx, end := startSpan(c, userUC.contextTimeout, "UserUsecase.Delete")
+	defer end()
+
+	user, err := userUC.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err = userUC.checkNotLastAdmin(ctx, user); err != nil {
+		return err
+	}
+
+	var replacement *domain.User
+	switch taskPolicy {
+	case domain.TaskPolicyOrphan:
+	case domain.TaskPolicyReassign:
+		if replacementID == userID {
+			return fmt.Errorf("%w: a user cannot be replaced by themselves", domain.ErrInvalidInput)
+		}
+		replacement, err = userUC.userRepository.GetByID(ctx, replacementID)
+		if err != nil || replacement.Disabled {
+			return fmt.Errorf("%w: replacement user '%v' does not exist or is disabled", domain.ErrInvalidInput, replacementID)
+		}
+	default:
+		return fmt.Errorf("%w: task policy must be '%v' or '%v'", domain.ErrInvalidInput, domain.TaskPolicyOrphan, domain.TaskPolicyReassign)
+	}
+
+	projects, err := userUC.projectRepository.GetByMember(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// check every project before changing anything
+	if replacement == nil {
+		for i := range projects {
+			if isLastOwner(&projects[i], userID) {
+				return fmt.Errorf("%w: '%v' has no other owner", domain.ErrLastOwner, projects[i].Name)
+			}
+		}
+	}
+
+	// the user is disabled while the rest is removed, which is undone if it leaves no active admin
+	if err = userUC.userRepository.SetDisabled(ctx, userID, true); err != nil {
+		return err
+	}
+	err = userUC.keepAnAdmin(ctx, user, func() error {
+		return userUC.userRepository.SetDisabled(ctx, userID, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	if replacement != nil {
+		err = userUC.taskRepository.ReassignUser(ctx, userID, replacementID)
+	} else {
+		err = userUC.taskRepository.UnassignUser(ctx, userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range projects {
+		if replacement != nil {
+			err = userUC.handOverMembership(ctx, &projects[i], userID, replacement)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = userUC.projectRepository.RemoveMember(ctx, projects[i].ID.Hex(), userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = userUC.tokenRepository.DeleteByUserID(ctx, user.UserID); err != nil {
+		return err
+	}
+	if err = userUC.commentRepository.DeleteByAuthor(ctx, userID, time.Now().UTC()); err != nil {
+		return err
+	}
+	if err = userUC.loginAttemptRepository.Reset(ctx, accountKey(user.Email)); err != nil {
+		return err
+	}
+
+	return userUC.userRepository.DeleteUser(ctx, userID)
+}
+
+// checkNotLastAdmin returns domain.ErrLastAdmin if the user is the only active admin left.
+func (userUC *userUsecase) checkNotLastAdmin(c context.Context, user *domain.User) error {
+	if user.Role != domain.RoleAdmin || user.Disabled {
+		return nil
+	}
+
+	count, err := userUC.userRepository.CountActiveByRole(c, domain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return domain.ErrLastAdmin
+	}
+
+	return nil
+}
+
+// keepAnAdmin undoes a change that was made to the user once checkNotLastAdmin passed, if no active admin
+// is left after it. This happens when two admins demote, disable or delete each other at the same time:
+// both pass the check, and then at least one of the changes is undone.
+func (userUC *userUsecase) keepAnAdmin(c context.Context, user *domain.User, undo func() error) error {
+	if user.Role != domain.RoleAdmin || user.Disabled {
+		return nil
+	}
+
+	count, err := userUC.userRepository.CountActiveByRole(c, domain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if err = undo(); err != nil {
+		return err
+	}
+	return domain.ErrLastAdmin
+}
+
+// handOverMembership gives the replacement the role the user has in the project,
+// unless the replacement already has a role at least as high.
+func (userUC *userUsecase) handOverMembership(c context.Context, project *domain.Project, userID string, replacement *domain.User) error {
+	role, err := memberRole(project, userID)
+	if err != nil {
+		return err
+	}
+
+	currentRole, _ := memberRole(project, replacement.UserID.Hex())
+	if domain.ProjectRoleRank(currentRole) >= domain.ProjectRoleRank(role) {
+		return nil
+	}
+
+	return userUC.projectRepository.SetMember(c, project.ID.Hex(), domain.ProjectMember{UserID: replacement.UserID, Role: role})
+}
@@ -10,25 +10,46 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type UserUsecaseTestSuite struct {
 	suite.Suite
-	userUsecase  *userUsecase
-	userMockRepo *mocks.UserRepository
+	userUsecase     *userUsecase
+	userMockRepo    *mocks.UserRepository
+	taskMockRepo    *mocks.TaskRepository
+	projectMockRepo *mocks.ProjectRepository
+	commentMockRepo *mocks.CommentRepository
+	tokenMockRepo   *mocks.PersonalAccessTokenRepository
+	attemptMockRepo *mocks.LoginAttemptRepository
 }
 
 // setupSuite runs once before all tests in the suite
 func (suite *UserUsecaseTestSuite) SetupSuite() {
 	suite.userMockRepo = new(mocks.UserRepository)
+	suite.taskMockRepo = new(mocks.TaskRepository)
+	suite.projectMockRepo = new(mocks.ProjectRepository)
+	suite.commentMockRepo = new(mocks.CommentRepository)
+	suite.tokenMockRepo = new(mocks.PersonalAccessTokenRepository)
+	suite.attemptMockRepo = new(mocks.LoginAttemptRepository)
 	suite.userUsecase = &userUsecase{
-		userRepository: suite.userMockRepo,
-		contextTimeout: time.Second * 2,
+		userRepository:         suite.userMockRepo,
+		taskRepository:         suite.taskMockRepo,
+		projectRepository:      suite.projectMockRepo,
+		commentRepository:      suite.commentMockRepo,
+		tokenRepository:        suite.tokenMockRepo,
+		loginAttemptRepository: suite.attemptMockRepo,
+		contextTimeout:         time.Second * 2,
 	}
 }
 
 func (suite *UserUsecaseTestSuite) TearDownSuite() {
 	suite.userMockRepo.AssertExpectations(suite.T())
+	suite.taskMockRepo.AssertExpectations(suite.T())
+	suite.projectMockRepo.AssertExpectations(suite.T())
+	suite.commentMockRepo.AssertExpectations(suite.T())
+	suite.tokenMockRepo.AssertExpectations(suite.T())
+	suite.attemptMockRepo.AssertExpectations(suite.T())
 }
 
 func (suite *UserUsecaseTestSuite) TestCreate() {
@@ -131,6 +152,177 @@ func (suite *UserUsecaseTestSuite) TestCreateAccessToken() {
 	assert.NotEmpty(suite.T(), token)
 }
 
+func (suite *UserUsecaseTestSuite) TestGetUsers() {
+	filter := domain.UserFilter{Query: "test", Role: "USER"}
+	pagination := domain.NewPagination(1, 10)
+	mockUsers := []domain.User{{UserID: primitive.NewObjectID(), Name: "Test Name", Password: "hashed", Role: "USER"}}
+
+	suite.userMockRepo.On("GetUsers", mock.Anything, filter, pagination).Return(mockUsers, int64(1), nil).Once()
+
+	page, err := suite.userUsecase.GetUsers(context.Background(), domain.UserFilter{Query: "  test ", Role: "USER"}, pagination)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), page.Total)
+	assert.Equal(suite.T(), []domain.UserProfile{mockUsers[0].Profile()}, page.Users)
+}
+
+func (suite *UserUsecaseTestSuite) TestDemote_LastAdmin() {
+	admin := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleAdmin}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, admin.UserID.Hex()).Return(admin, nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(1), nil).Once()
+
+	err := suite.userUsecase.Demote(context.Background(), admin.UserID.Hex())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrLastAdmin)
+}
+
+func (suite *UserUsecaseTestSuite) TestPromote() {
+	userID := primitive.NewObjectID().Hex()
+
+	suite.userMockRepo.On("SetRole", mock.Anything, userID, domain.RoleAdmin).Return(nil).Once()
+
+	err := suite.userUsecase.Promote(context.Background(), userID)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *UserUsecaseTestSuite) TestDemote() {
+	admin := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleAdmin}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, admin.UserID.Hex()).Return(admin, nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(2), nil).Once()
+	suite.userMockRepo.On("SetRole", mock.Anything, admin.UserID.Hex(), domain.RoleUser).Return(nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(1), nil).Once()
+
+	err := suite.userUsecase.Demote(context.Background(), admin.UserID.Hex())
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *UserUsecaseTestSuite) TestDemote_Concurrently() {
+	admin := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleAdmin}
+
+	// the other admin was demoted between the check and the update
+	suite.userMockRepo.On("GetByID", mock.Anything, admin.UserID.Hex()).Return(admin, nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(2), nil).Once()
+	suite.userMockRepo.On("SetRole", mock.Anything, admin.UserID.Hex(), domain.RoleUser).Return(nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(0), nil).Once()
+	suite.userMockRepo.On("SetRole", mock.Anything, admin.UserID.Hex(), domain.RoleAdmin).Return(nil).Once()
+
+	err := suite.userUsecase.Demote(context.Background(), admin.UserID.Hex())
+
+	assert.ErrorIs(suite.T(), err, domain.ErrLastAdmin)
+}
+
+func (suite *UserUsecaseTestSuite) TestSetDisabled_LastAdmin() {
+	admin := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleAdmin}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, admin.UserID.Hex()).Return(admin, nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(1), nil).Once()
+
+	err := suite.userUsecase.SetDisabled(context.Background(), admin.UserID.Hex(), true)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrLastAdmin)
+}
+
+func (suite *UserUsecaseTestSuite) TestSetDisabled_Concurrently() {
+	admin := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleAdmin}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, admin.UserID.Hex()).Return(admin, nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(2), nil).Once()
+	suite.userMockRepo.On("SetDisabled", mock.Anything, admin.UserID.Hex(), true).Return(nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(0), nil).Once()
+	suite.userMockRepo.On("SetDisabled", mock.Anything, admin.UserID.Hex(), false).Return(nil).Once()
+
+	err := suite.userUsecase.SetDisabled(context.Background(), admin.UserID.Hex(), true)
+
+	assert.ErrorIs(suite.T(), err, domain.ErrLastAdmin)
+}
+
+func (suite *UserUsecaseTestSuite) TestSetDisabled() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleUser}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.userMockRepo.On("SetDisabled", mock.Anything, user.UserID.Hex(), true).Return(nil).Once()
+
+	err := suite.userUsecase.SetDisabled(context.Background(), user.UserID.Hex(), true)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *UserUsecaseTestSuite) TestDelete_OrphanLastOwner() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleUser}
+	project := domain.Project{
+		ID:      primitive.NewObjectID(),
+		Members: []domain.ProjectMember{{UserID: user.UserID, Role: domain.ProjectRoleOwner}},
+	}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.projectMockRepo.On("GetByMember", mock.Anything, user.UserID.Hex()).Return([]domain.Project{project}, nil).Once()
+
+	err := suite.userUsecase.Delete(context.Background(), user.UserID.Hex(), domain.TaskPolicyOrphan, "")
+
+	// assert nothing is changed when the user is the last owner of a project
+	assert.ErrorIs(suite.T(), err, domain.ErrLastOwner)
+	suite.taskMockRepo.AssertNotCalled(suite.T(), "UnassignUser", mock.Anything, user.UserID.Hex())
+	suite.userMockRepo.AssertNotCalled(suite.T(), "DeleteUser", mock.Anything, user.UserID.Hex())
+}
+
+func (suite *UserUsecaseTestSuite) TestDelete_Reassign() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Email: "test@example.com", Role: domain.RoleUser}
+	replacement := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleUser}
+	project := domain.Project{
+		ID:      primitive.NewObjectID(),
+		Members: []domain.ProjectMember{{UserID: user.UserID, Role: domain.ProjectRoleOwner}},
+	}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+	suite.userMockRepo.On("GetByID", mock.Anything, replacement.UserID.Hex()).Return(replacement, nil).Once()
+	suite.projectMockRepo.On("GetByMember", mock.Anything, user.UserID.Hex()).Return([]domain.Project{project}, nil).Once()
+	suite.taskMockRepo.On("ReassignUser", mock.Anything, user.UserID.Hex(), replacement.UserID.Hex()).Return(nil).Once()
+	suite.projectMockRepo.On("SetMember", mock.Anything, project.ID.Hex(), domain.ProjectMember{UserID: replacement.UserID, Role: domain.ProjectRoleOwner}).Return(nil).Once()
+	suite.projectMockRepo.On("RemoveMember", mock.Anything, project.ID.Hex(), user.UserID.Hex()).Return(nil).Once()
+	suite.userMockRepo.On("SetDisabled", mock.Anything, user.UserID.Hex(), true).Return(nil).Once()
+	suite.tokenMockRepo.On("DeleteByUserID", mock.Anything, user.UserID).Return(nil).Once()
+	suite.commentMockRepo.On("DeleteByAuthor", mock.Anything, user.UserID.Hex(), mock.AnythingOfType("time.Time")).Return(nil).Once()
+	suite.attemptMockRepo.On("Reset", mock.Anything, "account:"+user.Email).Return(nil).Once()
+	suite.userMockRepo.On("DeleteUser", mock.Anything, user.UserID.Hex()).Return(nil).Once()
+
+	err := suite.userUsecase.Delete(context.Background(), user.UserID.Hex(), domain.TaskPolicyReassign, replacement.UserID.Hex())
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *UserUsecaseTestSuite) TestDelete_Concurrently() {
+	admin := &domain.User{UserID: primitive.NewObjectID(), Email: "admin@example.com", Role: domain.RoleAdmin}
+
+	// the other admin was disabled between the check and the deletion
+	suite.userMockRepo.On("GetByID", mock.Anything, admin.UserID.Hex()).Return(admin, nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(2), nil).Once()
+	suite.projectMockRepo.On("GetByMember", mock.Anything, admin.UserID.Hex()).Return([]domain.Project{}, nil).Once()
+	suite.userMockRepo.On("SetDisabled", mock.Anything, admin.UserID.Hex(), true).Return(nil).Once()
+	suite.userMockRepo.On("CountActiveByRole", mock.Anything, domain.RoleAdmin).Return(int64(0), nil).Once()
+	suite.userMockRepo.On("SetDisabled", mock.Anything, admin.UserID.Hex(), false).Return(nil).Once()
+
+	err := suite.userUsecase.Delete(context.Background(), admin.UserID.Hex(), domain.TaskPolicyOrphan, "")
+
+	// nothing is removed
+	assert.ErrorIs(suite.T(), err, domain.ErrLastAdmin)
+	suite.taskMockRepo.AssertNotCalled(suite.T(), "UnassignUser", mock.Anything, admin.UserID.Hex())
+	suite.userMockRepo.AssertNotCalled(suite.T(), "DeleteUser", mock.Anything, admin.UserID.Hex())
+}
+
+func (suite *UserUsecaseTestSuite) TestDelete_InvalidPolicy() {
+	user := &domain.User{UserID: primitive.NewObjectID(), Role: domain.RoleUser}
+
+	suite.userMockRepo.On("GetByID", mock.Anything, user.UserID.Hex()).Return(user, nil).Once()
+
+	err := suite.userUsecase.Delete(context.Background(), user.UserID.Hex(), "archive", "")
+
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidInput)
+}
+
 // TestUserUsecaseTestSuite runs the test suite
 func TestUserUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(UserUsecaseTestSuite))